@@ -2,10 +2,12 @@ package main
 
 import (
 	"geek-basic-go/webook/internal/events"
+	"geek-basic-go/webook/internal/job"
 	"github.com/gin-gonic/gin"
 )
 
 type App struct {
 	server    *gin.Engine
 	consumers []events.Consumer
+	jobs      []*job.IntervalRunner
 }
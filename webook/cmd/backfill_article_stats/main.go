@@ -0,0 +1,27 @@
+// backfill_article_stats 给长度统计上线之前发表的文章回填字数、阅读时间、图片和代码块数量，
+// 只需要跑一次，中途失败了重新跑就可以：go run ./cmd/backfill_article_stats --config=config/dev.yaml
+package main
+
+import (
+	"context"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"log"
+)
+
+func main() {
+	cFile := pflag.String("config", "config/dev.yaml", "配置文件路径")
+	batchSize := pflag.Int("batch", 100, "每批处理多少篇文章")
+	pflag.Parse()
+	viper.SetConfigFile(*cFile)
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	svc := InitArticleStatsService()
+	cnt, err := svc.Backfill(context.Background(), *batchSize)
+	if err != nil {
+		log.Fatalf("回填文章统计失败，已经更新了 %d 篇: %v", cnt, err)
+	}
+	log.Printf("回填文章统计完成，更新了 %d 篇", cnt)
+}
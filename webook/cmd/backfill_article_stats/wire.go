@@ -0,0 +1,28 @@
+//go:build wireinject
+
+package main
+
+import (
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/internal/repository/cache"
+	"geek-basic-go/webook/internal/repository/dao"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/ioc"
+	"github.com/google/wire"
+)
+
+func InitArticleStatsService() service.ArticleStatsService {
+	wire.Build(
+		ioc.InitDB, ioc.InitRedis, ioc.InitLogger,
+		dao.NewUserDao,
+		ioc.InitBlobStore,
+		ioc.InitArticleDao,
+		// 回填统计用不到搜索，给一个空的索引就可以了
+		dao.NewMemoryArticleSearchDao,
+		wire.Bind(new(dao.ArticleSearchDao), new(*dao.MemoryArticleSearchDao)),
+		cache.NewUserCache, cache.NewArticleRedisCache,
+		repository.NewCachedUserRepository, repository.NewArticleRepository,
+		service.NewArticleStatsService,
+	)
+	return nil
+}
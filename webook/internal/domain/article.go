@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 type Article struct {
 	Id      int64
@@ -10,6 +14,83 @@ type Article struct {
 	Status  ArticleStatus
 	Ctime   time.Time
 	Utime   time.Time
+	// Tags nil 代表不修改标签，空切片代表清空标签
+	Tags []string
+	// Format 决定 Content 怎么渲染成 Html
+	Format ArticleFormat
+	// Html 保存或者发表的时候渲染出来的，已经清理过，可以直接展示
+	Html string
+	// Summary 从渲染之后的纯文本里面截取的摘要
+	Summary string
+	// Version 乐观锁，修改的时候要带上读到的版本号
+	Version int64
+	// Dtime 移到回收站的时间，没有删除的文章是零值
+	Dtime time.Time
+	// Stats 和 Html 一起根据渲染结果统计出来的
+	Stats ArticleStats
+}
+
+// ArticleStats 文章的长度统计，功能上线之前发表的文章要跑一次回填命令
+type ArticleStats struct {
+	WordCnt int
+	// ReadMinutes 估算的阅读时间，有内容的文章至少一分钟
+	ReadMinutes  int
+	ImageCnt     int
+	CodeBlockCnt int
+}
+
+// NextVersion 保存成功之后的版本号，每次保存加一，新建的文章是 1
+func (a Article) NextVersion() int64 {
+	return a.Version + 1
+}
+
+const (
+	// ArticleMaxTags 一篇文章最多多少个标签
+	ArticleMaxTags = 5
+	// ArticleMaxTagLength 一个标签最多多少个字符
+	ArticleMaxTagLength = 32
+)
+
+// NormalizeArticleTags 去掉空白和重复的标签，标签太多或者太长返回 false。nil 还是 nil，代表不修改标签
+func NormalizeArticleTags(tags []string) ([]string, bool) {
+	if tags == nil {
+		return nil, true
+	}
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > ArticleMaxTagLength {
+			return nil, false
+		}
+		// 标签不区分大小写，保留第一次出现的写法
+		key := strings.ToLower(tag)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		res = append(res, tag)
+	}
+	return res, len(res) <= ArticleMaxTags
+}
+
+type ArticleFormat uint8
+
+func (f ArticleFormat) ToUint8() uint8 {
+	return uint8(f)
+}
+
+const (
+	// ArticleFormatPlain 纯文本，老的文章都是这种
+	ArticleFormatPlain ArticleFormat = iota
+	ArticleFormatMarkdown
+)
+
+func (f ArticleFormat) Valid() bool {
+	return f <= ArticleFormatMarkdown
 }
 
 type ArticleStatus uint8
@@ -19,6 +100,10 @@ func (s ArticleStatus) ToUint8() uint8 {
 }
 
 func (a Article) Abstract() string {
+	if a.Summary != "" {
+		return a.Summary
+	}
+	// 还没有渲染过的老数据
 	str := []rune(a.Content)
 	if len(str) > 128 {
 		str = str[:128]
@@ -32,8 +117,41 @@ const (
 	ArticleStatusUnpublished = iota
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	// ArticleStatusScheduled 定时发表，等待到点发表
+	ArticleStatusScheduled
+	// ArticleStatusDeleted 在回收站里面，过了保留期限之后会被彻底删除
+	ArticleStatusDeleted
+	// ArticleStatusPendingReview 发表的时候命中了敏感词，等待审核，读者看不到
+	ArticleStatusPendingReview
 )
 
+// ArticleCursor 按照 (Utime, Id) 倒序翻页时的位置，也就是上一页最后一篇文章，零值代表第一页
+type ArticleCursor struct {
+	Utime time.Time
+	Id    int64
+}
+
+func (c ArticleCursor) IsZero() bool {
+	return c.Id == 0
+}
+
+// Covers 判断 art 是不是排在游标之后，也就是下一页里面的文章
+func (c ArticleCursor) Covers(art Article) bool {
+	if c.IsZero() {
+		return true
+	}
+	cu, au := c.Utime.UnixMilli(), art.Utime.UnixMilli()
+	return au < cu || (au == cu && art.Id < c.Id)
+}
+
+// CursorOf 以 art 作为上一页的最后一篇文章
+func CursorOf(art Article) ArticleCursor {
+	return ArticleCursor{
+		Utime: art.Utime,
+		Id:    art.Id,
+	}
+}
+
 type Author struct {
 	Id   int64
 	Name string
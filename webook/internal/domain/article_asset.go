@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// ArticleAsset 文章里面引用的图片和附件
+type ArticleAsset struct {
+	Id        int64
+	ArticleId int64
+	Author    Author
+	// Key 在对象存储里面的位置，同一篇文章相同的内容只会存一份
+	Key         string
+	ContentType string
+	Size        int64
+	Ctime       time.Time
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// ArticleExport 导出作者所有的文章和历史版本，文章多的时候比较慢，在后台异步执行
+type ArticleExport struct {
+	Id     int64
+	Uid    int64
+	Status ArticleExportStatus
+	// Total 要导出的文章数量，开始执行之后才知道
+	Total int
+	// Done 已经导出的文章数量
+	Done int
+	// Key 导出完成之后 zip 文件在对象存储里面的 key
+	Key string
+	// Reason 导出失败的原因
+	Reason string
+	Ctime  time.Time
+	Utime  time.Time
+}
+
+type ArticleExportStatus uint8
+
+func (s ArticleExportStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	ArticleExportStatusUnknown ArticleExportStatus = iota
+	// ArticleExportStatusPending 等待执行
+	ArticleExportStatusPending
+	// ArticleExportStatusRunning 已经被某个实例抢占，正在导出
+	ArticleExportStatusRunning
+	ArticleExportStatusDone
+	ArticleExportStatusFailed
+)
+
+// ArticleImportReport 导入的结果，试运行的时候只检查，不创建草稿
+type ArticleImportReport struct {
+	DryRun bool
+	Items  []ArticleImportItem
+}
+
+// ArticleImportItem zip 里面的一个 Markdown 文件
+type ArticleImportItem struct {
+	Path   string
+	Title  string
+	Format ArticleFormat
+	Tags   []string
+	// Id 创建出来的草稿，试运行或者不能导入的时候是 0
+	Id int64
+	// Err 不能导入的原因，空的代表可以导入
+	Err string
+}
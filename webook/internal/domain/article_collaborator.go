@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// ArticleCollaborator 文章的协作者，文章的作者本人不在协作者里面，默认就是 owner
+type ArticleCollaborator struct {
+	ArticleId int64
+	// User 协作者，Name 只在需要展示的时候才有
+	User  Author
+	Role  ArticleRole
+	Ctime time.Time
+	Utime time.Time
+}
+
+// ArticleRole 数值越大权限越大，高的角色拥有低的角色的所有权限
+type ArticleRole uint8
+
+func (r ArticleRole) ToUint8() uint8 {
+	return uint8(r)
+}
+
+const (
+	// ArticleRoleNone 和文章没有关系
+	ArticleRoleNone ArticleRole = iota
+	// ArticleRoleViewer 可以看草稿和历史版本
+	ArticleRoleViewer
+	// ArticleRoleEditor 可以修改、发表和撤回
+	ArticleRoleEditor
+	// ArticleRoleOwner 可以删除文章、管理协作者
+	ArticleRoleOwner
+)
+
+func (r ArticleRole) Valid() bool {
+	return r >= ArticleRoleViewer && r <= ArticleRoleOwner
+}
+
+// Has 判断是不是至少有 need 这个角色的权限
+func (r ArticleRole) Has(need ArticleRole) bool {
+	return r >= need
+}
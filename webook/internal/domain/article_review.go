@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// ArticleReview 文章的审核记录，一篇文章只保留最近一次审核
+type ArticleReview struct {
+	ArticleId int64
+	Author    Author
+	// Title 提交审核时候的标题，方便审核的人看
+	Title  string
+	Status ArticleReviewStatus
+	// Hits 命中的敏感词
+	Hits []string
+	// Reason 拒绝的原因
+	Reason string
+	// Reviewer 审核的人，还没有审核是 0
+	Reviewer int64
+	Ctime    time.Time
+	Utime    time.Time
+}
+
+type ArticleReviewStatus uint8
+
+func (s ArticleReviewStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	ArticleReviewStatusUnknown ArticleReviewStatus = iota
+	// ArticleReviewStatusPending 等待审核
+	ArticleReviewStatusPending
+	// ArticleReviewStatusApproved 审核通过，文章已经发表
+	ArticleReviewStatusApproved
+	// ArticleReviewStatusRejected 审核没有通过，文章变回草稿
+	ArticleReviewStatusRejected
+)
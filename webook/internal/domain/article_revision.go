@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// ArticleRevision 文章的历史版本，每次保存或者发表都会生成一个，生成之后不可修改
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	Title     string
+	Content   string
+	Format    ArticleFormat
+	Author    Author
+	// Status 生成这个版本时文章的状态，用来区分是保存草稿还是发表
+	Status ArticleStatus
+	Ctime  time.Time
+}
+
+// ArticleRevisionDiff 两个历史版本之间的逐行对比
+type ArticleRevisionDiff struct {
+	From    ArticleRevision
+	To      ArticleRevision
+	Title   []DiffLine
+	Content []DiffLine
+}
+
+type DiffLine struct {
+	// Op equal, insert 或者 delete
+	Op   string
+	Text string
+}
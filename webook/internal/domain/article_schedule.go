@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// ArticleSchedule 文章的定时发表任务，一篇文章同时只有一个
+type ArticleSchedule struct {
+	Id        int64
+	ArticleId int64
+	Author    Author
+	PublishAt time.Time
+	Status    ArticleScheduleStatus
+	Ctime     time.Time
+	Utime     time.Time
+}
+
+type ArticleScheduleStatus uint8
+
+func (s ArticleScheduleStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	ArticleScheduleStatusUnknown ArticleScheduleStatus = iota
+	// ArticleScheduleStatusWaiting 等待发表
+	ArticleScheduleStatusWaiting
+	// ArticleScheduleStatusRunning 已经被某个实例抢占，正在发表
+	ArticleScheduleStatusRunning
+	ArticleScheduleStatusDone
+	ArticleScheduleStatusCancelled
+)
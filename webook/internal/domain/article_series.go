@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// ArticleSeriesTitleMaxLength 系列的标题最多多少个字符
+	ArticleSeriesTitleMaxLength = 64
+	// ArticleSeriesDescriptionMaxLength 系列的简介最多多少个字符
+	ArticleSeriesDescriptionMaxLength = 256
+)
+
+// ArticleSeries 作者把多篇文章按照顺序组织成一个系列，例如分成好几篇的教程
+type ArticleSeries struct {
+	Id          int64
+	Author      Author
+	Title       string
+	Description string
+	// Articles 按照系列里面的顺序，只在需要展示的时候才有
+	Articles []Article
+	Ctime    time.Time
+	Utime    time.Time
+}
+
+// Normalize 去掉首尾的空白，标题是空的或者太长、简介太长返回 false
+func (s ArticleSeries) Normalize() (ArticleSeries, bool) {
+	s.Title = strings.TrimSpace(s.Title)
+	s.Description = strings.TrimSpace(s.Description)
+	if s.Title == "" || utf8.RuneCountInString(s.Title) > ArticleSeriesTitleMaxLength ||
+		utf8.RuneCountInString(s.Description) > ArticleSeriesDescriptionMaxLength {
+		return s, false
+	}
+	return s, true
+}
+
+// ArticleSeriesNav 读者看一篇文章的时候，它在系列里面的上一篇和下一篇。
+// Series 的 Id 是 0 代表文章不在任何系列里面，Prev 和 Next 的 Id 是 0 代表没有
+type ArticleSeriesNav struct {
+	Series ArticleSeries
+	Prev   Article
+	Next   Article
+}
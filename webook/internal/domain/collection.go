@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CollectionNameMaxLength 收藏夹的名字最多多少个字符
+const CollectionNameMaxLength = 32
+
+// Collection 用户自己建的收藏夹。Id 是 0 的是每个用户都有的默认收藏夹，不需要创建，也不能修改和删除
+type Collection struct {
+	Id   int64
+	Uid  int64
+	Name string
+	// Public 公开的收藏夹别人也能看到里面的内容
+	Public bool
+	Ctime  time.Time
+	Utime  time.Time
+}
+
+// Normalize 去掉首尾的空白，名字是空的或者太长返回 false
+func (c Collection) Normalize() (Collection, bool) {
+	c.Name = strings.TrimSpace(c.Name)
+	return c, c.Name != "" && utf8.RuneCountInString(c.Name) <= CollectionNameMaxLength
+}
+
+// CollectionItem 收藏夹里面的一个资源，Article 只在 Biz 是文章的时候有，只有展示需要的字段
+type CollectionItem struct {
+	Cid     int64
+	Biz     string
+	BizId   int64
+	Article Article
+	// Ctime 收藏的时间
+	Ctime time.Time
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CommentMaxLength 评论最多多少个字符，web、service 和 dao 用的都是这个长度
+const CommentMaxLength = 1024
+
+// Comment 评论，和点赞、收藏一样用 (Biz, BizId) 来定位评论的资源
+type Comment struct {
+	Id    int64
+	Biz   string
+	BizId int64
+	// Commentator 评论的人，Name 只在需要展示的时候才有
+	Commentator Author
+	Content     string
+	// ParentId 回复的是哪条评论，根评论是 0
+	ParentId int64
+	// RootId 所在的根评论，根评论自己是 0
+	RootId int64
+	// ReplyCnt 根评论下面有多少条回复
+	ReplyCnt int64
+	Ctime    time.Time
+	Utime    time.Time
+}
+
+func (c Comment) IsRoot() bool {
+	return c.ParentId == 0
+}
+
+// NormalizeCommentContent 去掉首尾的空白，空的或者太长的评论返回 false
+func NormalizeCommentContent(content string) (string, bool) {
+	content = strings.TrimSpace(content)
+	if content == "" || utf8.RuneCountInString(content) > CommentMaxLength {
+		return "", false
+	}
+	return content, true
+}
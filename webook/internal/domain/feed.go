@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Feed 订阅源，Author 是零值的时候代表全站最新的文章
+type Feed struct {
+	Author   Author
+	Articles []Article
+	// Utime 最近一篇文章的更新时间，没有文章的时候是零值
+	Utime time.Time
+}
+
+// NewFeed 文章要按照更新时间倒序
+func NewFeed(author Author, arts []Article) Feed {
+	f := Feed{Author: author, Articles: arts}
+	if len(arts) > 0 {
+		f.Utime = arts[0].Utime
+	}
+	return f
+}
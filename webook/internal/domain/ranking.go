@@ -0,0 +1,8 @@
+package domain
+
+// HotArticle 热榜上的一篇文章，Intr 是计算分数的时候的互动数据
+type HotArticle struct {
+	Article Article
+	Intr    Interactive
+	Score   float64
+}
@@ -15,6 +15,8 @@ const (
 // Article 相关
 const (
 	// ArticleInvalidInput 文章模块的统一的错误码
-	ArticleInvalidInput        = 402001
+	ArticleInvalidInput = 402001
+	// ArticleVersionConflict 文章已经在别的地方修改过了，保存的版本号不是最新的
+	ArticleVersionConflict     = 402002
 	ArticleInternalServerError = 502001
 )
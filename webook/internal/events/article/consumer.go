@@ -9,6 +9,12 @@ import (
 	"time"
 )
 
+const (
+	// readEventBatchSize 和 readEventBatchInterval 哪个先到就处理一批
+	readEventBatchSize     = 100
+	readEventBatchInterval = time.Second
+)
+
 type InteractiveReadEventConsumer struct {
 	repo   repository.InteractiveRepository
 	client sarama.Client
@@ -21,7 +27,8 @@ func (i *InteractiveReadEventConsumer) Start() error {
 		return err
 	}
 	go func() {
-		er := cg.Consume(context.Background(), []string{TopicReadEvent}, saramax.NewHandler[ReadEvent](i.Consume, i.l))
+		er := cg.Consume(context.Background(), []string{TopicReadEvent}, saramax.NewBatchHandler[ReadEvent](i.BatchConsume,
+			readEventBatchSize, readEventBatchInterval, i.l))
 		if er != nil {
 			i.l.Error("退出消费", logger.Error(er))
 		}
@@ -43,3 +50,25 @@ func (i *InteractiveReadEventConsumer) Consume(msg *sarama.ConsumerMessage, even
 	defer cancel()
 	return i.repo.IncrReadCnt(ctx, "article", event.Aid)
 }
+
+// BatchConsume 同一篇文章的阅读数先合并，再一次性写数据库和缓存
+func (i *InteractiveReadEventConsumer) BatchConsume(msgs []*sarama.ConsumerMessage, events []ReadEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	idx := make(map[int64]int, len(events))
+	bizs := make([]string, 0, len(events))
+	ids := make([]int64, 0, len(events))
+	cnts := make([]int64, 0, len(events))
+	for _, evt := range events {
+		j, ok := idx[evt.Aid]
+		if ok {
+			cnts[j]++
+			continue
+		}
+		idx[evt.Aid] = len(ids)
+		bizs = append(bizs, "article")
+		ids = append(ids, evt.Aid)
+		cnts = append(cnts, 1)
+	}
+	return i.repo.BatchIncrReadCnt(ctx, bizs, ids, cnts)
+}
@@ -0,0 +1,45 @@
+package article
+
+import (
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestInteractiveReadEventConsumer_BatchConsume(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) repository.InteractiveRepository
+		events []ReadEvent
+
+		wantErr error
+	}{
+		{
+			name: "同一篇文章合并",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().BatchIncrReadCnt(gomock.Any(),
+					[]string{"article", "article", "article"},
+					[]int64{1, 2, 3},
+					[]int64{3, 1, 2}).Return(nil)
+				return repo
+			},
+			events: []ReadEvent{
+				{Aid: 1, Uid: 11}, {Aid: 2, Uid: 11}, {Aid: 1, Uid: 12},
+				{Aid: 3, Uid: 12}, {Aid: 1, Uid: 13}, {Aid: 3, Uid: 13},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewInteractiveReadEventConsumer(tc.mock(ctrl), nil, logger.NewNopLogger())
+			err := c.BatchConsume(nil, tc.events)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
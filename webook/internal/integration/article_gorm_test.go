@@ -3,6 +3,7 @@ package integration
 import (
 	"bytes"
 	"encoding/json"
+	"geek-basic-go/webook/internal/errs"
 	"geek-basic-go/webook/internal/integration/startup"
 	"geek-basic-go/webook/internal/repository/dao"
 	ijwt "geek-basic-go/webook/internal/web/jwt"
@@ -52,7 +53,7 @@ func (s *ArticleHandlerSuite) TestArticle_Publish() {
 		req   Article
 		// 预期响应
 		wantCode   int
-		wantResult Result[int64]
+		wantResult Result[ArticleSaved]
 	}{
 		{
 			name: "新建帖子并发表",
@@ -81,8 +82,8 @@ func (s *ArticleHandlerSuite) TestArticle_Publish() {
 				Content: "随便试试",
 			},
 			wantCode: 200,
-			wantResult: Result[int64]{
-				Data: 1,
+			wantResult: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 1, Version: 1},
 			},
 		},
 		{
@@ -122,10 +123,11 @@ func (s *ArticleHandlerSuite) TestArticle_Publish() {
 				Id:      2,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: 200,
-			wantResult: Result[int64]{
-				Data: 2,
+			wantResult: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 2, Version: 2},
 			},
 		},
 		{
@@ -168,10 +170,11 @@ func (s *ArticleHandlerSuite) TestArticle_Publish() {
 				Id:      3,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: 200,
-			wantResult: Result[int64]{
-				Data: 3,
+			wantResult: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 3, Version: 2},
 			},
 		},
 		{
@@ -222,11 +225,12 @@ func (s *ArticleHandlerSuite) TestArticle_Publish() {
 				Id:      4,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: 200,
-			wantResult: Result[int64]{
-				Code: 5,
-				Msg:  "系统错误",
+			wantResult: Result[ArticleSaved]{
+				Code: errs.ArticleInvalidInput,
+				Msg:  "没有权限修改这篇文章",
 			},
 		},
 	}
@@ -249,8 +253,8 @@ func (s *ArticleHandlerSuite) TestArticle_Publish() {
 				return
 			}
 			// 反序列化为结果
-			// 利用泛型来限定结果必须是 int64
-			var result Result[int64]
+			// 利用泛型来限定结果必须是 ArticleSaved
+			var result Result[ArticleSaved]
 			err = json.Unmarshal(recorder.Body.Bytes(), &result)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.wantResult, result)
@@ -269,7 +273,7 @@ func (s *ArticleHandlerSuite) TestEdit() {
 		//前端会传一个article json
 		art        Article
 		wantedCode int
-		wantedRes  Result[int64]
+		wantedRes  Result[ArticleSaved]
 	}{
 		{
 			name: "新建帖子",
@@ -291,6 +295,7 @@ func (s *ArticleHandlerSuite) TestEdit() {
 					Content:  "我的内容",
 					AuthorId: 123,
 					Status:   1,
+					Version:  1,
 				}, art)
 			},
 			art: Article{
@@ -298,8 +303,8 @@ func (s *ArticleHandlerSuite) TestEdit() {
 				Content: "我的内容",
 			},
 			wantedCode: http.StatusOK,
-			wantedRes: Result[int64]{
-				Data: 1,
+			wantedRes: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 1, Version: 1},
 			},
 		},
 		{
@@ -330,18 +335,20 @@ func (s *ArticleHandlerSuite) TestEdit() {
 					Content:  "我的内容",
 					AuthorId: 123,
 					// 修改之后是未发表状态
-					Status: 1,
-					Ctime:  789,
+					Status:  1,
+					Ctime:   789,
+					Version: 2,
 				}, art)
 			},
 			art: Article{
 				Id:      2,
 				Title:   "我的标题",
 				Content: "我的内容",
+				Version: 1,
 			},
 			wantedCode: http.StatusOK,
-			wantedRes: Result[int64]{
-				Data: 2,
+			wantedRes: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 2, Version: 2},
 			},
 		},
 		{
@@ -371,17 +378,19 @@ func (s *ArticleHandlerSuite) TestEdit() {
 					Status:   1,
 					Utime:    456,
 					Ctime:    789,
+					Version:  1,
 				}, art)
 			},
 			art: Article{
 				Id:      3,
 				Title:   "我的标题",
 				Content: "我的内容",
+				Version: 1,
 			},
 			wantedCode: http.StatusOK,
-			wantedRes: Result[int64]{
-				Code: 5,
-				Msg:  "系统错误",
+			wantedRes: Result[ArticleSaved]{
+				Code: errs.ArticleInvalidInput,
+				Msg:  "没有权限修改这篇文章",
 			},
 		},
 	}
@@ -409,7 +418,7 @@ func (s *ArticleHandlerSuite) TestEdit() {
 			if tc.wantedCode != http.StatusOK {
 				return
 			}
-			var res Result[int64]
+			var res Result[ArticleSaved]
 			err = json.NewDecoder(recorder.Body).Decode(&res)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.wantedRes, res)
@@ -430,4 +439,10 @@ type Article struct {
 	Id      int64
 	Title   string
 	Content string
+	Version int64
+}
+
+type ArticleSaved struct {
+	Id      int64 `json:"id"`
+	Version int64 `json:"version"`
 }
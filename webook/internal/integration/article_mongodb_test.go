@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"geek-basic-go/webook/internal/errs"
 	"geek-basic-go/webook/internal/integration/startup"
 	"geek-basic-go/webook/internal/repository/dao"
 	ijwt "geek-basic-go/webook/internal/web/jwt"
@@ -66,7 +67,7 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 
 		// 预期响应
 		wantCode   int
-		wantResult Result[int64]
+		wantResult Result[ArticleSaved]
 	}{
 		{
 			name: "新建帖子并发表",
@@ -98,8 +99,8 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 				Content: "随便试试",
 			},
 			wantCode: 200,
-			wantResult: Result[int64]{
-				Data: 1,
+			wantResult: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 1, Version: 1},
 			},
 		},
 		{
@@ -116,6 +117,7 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 					Ctime:    456,
 					Utime:    234,
 					AuthorId: 123,
+					Version:  1,
 				})
 				assert.NoError(t, err)
 			},
@@ -146,10 +148,11 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 				Id:      2,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: 200,
-			wantResult: Result[int64]{
-				Data: 2,
+			wantResult: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 2, Version: 2},
 			},
 		},
 		{
@@ -164,6 +167,7 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 					Ctime:    456,
 					Utime:    234,
 					AuthorId: 123,
+					Version:  1,
 				}
 				_, err := s.col.InsertOne(ctx, &art)
 				assert.NoError(t, err)
@@ -200,10 +204,11 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 				Id:      3,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: 200,
-			wantResult: Result[int64]{
-				Data: 3,
+			wantResult: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 3, Version: 2},
 			},
 		},
 		{
@@ -219,6 +224,7 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 					Utime:   234,
 					// 注意。这个 AuthorID 我们设置为另外一个人的ID
 					AuthorId: 789,
+					Version:  1,
 				}
 				_, err := s.col.InsertOne(ctx, &art)
 				assert.NoError(t, err)
@@ -229,6 +235,7 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 					Ctime:    456,
 					Utime:    234,
 					AuthorId: 789,
+					Version:  1,
 				})
 				_, err = s.col.InsertOne(ctx, &part)
 				assert.NoError(t, err)
@@ -262,11 +269,12 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 				Id:      4,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: 200,
-			wantResult: Result[int64]{
-				Code: 5,
-				Msg:  "系统错误",
+			wantResult: Result[ArticleSaved]{
+				Code: errs.ArticleInvalidInput,
+				Msg:  "没有权限修改这篇文章",
 			},
 		},
 	}
@@ -291,12 +299,13 @@ func (s *ArticleMongoDBHandlerSuite) TestArticle_Publish() {
 				return
 			}
 			// 反序列化为结果
-			// 利用泛型来限定结果必须是 int64
-			var result Result[int64]
+			// 利用泛型来限定结果必须是 ArticleSaved
+			var result Result[ArticleSaved]
 			err = json.Unmarshal(recorder.Body.Bytes(), &result)
 			assert.NoError(t, err)
-			if tc.wantResult.Data > 0 {
-				assert.True(t, result.Data > 0)
+			if tc.wantResult.Data.Id > 0 {
+				assert.True(t, result.Data.Id > 0)
+				assert.Equal(t, tc.wantResult.Data.Version, result.Data.Version)
 			}
 			tc.after(t)
 		})
@@ -313,7 +322,7 @@ func (s *ArticleMongoDBHandlerSuite) TestEdit() {
 		//前端会传一个article json
 		art        Article
 		wantedCode int
-		wantedRes  Result[int64]
+		wantedRes  Result[ArticleSaved]
 	}{
 		{
 			name: "新建帖子",
@@ -340,6 +349,7 @@ func (s *ArticleMongoDBHandlerSuite) TestEdit() {
 					Content:  "我的内容",
 					AuthorId: 123,
 					Status:   1,
+					Version:  1,
 				}, art)
 			},
 			art: Article{
@@ -347,8 +357,8 @@ func (s *ArticleMongoDBHandlerSuite) TestEdit() {
 				Content: "我的内容",
 			},
 			wantedCode: http.StatusOK,
-			wantedRes: Result[int64]{
-				Data: 1,
+			wantedRes: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 1, Version: 1},
 			},
 		},
 		{
@@ -362,9 +372,10 @@ func (s *ArticleMongoDBHandlerSuite) TestEdit() {
 					Content:  "我的内容",
 					AuthorId: 123,
 					// 已经发表的帖子
-					Status: 2,
-					Utime:  456,
-					Ctime:  789,
+					Status:  2,
+					Utime:   456,
+					Ctime:   789,
+					Version: 1,
 				})
 				assert.NoError(t, err)
 			},
@@ -383,18 +394,20 @@ func (s *ArticleMongoDBHandlerSuite) TestEdit() {
 					Content:  "我的内容",
 					AuthorId: 123,
 					// 修改之后是未发表状态
-					Status: 1,
-					Ctime:  789,
+					Status:  1,
+					Ctime:   789,
+					Version: 2,
 				}, art)
 			},
 			art: Article{
 				Id:      2,
 				Title:   "我的标题",
 				Content: "我的内容",
+				Version: 1,
 			},
 			wantedCode: http.StatusOK,
-			wantedRes: Result[int64]{
-				Data: 2,
+			wantedRes: Result[ArticleSaved]{
+				Data: ArticleSaved{Id: 2, Version: 2},
 			},
 		},
 		{
@@ -410,6 +423,7 @@ func (s *ArticleMongoDBHandlerSuite) TestEdit() {
 					Status:   1,
 					Utime:    456,
 					Ctime:    789,
+					Version:  1,
 				})
 				assert.NoError(t, err)
 			},
@@ -428,17 +442,19 @@ func (s *ArticleMongoDBHandlerSuite) TestEdit() {
 					Status:   1,
 					Utime:    456,
 					Ctime:    789,
+					Version:  1,
 				}, art)
 			},
 			art: Article{
 				Id:      3,
 				Title:   "我的标题",
 				Content: "我的内容",
+				Version: 1,
 			},
 			wantedCode: http.StatusOK,
-			wantedRes: Result[int64]{
-				Code: 5,
-				Msg:  "系统错误",
+			wantedRes: Result[ArticleSaved]{
+				Code: errs.ArticleInvalidInput,
+				Msg:  "没有权限修改这篇文章",
 			},
 		},
 	}
@@ -466,11 +482,12 @@ func (s *ArticleMongoDBHandlerSuite) TestEdit() {
 			if tc.wantedCode != http.StatusOK {
 				return
 			}
-			var res Result[int64]
+			var res Result[ArticleSaved]
 			err = json.NewDecoder(recorder.Body).Decode(&res)
 			assert.NoError(t, err)
-			if tc.wantedRes.Data > 0 {
-				assert.True(t, res.Data > 0)
+			if tc.wantedRes.Data.Id > 0 {
+				assert.True(t, res.Data.Id > 0)
+				assert.Equal(t, tc.wantedRes.Data.Version, res.Data.Version)
 			}
 		})
 	}
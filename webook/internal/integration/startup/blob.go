@@ -0,0 +1,12 @@
+package startup
+
+import (
+	"geek-basic-go/webook/pkg/blobx"
+	"os"
+	"path/filepath"
+)
+
+// InitBlobStore 测试的时候存在本地的临时目录
+func InitBlobStore() blobx.BlobStore {
+	return blobx.NewLocalBlobStore(filepath.Join(os.TempDir(), "webook_blob"), "")
+}
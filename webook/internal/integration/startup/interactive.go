@@ -0,0 +1,17 @@
+package startup
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/service"
+)
+
+// InitBizRegistry 集成测试直接往库里面写计数，不校验资源存不存在
+func InitBizRegistry() *service.BizRegistry {
+	r := service.NewBizRegistry()
+	exist := service.InteractiveBizFunc(func(ctx context.Context, id int64) (int64, error) {
+		return 0, nil
+	})
+	r.Register("test", exist)
+	r.Register("article", exist)
+	return r
+}
@@ -32,13 +32,31 @@ var articleSvcProvider = wire.NewSet(
 	repository.NewArticleRepository,
 	cache.NewArticleRedisCache,
 	dao.NewGormDBArticleDao,
+	ioc.InitArticleSearchDao,
+	wire.Bind(new(dao.ArticleSearchDao), new(*dao.MemoryArticleSearchDao)),
+	dao.NewGormArticleRevisionDao,
+	repository.NewArticleRevisionRepository,
+	dao.NewGormArticleScheduleDao,
+	repository.NewArticleScheduleRepository,
+	dao.NewGormArticleCollaboratorDao,
+	repository.NewArticleCollaboratorRepository,
+	dao.NewGormArticleReviewDao,
+	repository.NewArticleReviewRepository,
+	dao.NewGormArticleSeriesDao,
+	repository.NewArticleSeriesRepository,
+	ioc.InitSensitiveFilter,
 	service.NewArticleService,
+	service.NewArticleCollaboratorService,
+	service.NewArticleSeriesService,
 )
 
 var interactiveSvcSet = wire.NewSet(
 	dao.NewGormInteractiveDao,
 	cache.NewInteractiveRedisCache,
 	repository.NewCachedInteractiveRepository,
+	dao.NewGormCollectionDao,
+	repository.NewCollectionRepository,
+	InitBizRegistry,
 	service.NewInteractiveServiceImpl,
 )
 
@@ -63,19 +81,70 @@ func InitWebServer() *gin.Engine {
 		web.NewArticleHandler,
 		ijwt.NewRedisJwtHandler,
 		web.NewOAuth2WechatHandler,
+		// 文章的图片和附件
+		InitBlobStore,
+		dao.NewGormArticleAssetDao,
+		repository.NewArticleAssetRepository,
+		service.NewArticleAssetService,
+		web.NewArticleAssetHandler,
+		web.NewArticleCollaboratorHandler,
+		// 评论
+		dao.NewGormCommentDao,
+		repository.NewCachedCommentRepository,
+		service.NewCommentService,
+		web.NewCommentHandler,
+		// 审核
+		service.NewArticleReviewService,
+		ioc.InitArticleReviewHandler,
+		// 订阅源
+		service.NewFeedService,
+		ioc.InitFeedHandler,
+		// 系列
+		web.NewArticleSeriesHandler,
+		// 导出和导入
+		dao.NewGormArticleExportDao,
+		repository.NewArticleExportRepository,
+		service.NewArticleBackupService,
+		web.NewArticleBackupHandler,
+		// 热榜
+		cache.NewRankingRedisCache,
+		cache.NewRankingLocalCache,
+		repository.NewCachedRankingRepository,
+		service.NewBatchRankingService,
+		web.NewRankingHandler,
+		// 收藏夹
+		service.NewCollectionService,
+		web.NewCollectionHandler,
+		// 通用的点赞、收藏
+		web.NewInteractiveHandler,
 		ioc.InitWebServer,
 	)
 	return gin.Default()
 }
 
-func InitArticleHandler(dao dao.ArticleDao) *web.ArticleHandler {
+func InitArticleHandler(artDao dao.ArticleDao) *web.ArticleHandler {
 	wire.Build(
 		thirdPartySet,
 		userSvcProvider,
 		interactiveSvcSet,
 		cache.NewArticleRedisCache,
 		repository.NewArticleRepository,
+		ioc.InitArticleSearchDao,
+		wire.Bind(new(dao.ArticleSearchDao), new(*dao.MemoryArticleSearchDao)),
+		dao.NewGormArticleRevisionDao,
+		repository.NewArticleRevisionRepository,
+		dao.NewGormArticleScheduleDao,
+		repository.NewArticleScheduleRepository,
+		dao.NewGormArticleCollaboratorDao,
+		repository.NewArticleCollaboratorRepository,
+		dao.NewGormArticleReviewDao,
+		repository.NewArticleReviewRepository,
+		dao.NewGormArticleSeriesDao,
+		repository.NewArticleSeriesRepository,
+		ioc.InitSensitiveFilter,
 		service.NewArticleService,
+		service.NewArticleCollaboratorService,
+		service.NewArticleSeriesService,
 		article.NewSaramaSyncProducer,
 		web.NewArticleHandler,
 	)
@@ -84,5 +153,5 @@ func InitArticleHandler(dao dao.ArticleDao) *web.ArticleHandler {
 
 func InitInteractiveService() service.InteractiveService {
 	wire.Build(thirdPartySet, interactiveSvcSet)
-	return service.NewInteractiveServiceImpl(nil)
+	return service.NewInteractiveServiceImpl(nil, nil, nil)
 }
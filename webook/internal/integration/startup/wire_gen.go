@@ -40,17 +40,61 @@ func InitWebServer() *gin.Engine {
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
 	articleDao := dao.NewGormDBArticleDao(db)
 	articleCache := cache.NewArticleRedisCache(cmdable)
-	articleRepository := repository.NewArticleRepository(articleDao, userRepository, articleCache)
+	memoryArticleSearchDao := ioc.InitArticleSearchDao(articleDao, loggerV1)
+	articleRepository := repository.NewArticleRepository(articleDao, memoryArticleSearchDao, userRepository, articleCache)
 	client := InitSaramaClient()
 	syncProducer := InitSyncProducer(client)
 	producer := article.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer)
+	articleRevisionDao := dao.NewGormArticleRevisionDao(db)
+	articleRevisionRepository := repository.NewArticleRevisionRepository(articleRevisionDao)
+	articleScheduleDao := dao.NewGormArticleScheduleDao(db)
+	articleScheduleRepository := repository.NewArticleScheduleRepository(articleScheduleDao)
 	interactiveDao := dao.NewGormInteractiveDao(db)
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDao, loggerV1, interactiveCache)
-	interactiveService := service.NewInteractiveServiceImpl(interactiveRepository)
-	articleHandler := web.NewArticleHandler(articleService, interactiveService, loggerV1)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
+	articleCollaboratorDao := dao.NewGormArticleCollaboratorDao(db)
+	articleCollaboratorRepository := repository.NewArticleCollaboratorRepository(articleCollaboratorDao)
+	articleReviewDao := dao.NewGormArticleReviewDao(db)
+	articleReviewRepository := repository.NewArticleReviewRepository(articleReviewDao)
+	articleSeriesDao := dao.NewGormArticleSeriesDao(db)
+	articleSeriesRepository := repository.NewArticleSeriesRepository(articleSeriesDao)
+	filter := ioc.InitSensitiveFilter(loggerV1)
+	articleService := service.NewArticleService(articleRepository, articleRevisionRepository, articleScheduleRepository, interactiveRepository, articleCollaboratorRepository, articleReviewRepository, articleSeriesRepository, filter, producer, loggerV1)
+	collectionDao := dao.NewGormCollectionDao(db)
+	collectionRepository := repository.NewCollectionRepository(collectionDao, interactiveCache, loggerV1)
+	bizRegistry := InitBizRegistry()
+	interactiveService := service.NewInteractiveServiceImpl(interactiveRepository, collectionRepository, bizRegistry)
+	articleCollaboratorService := service.NewArticleCollaboratorService(articleCollaboratorRepository, articleRepository, userRepository, loggerV1)
+	articleSeriesService := service.NewArticleSeriesService(articleSeriesRepository, articleRepository, userRepository, loggerV1)
+	articleHandler := web.NewArticleHandler(articleService, interactiveService, articleCollaboratorService, userService, articleSeriesService, loggerV1)
+	blobStore := InitBlobStore()
+	articleAssetDao := dao.NewGormArticleAssetDao(db)
+	articleAssetRepository := repository.NewArticleAssetRepository(articleAssetDao, blobStore)
+	articleAssetService := service.NewArticleAssetService(articleAssetRepository, articleRepository, articleCollaboratorRepository, loggerV1)
+	articleAssetHandler := web.NewArticleAssetHandler(articleAssetService, loggerV1)
+	articleCollaboratorHandler := web.NewArticleCollaboratorHandler(articleCollaboratorService, loggerV1)
+	commentDao := dao.NewGormCommentDao(db)
+	commentRepository := repository.NewCachedCommentRepository(commentDao, interactiveCache, loggerV1)
+	commentService := service.NewCommentService(commentRepository, articleRepository, userRepository, loggerV1)
+	commentHandler := web.NewCommentHandler(commentService, loggerV1)
+	articleReviewService := service.NewArticleReviewService(articleReviewRepository, articleRepository, loggerV1)
+	articleReviewHandler := ioc.InitArticleReviewHandler(articleReviewService, loggerV1)
+	feedService := service.NewFeedService(articleRepository, userRepository, loggerV1)
+	feedHandler := ioc.InitFeedHandler(feedService, loggerV1)
+	articleSeriesHandler := web.NewArticleSeriesHandler(articleSeriesService, loggerV1)
+	articleExportDao := dao.NewGormArticleExportDao(db)
+	articleExportRepository := repository.NewArticleExportRepository(articleExportDao, blobStore)
+	articleBackupService := service.NewArticleBackupService(articleExportRepository, articleRepository, articleRevisionRepository, articleService, loggerV1)
+	articleBackupHandler := web.NewArticleBackupHandler(articleBackupService, loggerV1)
+	rankingRedisCache := cache.NewRankingRedisCache(cmdable)
+	rankingLocalCache := cache.NewRankingLocalCache()
+	rankingRepository := repository.NewCachedRankingRepository(rankingRedisCache, rankingLocalCache, loggerV1)
+	rankingService := service.NewBatchRankingService(articleRepository, interactiveRepository, rankingRepository, loggerV1)
+	rankingHandler := web.NewRankingHandler(rankingService, loggerV1)
+	collectionService := service.NewCollectionService(collectionRepository, articleRepository, loggerV1)
+	collectionHandler := web.NewCollectionHandler(collectionService, loggerV1)
+	interactiveHandler := web.NewInteractiveHandler(interactiveService, loggerV1)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler, articleAssetHandler, articleCollaboratorHandler, commentHandler, articleReviewHandler, feedHandler, articleSeriesHandler, articleBackupHandler, rankingHandler, collectionHandler, interactiveHandler)
 	return engine
 }
 
@@ -61,17 +105,35 @@ func InitArticleHandler(dao2 dao.ArticleDao) *web.ArticleHandler {
 	userCache := cache.NewUserCache(cmdable)
 	userRepository := repository.NewCachedUserRepository(userDao, userCache)
 	articleCache := cache.NewArticleRedisCache(cmdable)
-	articleRepository := repository.NewArticleRepository(dao2, userRepository, articleCache)
+	loggerV1 := InitLogger()
+	memoryArticleSearchDao := ioc.InitArticleSearchDao(dao2, loggerV1)
+	articleRepository := repository.NewArticleRepository(dao2, memoryArticleSearchDao, userRepository, articleCache)
+	articleRevisionDao := dao.NewGormArticleRevisionDao(db)
+	articleRevisionRepository := repository.NewArticleRevisionRepository(articleRevisionDao)
+	articleScheduleDao := dao.NewGormArticleScheduleDao(db)
+	articleScheduleRepository := repository.NewArticleScheduleRepository(articleScheduleDao)
 	client := InitSaramaClient()
 	syncProducer := InitSyncProducer(client)
 	producer := article.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer)
 	interactiveDao := dao.NewGormInteractiveDao(db)
-	loggerV1 := InitLogger()
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDao, loggerV1, interactiveCache)
-	interactiveService := service.NewInteractiveServiceImpl(interactiveRepository)
-	articleHandler := web.NewArticleHandler(articleService, interactiveService, loggerV1)
+	articleCollaboratorDao := dao.NewGormArticleCollaboratorDao(db)
+	articleCollaboratorRepository := repository.NewArticleCollaboratorRepository(articleCollaboratorDao)
+	articleReviewDao := dao.NewGormArticleReviewDao(db)
+	articleReviewRepository := repository.NewArticleReviewRepository(articleReviewDao)
+	articleSeriesDao := dao.NewGormArticleSeriesDao(db)
+	articleSeriesRepository := repository.NewArticleSeriesRepository(articleSeriesDao)
+	filter := ioc.InitSensitiveFilter(loggerV1)
+	articleService := service.NewArticleService(articleRepository, articleRevisionRepository, articleScheduleRepository, interactiveRepository, articleCollaboratorRepository, articleReviewRepository, articleSeriesRepository, filter, producer, loggerV1)
+	collectionDao := dao.NewGormCollectionDao(db)
+	collectionRepository := repository.NewCollectionRepository(collectionDao, interactiveCache, loggerV1)
+	bizRegistry := InitBizRegistry()
+	interactiveService := service.NewInteractiveServiceImpl(interactiveRepository, collectionRepository, bizRegistry)
+	articleCollaboratorService := service.NewArticleCollaboratorService(articleCollaboratorRepository, articleRepository, userRepository, loggerV1)
+	userService := service.NewUserService(userRepository)
+	articleSeriesService := service.NewArticleSeriesService(articleSeriesRepository, articleRepository, userRepository, loggerV1)
+	articleHandler := web.NewArticleHandler(articleService, interactiveService, articleCollaboratorService, userService, articleSeriesService, loggerV1)
 	return articleHandler
 }
 
@@ -82,7 +144,10 @@ func InitInteractiveService() service.InteractiveService {
 	cmdable := InitRedis()
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDao, loggerV1, interactiveCache)
-	interactiveService := service.NewInteractiveServiceImpl(interactiveRepository)
+	collectionDao := dao.NewGormCollectionDao(db)
+	collectionRepository := repository.NewCollectionRepository(collectionDao, interactiveCache, loggerV1)
+	bizRegistry := InitBizRegistry()
+	interactiveService := service.NewInteractiveServiceImpl(interactiveRepository, collectionRepository, bizRegistry)
 	return interactiveService
 }
 
@@ -98,6 +163,6 @@ var thirdPartySet = wire.NewSet(
 
 var userSvcProvider = wire.NewSet(dao.NewUserDao, cache.NewUserCache, repository.NewCachedUserRepository, service.NewUserService)
 
-var articleSvcProvider = wire.NewSet(repository.NewArticleRepository, cache.NewArticleRedisCache, dao.NewGormDBArticleDao, service.NewArticleService)
+var articleSvcProvider = wire.NewSet(repository.NewArticleRepository, cache.NewArticleRedisCache, dao.NewGormDBArticleDao, ioc.InitArticleSearchDao, wire.Bind(new(dao.ArticleSearchDao), new(*dao.MemoryArticleSearchDao)), dao.NewGormArticleRevisionDao, repository.NewArticleRevisionRepository, dao.NewGormArticleScheduleDao, repository.NewArticleScheduleRepository, service.NewArticleService)
 
-var interactiveSvcSet = wire.NewSet(dao.NewGormInteractiveDao, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, service.NewInteractiveServiceImpl)
+var interactiveSvcSet = wire.NewSet(dao.NewGormInteractiveDao, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, dao.NewGormCollectionDao, repository.NewCollectionRepository, InitBizRegistry, service.NewInteractiveServiceImpl)
@@ -0,0 +1,63 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/pkg/logger"
+	"geek-basic-go/webook/pkg/rlock"
+	"time"
+)
+
+// ArticleAssetGCJob 回收没有被文章引用的图片和附件，只有拿到分布式锁的实例会回收
+type ArticleAssetGCJob struct {
+	svc service.ArticleAssetService
+	// grace 刚上传的文件作者可能还没保存文章，这段时间内不回收
+	grace  time.Duration
+	batch  int
+	client *rlock.Client
+	key    string
+	// expiration 锁的过期时间，要比 IntervalRunner 的超时时间长
+	expiration time.Duration
+	l          logger.LoggerV1
+}
+
+func NewArticleAssetGCJob(svc service.ArticleAssetService, client *rlock.Client, l logger.LoggerV1) *ArticleAssetGCJob {
+	return &ArticleAssetGCJob{
+		svc:        svc,
+		grace:      time.Hour * 24,
+		batch:      100,
+		client:     client,
+		key:        "job:article:asset_gc",
+		expiration: time.Minute * 15,
+		l:          l,
+	}
+}
+
+func (j *ArticleAssetGCJob) Name() string {
+	return "article_asset_gc"
+}
+
+func (j *ArticleAssetGCJob) Run(ctx context.Context) error {
+	lock, err := j.client.TryLock(ctx, j.key, j.expiration)
+	if errors.Is(err, rlock.ErrLockHeld) {
+		// 别的实例正在回收
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Unlock(ctx)
+		if er != nil {
+			j.l.Warn("释放回收文章资源的分布式锁失败", logger.Error(er))
+		}
+	}()
+	cnt, err := j.svc.GC(ctx, time.Now().Add(-j.grace), j.batch)
+	if cnt > 0 {
+		j.l.Info("回收文章资源", logger.Int("cnt", cnt))
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package job
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/pkg/logger"
+)
+
+// ArticleExportJob 在后台执行用户的文章导出
+type ArticleExportJob struct {
+	svc service.ArticleBackupService
+	// batch 每次最多执行多少个导出
+	batch int
+	l     logger.LoggerV1
+}
+
+func NewArticleExportJob(svc service.ArticleBackupService, l logger.LoggerV1) *ArticleExportJob {
+	return &ArticleExportJob{
+		svc:   svc,
+		batch: 5,
+		l:     l,
+	}
+}
+
+func (j *ArticleExportJob) Name() string {
+	return "article_export"
+}
+
+func (j *ArticleExportJob) Run(ctx context.Context) error {
+	cnt, err := j.svc.RunExports(ctx, j.batch)
+	if err != nil {
+		return err
+	}
+	if cnt > 0 {
+		j.l.Info("导出文章", logger.Int("cnt", cnt))
+	}
+	return nil
+}
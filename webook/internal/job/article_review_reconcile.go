@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/pkg/logger"
+	"geek-basic-go/webook/pkg/rlock"
+	"time"
+)
+
+// ArticleReviewReconcileJob 发表的时候提交审核失败，文章会卡在等待审核，这里定时补上审核记录。
+// 要扫描整个线上库，只有拿到分布式锁的实例会执行
+type ArticleReviewReconcileJob struct {
+	svc    service.ArticleService
+	batch  int
+	client *rlock.Client
+	key    string
+	// expiration 锁的过期时间，要比 IntervalRunner 的超时时间长
+	expiration time.Duration
+	l          logger.LoggerV1
+}
+
+func NewArticleReviewReconcileJob(svc service.ArticleService, client *rlock.Client, l logger.LoggerV1) *ArticleReviewReconcileJob {
+	return &ArticleReviewReconcileJob{
+		svc:        svc,
+		batch:      500,
+		client:     client,
+		key:        "job:article:review_reconcile",
+		expiration: time.Minute * 15,
+		l:          l,
+	}
+}
+
+func (j *ArticleReviewReconcileJob) Name() string {
+	return "article_review_reconcile"
+}
+
+func (j *ArticleReviewReconcileJob) Run(ctx context.Context) error {
+	lock, err := j.client.TryLock(ctx, j.key, j.expiration)
+	if errors.Is(err, rlock.ErrLockHeld) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Unlock(ctx)
+		if er != nil {
+			j.l.Warn("释放补审核记录的分布式锁失败", logger.Error(er))
+		}
+	}()
+	cnt, err := j.svc.ReconcileReviews(ctx, j.batch)
+	if cnt > 0 {
+		j.l.Info("补上文章的审核记录", logger.Int("cnt", cnt))
+	}
+	return err
+}
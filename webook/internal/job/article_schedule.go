@@ -0,0 +1,38 @@
+package job
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/pkg/logger"
+)
+
+// ArticleScheduleJob 把到点的定时发表文章发表出去
+type ArticleScheduleJob struct {
+	svc service.ArticleService
+	// batch 每次最多处理多少篇
+	batch int
+	l     logger.LoggerV1
+}
+
+func NewArticleScheduleJob(svc service.ArticleService, l logger.LoggerV1) *ArticleScheduleJob {
+	return &ArticleScheduleJob{
+		svc:   svc,
+		batch: 100,
+		l:     l,
+	}
+}
+
+func (j *ArticleScheduleJob) Name() string {
+	return "article_schedule"
+}
+
+func (j *ArticleScheduleJob) Run(ctx context.Context) error {
+	cnt, err := j.svc.PublishDue(ctx, j.batch)
+	if err != nil {
+		return err
+	}
+	if cnt > 0 {
+		j.l.Info("定时发表文章", logger.Int("cnt", cnt))
+	}
+	return nil
+}
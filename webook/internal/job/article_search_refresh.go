@@ -0,0 +1,29 @@
+package job
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/repository/dao"
+)
+
+// ArticleSearchRefreshJob 定时重建当前实例的搜索索引。
+// 索引在每个实例的内存里面，别的实例发表或者撤回的文章只有重建之后才能同步过来，
+// 所以每个实例都要跑，不需要分布式锁
+type ArticleSearchRefreshJob struct {
+	searchDao *dao.MemoryArticleSearchDao
+	artDao    dao.ArticleDao
+}
+
+func NewArticleSearchRefreshJob(searchDao *dao.MemoryArticleSearchDao, artDao dao.ArticleDao) *ArticleSearchRefreshJob {
+	return &ArticleSearchRefreshJob{
+		searchDao: searchDao,
+		artDao:    artDao,
+	}
+}
+
+func (j *ArticleSearchRefreshJob) Name() string {
+	return "article_search_refresh"
+}
+
+func (j *ArticleSearchRefreshJob) Run(ctx context.Context) error {
+	return j.searchDao.Load(ctx, j.artDao)
+}
@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/pkg/logger"
+	"geek-basic-go/webook/pkg/rlock"
+	"time"
+)
+
+// ArticleTrashPurgeJob 彻底删除回收站里面超过保留期限的文章，只有拿到分布式锁的实例会删除
+type ArticleTrashPurgeJob struct {
+	svc    service.ArticleService
+	batch  int
+	client *rlock.Client
+	key    string
+	// expiration 锁的过期时间，要比 IntervalRunner 的超时时间长
+	expiration time.Duration
+	l          logger.LoggerV1
+}
+
+func NewArticleTrashPurgeJob(svc service.ArticleService, client *rlock.Client, l logger.LoggerV1) *ArticleTrashPurgeJob {
+	return &ArticleTrashPurgeJob{
+		svc:        svc,
+		batch:      100,
+		client:     client,
+		key:        "job:article:trash_purge",
+		expiration: time.Minute * 15,
+		l:          l,
+	}
+}
+
+func (j *ArticleTrashPurgeJob) Name() string {
+	return "article_trash_purge"
+}
+
+func (j *ArticleTrashPurgeJob) Run(ctx context.Context) error {
+	lock, err := j.client.TryLock(ctx, j.key, j.expiration)
+	if errors.Is(err, rlock.ErrLockHeld) {
+		// 别的实例正在删除
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Unlock(ctx)
+		if er != nil {
+			j.l.Warn("释放清理回收站的分布式锁失败", logger.Error(er))
+		}
+	}()
+	cnt, err := j.svc.PurgeTrash(ctx, j.batch)
+	if cnt > 0 {
+		j.l.Info("彻底删除回收站里面的文章", logger.Int("cnt", cnt))
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+package job
+
+import (
+	"context"
+	"geek-basic-go/webook/pkg/logger"
+	"time"
+)
+
+// IntervalRunner 按照固定的时间间隔执行 Job，一次执行完了才会开始下一次
+type IntervalRunner struct {
+	job      Job
+	interval time.Duration
+	// timeout 单次执行的超时时间
+	timeout time.Duration
+	l       logger.LoggerV1
+	cancel  context.CancelFunc
+}
+
+func NewIntervalRunner(job Job, interval time.Duration, timeout time.Duration, l logger.LoggerV1) *IntervalRunner {
+	return &IntervalRunner{
+		job:      job,
+		interval: interval,
+		timeout:  timeout,
+		l:        l,
+	}
+}
+
+func (r *IntervalRunner) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.runOnce(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *IntervalRunner) runOnce(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	err := r.job.Run(ctx)
+	if err != nil {
+		r.l.Error("执行任务失败",
+			logger.String("job", r.job.Name()),
+			logger.Error(err))
+	}
+}
+
+func (r *IntervalRunner) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
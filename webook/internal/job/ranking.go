@@ -0,0 +1,55 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/pkg/logger"
+	"geek-basic-go/webook/pkg/rlock"
+	"time"
+)
+
+// RankingJob 定时重新计算热榜，每个实例都会调度，但是只有拿到分布式锁的实例会计算
+type RankingJob struct {
+	svc    service.RankingService
+	client *rlock.Client
+	key    string
+	// expiration 锁的过期时间，要比 IntervalRunner 的超时时间长，计算完之前锁不会过期
+	expiration time.Duration
+	l          logger.LoggerV1
+}
+
+func NewRankingJob(svc service.RankingService, client *rlock.Client, l logger.LoggerV1) *RankingJob {
+	return &RankingJob{
+		svc:        svc,
+		client:     client,
+		key:        "job:ranking:article",
+		expiration: time.Minute * 2,
+		l:          l,
+	}
+}
+
+func (j *RankingJob) Name() string {
+	return "ranking"
+}
+
+func (j *RankingJob) Run(ctx context.Context) error {
+	lock, err := j.client.TryLock(ctx, j.key, j.expiration)
+	if errors.Is(err, rlock.ErrLockHeld) {
+		// 别的实例正在计算
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// 计算超时的时候 ctx 已经取消了，释放锁要用新的 ctx
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Unlock(ctx)
+		if er != nil {
+			j.l.Warn("释放热榜的分布式锁失败", logger.Error(er))
+		}
+	}()
+	return j.svc.TopN(ctx)
+}
@@ -0,0 +1,9 @@
+package job
+
+import "context"
+
+// Job 后台任务，由 IntervalRunner 之类的调度器定时调用
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
@@ -10,18 +10,52 @@ import (
 	"time"
 )
 
+var (
+	ErrArticleNotFound        = dao.ErrRecordNotFound
+	ErrArticleVersionConflict = dao.ErrArticleVersionConflict
+)
+
 type ArticleRepository interface {
 	Create(ctx context.Context, art domain.Article) (int64, error)
 	Update(ctx context.Context, art domain.Article) error
 	Sync(ctx context.Context, art domain.Article) (int64, error)
+	// SyncStatus uid 是文章的作者，用来删除作者第一页的缓存，Trash 和 Restore 也一样
 	SyncStatus(ctx context.Context, uid int64, id int64, status domain.ArticleStatus) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// GetByAuthor 按照更新时间倒序翻页，cursor 是上一页最后一篇文章，零值代表第一页
+	GetByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// ScanByAuthor 和 GetByAuthor 一样翻页，但是不走缓存，缓存里面只有摘要，导出这种要完整内容的场景用这个
+	ScanByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// CountByAuthor 不包括回收站里面的文章
+	CountByAuthor(ctx context.Context, uid int64) (int64, error)
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 	GetPubById(ctx context.Context, id int64) (domain.Article, error)
+	// SearchPub 全文搜索已发表的文章，按照相关度排序
+	SearchPub(ctx context.Context, query string, offset int, limit int) ([]domain.Article, error)
+	ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]domain.Article, error)
+	ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// ListPubSince 在 since 之后第一次发表、id 大于 id 的文章，按照 id 正序，
+	// 只有 Id、Title、Author.Id、Status 和时间，不走缓存。Ctime 是第一次发表的时间
+	ListPubSince(ctx context.Context, since time.Time, id int64, limit int) ([]domain.Article, error)
+	// ScanPub 线上库里面 id 大于 id 的文章，按照 id 正序，包括内容，不走缓存
+	ScanPub(ctx context.Context, id int64, limit int) ([]domain.Article, error)
+	// UpdatePubStats 只修改线上库的 art.Stats，列表的缓存没有删，过期之后就是新的了
+	UpdatePubStats(ctx context.Context, art domain.Article) error
+	// ListFeed 订阅源里面最新的 FeedSize 篇已发表的文章，uid 是 0 代表全站
+	ListFeed(ctx context.Context, uid int64) ([]domain.Article, error)
+	// Trash 移到回收站，读者立刻就看不到了
+	Trash(ctx context.Context, uid int64, id int64) error
+	// Restore 从回收站恢复成草稿
+	Restore(ctx context.Context, uid int64, id int64) error
+	ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// ListTrashBefore 在 before 之前移到回收站的文章，按照删除时间正序
+	ListTrashBefore(ctx context.Context, before time.Time, limit int) ([]domain.Article, error)
+	// Purge 彻底删除文章，包括缓存和搜索索引
+	Purge(ctx context.Context, art domain.Article) error
 }
 
 type CachedArticleRepository struct {
 	dao       dao.ArticleDao
+	searchDao dao.ArticleSearchDao
 	cache     cache.ArticleCache
 	userRepo  UserRepository // repository 一般都有一些缓存
 	readerDao dao.ArticleReaderDao
@@ -58,6 +92,77 @@ func (c *CachedArticleRepository) GetPubById(ctx context.Context, id int64) (dom
 	return res, nil
 }
 
+func (c *CachedArticleRepository) SearchPub(ctx context.Context, query string, offset int, limit int) ([]domain.Article, error) {
+	arts, err := c.searchDao.Search(ctx, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.PublishedArticle, domain.Article](arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return c.toDomain(dao.Article(src))
+	}), nil
+}
+
+func (c *CachedArticleRepository) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPubByTag(ctx, tag, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.PublishedArticle, domain.Article](arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return c.toDomain(dao.Article(src))
+	}), nil
+}
+
+func (c *CachedArticleRepository) ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPubByAuthor(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.PublishedArticle, domain.Article](arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return c.toDomain(dao.Article(src))
+	}), nil
+}
+
+// FeedSize 订阅源里面最多有这么多篇文章，阅读器一般只关心最新的几篇
+const FeedSize = 20
+
+func (c *CachedArticleRepository) ListFeed(ctx context.Context, uid int64) ([]domain.Article, error) {
+	res, err := c.cache.GetFeed(ctx, uid)
+	if err == nil {
+		return res, nil
+	}
+	var arts []dao.PublishedArticle
+	if uid > 0 {
+		arts, err = c.dao.ListPubByAuthor(ctx, uid, 0, FeedSize)
+	} else {
+		arts, err = c.dao.ListPubLatest(ctx, FeedSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res = slice.Map[dao.PublishedArticle, domain.Article](arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return c.toDomain(dao.Article(src))
+	})
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := c.cache.SetFeed(ctx, uid, res)
+		if err != nil {
+			// 记录日志
+		}
+	}()
+	return res, nil
+}
+
+// deleteFeed 作者的订阅源和全站最新的订阅源里面都可能有这篇文章
+func (c *CachedArticleRepository) deleteFeed(ctx context.Context, uid int64) {
+	for _, id := range []int64{uid, 0} {
+		err := c.cache.DeleteFeed(ctx, id)
+		if err != nil {
+			// 记录日志
+		}
+	}
+}
+
 func (c *CachedArticleRepository) GetById(ctx context.Context, id int64) (domain.Article, error) {
 	res, err := c.cache.Get(ctx, id)
 	if err == nil {
@@ -76,47 +181,120 @@ func (c *CachedArticleRepository) GetById(ctx context.Context, id int64) (domain
 	return c.toDomain(art), nil
 }
 
-func (c *CachedArticleRepository) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+// firstPageWindow 缓存作者最新的这么多篇文章，不管每页多大，只要落在这个范围里面就可以直接用缓存
+const firstPageWindow = 100
+
+func (c *CachedArticleRepository) GetByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
 	// 先判断要不要查询缓存
-	if offset == 0 && limit == 100 {
-		res, err := c.cache.GetFirstPage(ctx, uid)
+	if limit <= firstPageWindow {
+		window, err := c.cache.GetFirstPage(ctx, uid)
 		if err == nil {
-			return res, nil
+			if res, ok := pageInWindow(window, cursor, limit); ok {
+				return res, nil
+			}
 		} else {
 			// 记录日志
 			// 缓存未命中，忽略
 			// 网络问题
 			// Redis 问题
 		}
+		if cursor.IsZero() {
+			return c.loadFirstPage(ctx, uid, limit)
+		}
 	}
-	arts, err := c.dao.GetByAuthor(ctx, uid, offset, limit)
+	arts, err := c.dao.GetByAuthor(ctx, uid, cursor.Utime.UnixMilli(), cursor.Id, limit)
 	if err != nil {
 		return nil, err
 	}
-	res := slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
+	return slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedArticleRepository) ListPubSince(ctx context.Context, since time.Time, id int64, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPubSince(ctx, since.UnixMilli(), id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.PublishedArticle, domain.Article](arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return c.toDomain(dao.Article(src))
+	}), nil
+}
+
+func (c *CachedArticleRepository) ScanPub(ctx context.Context, id int64, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ScanPub(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.PublishedArticle, domain.Article](arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return c.toDomain(dao.Article(src))
+	}), nil
+}
+
+func (c *CachedArticleRepository) UpdatePubStats(ctx context.Context, art domain.Article) error {
+	pubArt := dao.PublishedArticle(c.toEntity(art))
+	err := c.dao.UpdatePubStats(ctx, pubArt)
+	if err != nil {
+		return err
+	}
+	return c.cache.DeletePub(ctx, art.Id)
+}
+
+func (c *CachedArticleRepository) ScanByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.GetByAuthor(ctx, uid, cursor.Utime.UnixMilli(), cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedArticleRepository) CountByAuthor(ctx context.Context, uid int64) (int64, error) {
+	return c.dao.CountByAuthor(ctx, uid)
+}
+
+// loadFirstPage 第一页总是查出整个窗口回写缓存，后面不同大小的第一页和紧接着的几页都能命中
+func (c *CachedArticleRepository) loadFirstPage(ctx context.Context, uid int64, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.GetByAuthor(ctx, uid, 0, 0, firstPageWindow)
+	if err != nil {
+		return nil, err
+	}
+	window := slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
 		return c.toDomain(src)
 	})
 	go func() {
 		// 因为是异步，最好用一个新的context
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
-		// limit <= 100都可以缓存
-		if offset == 0 && limit == 100 {
-			// 缓存回写失败不一定是大问题，也可能是大问题
-			err = c.cache.SetFirstPage(ctx, uid, res)
-			if err != nil {
-				// 网络抖动，记录日志，监控
-			}
+		// 缓存回写失败不一定是大问题，也可能是大问题
+		err := c.cache.SetFirstPage(ctx, uid, window)
+		if err != nil {
+			// 网络抖动，记录日志，监控
 		}
-	}()
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
 		c.preCache(ctx, arts)
 	}()
+	res, _ := pageInWindow(window, domain.ArticleCursor{}, limit)
 	return res, nil
 }
 
+// pageInWindow 从缓存的窗口里面取出游标后面的一页，窗口里面剩下的文章不够一页的时候返回 false
+func pageInWindow(window []domain.Article, cursor domain.ArticleCursor, limit int) ([]domain.Article, bool) {
+	start := 0
+	for start < len(window) && !cursor.Covers(window[start]) {
+		start++
+	}
+	end := start + limit
+	if end <= len(window) {
+		return window[start:end:end], true
+	}
+	// 窗口没满，说明作者所有的文章都在窗口里面了
+	if len(window) < firstPageWindow {
+		return window[start:], true
+	}
+	return nil, false
+}
+
 func (c *CachedArticleRepository) preCache(ctx context.Context, arts []dao.Article) {
 	const size = 1024 * 1024
 	if len(arts) > 0 && len(arts[0].Content) <= size {
@@ -128,21 +306,111 @@ func (c *CachedArticleRepository) preCache(ctx context.Context, arts []dao.Artic
 }
 
 func (c *CachedArticleRepository) SyncStatus(ctx context.Context, uid int64, id int64, status domain.ArticleStatus) error {
-	err := c.dao.SyncStatus(ctx, uid, id, status)
+	err := c.dao.SyncStatus(ctx, id, status)
 	if err == nil {
 		err := c.cache.DeleteFirstPage(ctx, uid)
 		if err != nil {
 			// 记录日志
 		}
+		// 缓存里面的线上文章还是原来的状态，审核通过之后读者要马上能看到
+		err = c.cache.DeletePub(ctx, id)
+		if err != nil {
+			// 记录日志
+		}
+		c.deleteFeed(ctx, uid)
+		c.syncSearch(ctx, id, status)
 	}
 	return err
 }
 
-func NewArticleRepository(dao dao.ArticleDao, userRepo UserRepository, cache cache.ArticleCache) ArticleRepository {
+// syncSearch 只有已发表的文章能被搜到，撤回之类的要从索引里面删掉
+func (c *CachedArticleRepository) syncSearch(ctx context.Context, id int64, status domain.ArticleStatus) {
+	if status != domain.ArticleStatusPublished {
+		err := c.searchDao.Delete(ctx, id)
+		if err != nil {
+			// 记录日志
+		}
+		return
+	}
+	art, err := c.dao.GetPubById(ctx, id)
+	if err != nil {
+		// 记录日志
+		return
+	}
+	err = c.searchDao.Upsert(ctx, art)
+	if err != nil {
+		// 记录日志
+	}
+}
+
+func (c *CachedArticleRepository) Trash(ctx context.Context, uid int64, id int64) error {
+	err := c.dao.Trash(ctx, id)
+	if err != nil {
+		return err
+	}
+	c.deleteCache(ctx, domain.Article{Id: id, Author: domain.Author{Id: uid}})
+	err = c.cache.DeletePub(ctx, id)
+	if err != nil {
+		// 记录日志
+	}
+	c.deleteFeed(ctx, uid)
+	c.syncSearch(ctx, id, domain.ArticleStatusDeleted)
+	return nil
+}
+
+func (c *CachedArticleRepository) Restore(ctx context.Context, uid int64, id int64) error {
+	err := c.dao.Restore(ctx, id)
+	if err == nil {
+		c.deleteCache(ctx, domain.Article{Id: id, Author: domain.Author{Id: uid}})
+	}
+	return err
+}
+
+func (c *CachedArticleRepository) ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListTrash(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedArticleRepository) ListTrashBefore(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListTrashBefore(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedArticleRepository) Purge(ctx context.Context, art domain.Article) error {
+	err := c.dao.Purge(ctx, art.Id)
+	if err != nil {
+		return err
+	}
+	// 移到回收站的时候已经删过一次了，这里兜底，缓存删除失败也会在过期之后消失
+	c.deleteCache(ctx, art)
+	err = c.cache.DeletePub(ctx, art.Id)
+	if err != nil {
+		// 记录日志
+	}
+	err = c.searchDao.Delete(ctx, art.Id)
+	if err != nil {
+		// 记录日志
+	}
+	return nil
+}
+
+func NewArticleRepository(dao dao.ArticleDao, searchDao dao.ArticleSearchDao,
+	userRepo UserRepository, cache cache.ArticleCache) ArticleRepository {
 	return &CachedArticleRepository{
-		dao:      dao,
-		cache:    cache,
-		userRepo: userRepo,
+		dao:       dao,
+		searchDao: searchDao,
+		cache:     cache,
+		userRepo:  userRepo,
 	}
 }
 
@@ -157,32 +425,19 @@ func NewArticleRepositoryV1(
 
 func (c *CachedArticleRepository) Sync(ctx context.Context, art domain.Article) (int64, error) {
 	id, err := c.dao.Sync(ctx, c.toEntity(art))
-	if err == nil {
-		err := c.cache.DeleteFirstPage(ctx, art.Author.Id)
-		if err != nil {
-			// 记录日志
-		}
+	if err != nil {
+		return 0, err
 	}
-	// 一发布的时候就尝试设置缓存
-	go func() {
-		// 设置一个新的context，摆脱整个链路的控制，让它有独立的超时时间
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
-		user, er := c.userRepo.FindById(ctx, art.Author.Id)
-		if er != nil {
-			// 记录日志
-		}
-		// 灵活设置过期时间,大V和普通创作者区分设置过期时间
-		art.Author = domain.Author{
-			Id:   user.Id,
-			Name: user.NickName,
-		}
-		er = c.cache.SetPub(ctx, art)
-		if er != nil {
-			// 记录日志
-		}
-	}()
-	return id, err
+	c.deleteCache(ctx, art)
+	c.deleteFeed(ctx, art.Author.Id)
+	c.syncSearch(ctx, id, art.Status)
+	// 不能直接用 art 设置线上库的缓存，没有修改标签的时候 art.Tags 是 nil，
+	// 删掉之后下一次读的时候从数据库里面加载完整的文章
+	err = c.cache.DeletePub(ctx, id)
+	if err != nil {
+		// 记录日志
+	}
+	return id, nil
 }
 
 func (c *CachedArticleRepository) SyncV2(ctx context.Context, art domain.Article) (int64, error) {
@@ -241,12 +496,23 @@ func (c *CachedArticleRepository) SyncV1(ctx context.Context, art domain.Article
 func (c *CachedArticleRepository) Update(ctx context.Context, art domain.Article) error {
 	err := c.dao.UpdateById(ctx, c.toEntity(art))
 	if err == nil {
-		err := c.cache.DeleteFirstPage(ctx, art.Author.Id)
+		c.deleteCache(ctx, art)
+	}
+	return err
+}
+
+// deleteCache 制作库变了，第一页和详情的缓存都要删掉，不然定时发表之类的场景会读到旧的内容
+func (c *CachedArticleRepository) deleteCache(ctx context.Context, art domain.Article) {
+	err := c.cache.DeleteFirstPage(ctx, art.Author.Id)
+	if err != nil {
+		// 记录日志
+	}
+	if art.Id > 0 {
+		err = c.cache.Delete(ctx, art.Id)
 		if err != nil {
 			// 记录日志
 		}
 	}
-	return err
 }
 
 func (c *CachedArticleRepository) Create(ctx context.Context, art domain.Article) (int64, error) {
@@ -269,6 +535,16 @@ func (c *CachedArticleRepository) toEntity(art domain.Article) dao.Article {
 		AuthorId: art.Author.Id,
 		Status:   art.Status.ToUint8(),
 		//Status:   uint8(art.Status),
+		Tags:    art.Tags,
+		Format:  art.Format.ToUint8(),
+		Html:    art.Html,
+		Summary: art.Summary,
+		Version: art.Version,
+
+		WordCnt:      art.Stats.WordCnt,
+		ReadMinutes:  art.Stats.ReadMinutes,
+		ImageCnt:     art.Stats.ImageCnt,
+		CodeBlockCnt: art.Stats.CodeBlockCnt,
 	}
 	return article
 }
@@ -281,8 +557,28 @@ func (c *CachedArticleRepository) toDomain(art dao.Article) domain.Article {
 		Author: domain.Author{
 			Id: art.AuthorId,
 		},
-		Status: domain.ArticleStatus(art.Status),
-		Ctime:  time.UnixMilli(art.Ctime),
-		Utime:  time.UnixMilli(art.Utime),
+		Status:  domain.ArticleStatus(art.Status),
+		Tags:    art.Tags,
+		Format:  domain.ArticleFormat(art.Format),
+		Html:    art.Html,
+		Summary: art.Summary,
+		Version: art.Version,
+		Ctime:   time.UnixMilli(art.Ctime),
+		Utime:   time.UnixMilli(art.Utime),
+		Dtime:   c.toTime(art.Dtime),
+		Stats: domain.ArticleStats{
+			WordCnt:      art.WordCnt,
+			ReadMinutes:  art.ReadMinutes,
+			ImageCnt:     art.ImageCnt,
+			CodeBlockCnt: art.CodeBlockCnt,
+		},
+	}
+}
+
+// toTime 0 代表没有，转成 time.Time 的零值
+func (c *CachedArticleRepository) toTime(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
 	}
+	return time.UnixMilli(ms)
 }
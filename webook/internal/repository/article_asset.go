@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/dao"
+	"geek-basic-go/webook/pkg/blobx"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrArticleAssetNotFound = dao.ErrRecordNotFound
+
+type ArticleAssetRepository interface {
+	// Create 先存内容再记录，相同的 key 重复上传是幂等的
+	Create(ctx context.Context, asset domain.ArticleAsset, data []byte) (domain.ArticleAsset, error)
+	GetByKey(ctx context.Context, key string) (domain.ArticleAsset, error)
+	GetContent(ctx context.Context, key string) ([]byte, error)
+	ListBefore(ctx context.Context, before time.Time, startId int64, limit int) ([]domain.ArticleAsset, error)
+	IsReferenced(ctx context.Context, aid int64, needle string) (bool, error)
+	// Delete 删除记录和内容
+	Delete(ctx context.Context, asset domain.ArticleAsset) error
+}
+
+type ArticleAssetRepositoryImpl struct {
+	dao   dao.ArticleAssetDao
+	store blobx.BlobStore
+}
+
+func NewArticleAssetRepository(dao dao.ArticleAssetDao, store blobx.BlobStore) ArticleAssetRepository {
+	return &ArticleAssetRepositoryImpl{
+		dao:   dao,
+		store: store,
+	}
+}
+
+func (r *ArticleAssetRepositoryImpl) Create(ctx context.Context, asset domain.ArticleAsset, data []byte) (domain.ArticleAsset, error) {
+	// 先写内容，写成功了但是记录失败，最多是对象存储里面多一个没人引用的文件
+	err := r.store.Put(ctx, r.blobKey(asset.Key), data, asset.ContentType)
+	if err != nil {
+		return domain.ArticleAsset{}, err
+	}
+	res, err := r.dao.Insert(ctx, r.toEntity(asset))
+	if err != nil {
+		return domain.ArticleAsset{}, err
+	}
+	return r.toDomain(res), nil
+}
+
+func (r *ArticleAssetRepositoryImpl) GetByKey(ctx context.Context, key string) (domain.ArticleAsset, error) {
+	asset, err := r.dao.GetByKey(ctx, key)
+	if err != nil {
+		return domain.ArticleAsset{}, err
+	}
+	return r.toDomain(asset), nil
+}
+
+func (r *ArticleAssetRepositoryImpl) GetContent(ctx context.Context, key string) ([]byte, error) {
+	data, err := r.store.Get(ctx, r.blobKey(key))
+	if errors.Is(err, blobx.ErrBlobNotFound) {
+		return nil, ErrArticleAssetNotFound
+	}
+	return data, err
+}
+
+func (r *ArticleAssetRepositoryImpl) ListBefore(ctx context.Context, before time.Time, startId int64, limit int) ([]domain.ArticleAsset, error) {
+	assets, err := r.dao.ListBefore(ctx, before.UnixMilli(), startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleAsset, domain.ArticleAsset](assets, func(idx int, src dao.ArticleAsset) domain.ArticleAsset {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *ArticleAssetRepositoryImpl) IsReferenced(ctx context.Context, aid int64, needle string) (bool, error) {
+	return r.dao.IsReferenced(ctx, aid, needle)
+}
+
+func (r *ArticleAssetRepositoryImpl) Delete(ctx context.Context, asset domain.ArticleAsset) error {
+	// 先删记录，删内容失败的时候，最多是对象存储里面多一个文件，不会出现记录指向不存在的内容
+	err := r.dao.Delete(ctx, asset.Id)
+	if err != nil {
+		return err
+	}
+	return r.store.Delete(ctx, r.blobKey(asset.Key))
+}
+
+// blobKey 资源放在对象存储的 assets/ 下面
+func (r *ArticleAssetRepositoryImpl) blobKey(key string) string {
+	return "assets/" + key
+}
+
+func (r *ArticleAssetRepositoryImpl) toEntity(asset domain.ArticleAsset) dao.ArticleAsset {
+	return dao.ArticleAsset{
+		Id:          asset.Id,
+		ArticleId:   asset.ArticleId,
+		AuthorId:    asset.Author.Id,
+		Key:         asset.Key,
+		ContentType: asset.ContentType,
+		Size:        asset.Size,
+	}
+}
+
+func (r *ArticleAssetRepositoryImpl) toDomain(asset dao.ArticleAsset) domain.ArticleAsset {
+	return domain.ArticleAsset{
+		Id:        asset.Id,
+		ArticleId: asset.ArticleId,
+		Author: domain.Author{
+			Id: asset.AuthorId,
+		},
+		Key:         asset.Key,
+		ContentType: asset.ContentType,
+		Size:        asset.Size,
+		Ctime:       time.UnixMilli(asset.Ctime),
+	}
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrArticleCollaboratorNotFound = dao.ErrRecordNotFound
+
+type ArticleCollaboratorRepository interface {
+	Upsert(ctx context.Context, c domain.ArticleCollaborator) error
+	Delete(ctx context.Context, aid int64, uid int64) error
+	Get(ctx context.Context, aid int64, uid int64) (domain.ArticleCollaborator, error)
+	ListByArticle(ctx context.Context, aid int64) ([]domain.ArticleCollaborator, error)
+	DeleteByArticle(ctx context.Context, aid int64) error
+}
+
+// ArticleCollaboratorRepositoryImpl 只有编辑的时候才查协作者，不需要缓存
+type ArticleCollaboratorRepositoryImpl struct {
+	dao dao.ArticleCollaboratorDao
+}
+
+func NewArticleCollaboratorRepository(dao dao.ArticleCollaboratorDao) ArticleCollaboratorRepository {
+	return &ArticleCollaboratorRepositoryImpl{
+		dao: dao,
+	}
+}
+
+func (r *ArticleCollaboratorRepositoryImpl) Upsert(ctx context.Context, c domain.ArticleCollaborator) error {
+	return r.dao.Upsert(ctx, r.toEntity(c))
+}
+
+func (r *ArticleCollaboratorRepositoryImpl) Delete(ctx context.Context, aid int64, uid int64) error {
+	return r.dao.Delete(ctx, aid, uid)
+}
+
+func (r *ArticleCollaboratorRepositoryImpl) Get(ctx context.Context, aid int64, uid int64) (domain.ArticleCollaborator, error) {
+	c, err := r.dao.Get(ctx, aid, uid)
+	if err != nil {
+		return domain.ArticleCollaborator{}, err
+	}
+	return r.toDomain(c), nil
+}
+
+func (r *ArticleCollaboratorRepositoryImpl) ListByArticle(ctx context.Context, aid int64) ([]domain.ArticleCollaborator, error) {
+	cs, err := r.dao.ListByArticle(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleCollaborator, domain.ArticleCollaborator](cs, func(idx int, src dao.ArticleCollaborator) domain.ArticleCollaborator {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *ArticleCollaboratorRepositoryImpl) DeleteByArticle(ctx context.Context, aid int64) error {
+	return r.dao.DeleteByArticle(ctx, aid)
+}
+
+func (r *ArticleCollaboratorRepositoryImpl) toEntity(c domain.ArticleCollaborator) dao.ArticleCollaborator {
+	return dao.ArticleCollaborator{
+		ArticleId: c.ArticleId,
+		Uid:       c.User.Id,
+		Role:      c.Role.ToUint8(),
+	}
+}
+
+func (r *ArticleCollaboratorRepositoryImpl) toDomain(c dao.ArticleCollaborator) domain.ArticleCollaborator {
+	return domain.ArticleCollaborator{
+		ArticleId: c.ArticleId,
+		User: domain.Author{
+			Id: c.Uid,
+		},
+		Role:  domain.ArticleRole(c.Role),
+		Ctime: time.UnixMilli(c.Ctime),
+		Utime: time.UnixMilli(c.Utime),
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/dao"
+	"geek-basic-go/webook/pkg/blobx"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrArticleExportNotFound = dao.ErrRecordNotFound
+
+type ArticleExportRepository interface {
+	Create(ctx context.Context, uid int64) (int64, error)
+	// FindActive 用户等待执行或者正在执行的导出，没有返回 ErrArticleExportNotFound
+	FindActive(ctx context.Context, uid int64) (domain.ArticleExport, error)
+	GetById(ctx context.Context, id int64) (domain.ArticleExport, error)
+	ListByUser(ctx context.Context, uid int64, limit int) ([]domain.ArticleExport, error)
+	FindRunnable(ctx context.Context, now time.Time, timeout time.Duration, limit int) ([]domain.ArticleExport, error)
+	Claim(ctx context.Context, id int64, now time.Time, timeout time.Duration) (bool, error)
+	UpdateProgress(ctx context.Context, id int64, total int, done int) error
+	// Complete 先把 zip 文件存到对象存储，再标记成完成
+	Complete(ctx context.Context, e domain.ArticleExport, data []byte) error
+	Fail(ctx context.Context, id int64, reason string) error
+	// GetFile 导出的 zip 文件
+	GetFile(ctx context.Context, e domain.ArticleExport) ([]byte, error)
+}
+
+// ArticleExportRepositoryImpl 导出不常用，不需要缓存
+type ArticleExportRepositoryImpl struct {
+	dao   dao.ArticleExportDao
+	store blobx.BlobStore
+}
+
+func NewArticleExportRepository(dao dao.ArticleExportDao, store blobx.BlobStore) ArticleExportRepository {
+	return &ArticleExportRepositoryImpl{
+		dao:   dao,
+		store: store,
+	}
+}
+
+func (r *ArticleExportRepositoryImpl) Create(ctx context.Context, uid int64) (int64, error) {
+	return r.dao.Insert(ctx, dao.ArticleExport{Uid: uid})
+}
+
+func (r *ArticleExportRepositoryImpl) FindActive(ctx context.Context, uid int64) (domain.ArticleExport, error) {
+	e, err := r.dao.FindActive(ctx, uid)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	return r.toDomain(e), nil
+}
+
+func (r *ArticleExportRepositoryImpl) GetById(ctx context.Context, id int64) (domain.ArticleExport, error) {
+	e, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	return r.toDomain(e), nil
+}
+
+func (r *ArticleExportRepositoryImpl) ListByUser(ctx context.Context, uid int64, limit int) ([]domain.ArticleExport, error) {
+	es, err := r.dao.ListByUser(ctx, uid, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(es), nil
+}
+
+func (r *ArticleExportRepositoryImpl) FindRunnable(ctx context.Context, now time.Time, timeout time.Duration, limit int) ([]domain.ArticleExport, error) {
+	es, err := r.dao.FindRunnable(ctx, now.UnixMilli(), timeout, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(es), nil
+}
+
+func (r *ArticleExportRepositoryImpl) Claim(ctx context.Context, id int64, now time.Time, timeout time.Duration) (bool, error) {
+	return r.dao.Claim(ctx, id, now.UnixMilli(), timeout)
+}
+
+func (r *ArticleExportRepositoryImpl) UpdateProgress(ctx context.Context, id int64, total int, done int) error {
+	return r.dao.UpdateProgress(ctx, id, total, done)
+}
+
+func (r *ArticleExportRepositoryImpl) Complete(ctx context.Context, e domain.ArticleExport, data []byte) error {
+	// 重新执行的时候 key 一样，直接覆盖上一次没完成的文件
+	err := r.store.Put(ctx, e.Key, data, "application/zip")
+	if err != nil {
+		return err
+	}
+	return r.dao.Complete(ctx, e.Id, e.Key)
+}
+
+func (r *ArticleExportRepositoryImpl) Fail(ctx context.Context, id int64, reason string) error {
+	return r.dao.Fail(ctx, id, reason)
+}
+
+func (r *ArticleExportRepositoryImpl) GetFile(ctx context.Context, e domain.ArticleExport) ([]byte, error) {
+	data, err := r.store.Get(ctx, e.Key)
+	if errors.Is(err, blobx.ErrBlobNotFound) {
+		return nil, ErrArticleExportNotFound
+	}
+	return data, err
+}
+
+func (r *ArticleExportRepositoryImpl) toDomains(es []dao.ArticleExport) []domain.ArticleExport {
+	return slice.Map[dao.ArticleExport, domain.ArticleExport](es, func(idx int, src dao.ArticleExport) domain.ArticleExport {
+		return r.toDomain(src)
+	})
+}
+
+func (r *ArticleExportRepositoryImpl) toDomain(e dao.ArticleExport) domain.ArticleExport {
+	return domain.ArticleExport{
+		Id:     e.Id,
+		Uid:    e.Uid,
+		Status: domain.ArticleExportStatus(e.Status),
+		Total:  e.Total,
+		Done:   e.Done,
+		Key:    e.Key,
+		Reason: e.Reason,
+		Ctime:  time.UnixMilli(e.Ctime),
+		Utime:  time.UnixMilli(e.Utime),
+	}
+}
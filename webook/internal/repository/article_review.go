@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrArticleReviewNotFound = dao.ErrRecordNotFound
+
+type ArticleReviewRepository interface {
+	// Submit 提交审核，覆盖上一次的审核结果
+	Submit(ctx context.Context, r domain.ArticleReview) error
+	Get(ctx context.Context, aid int64) (domain.ArticleReview, error)
+	ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error)
+	// Review 记录审核结果，已经不是等待审核的状态了返回 ErrArticleReviewNotFound
+	Review(ctx context.Context, r domain.ArticleReview) error
+	DeleteByArticle(ctx context.Context, aid int64) error
+}
+
+// ArticleReviewRepositoryImpl 审核只有作者和管理员会看，不需要缓存
+type ArticleReviewRepositoryImpl struct {
+	dao dao.ArticleReviewDao
+}
+
+func NewArticleReviewRepository(dao dao.ArticleReviewDao) ArticleReviewRepository {
+	return &ArticleReviewRepositoryImpl{
+		dao: dao,
+	}
+}
+
+func (r *ArticleReviewRepositoryImpl) Submit(ctx context.Context, review domain.ArticleReview) error {
+	review.Status = domain.ArticleReviewStatusPending
+	review.Reason = ""
+	review.Reviewer = 0
+	return r.dao.Upsert(ctx, r.toEntity(review))
+}
+
+func (r *ArticleReviewRepositoryImpl) Get(ctx context.Context, aid int64) (domain.ArticleReview, error) {
+	review, err := r.dao.Get(ctx, aid)
+	if err != nil {
+		return domain.ArticleReview{}, err
+	}
+	return r.toDomain(review), nil
+}
+
+func (r *ArticleReviewRepositoryImpl) ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error) {
+	rs, err := r.dao.ListByStatus(ctx, domain.ArticleReviewStatusPending.ToUint8(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleReview, domain.ArticleReview](rs, func(idx int, src dao.ArticleReview) domain.ArticleReview {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *ArticleReviewRepositoryImpl) Review(ctx context.Context, review domain.ArticleReview) error {
+	return r.dao.Review(ctx, review.ArticleId,
+		domain.ArticleReviewStatusPending.ToUint8(), r.toEntity(review))
+}
+
+func (r *ArticleReviewRepositoryImpl) DeleteByArticle(ctx context.Context, aid int64) error {
+	return r.dao.DeleteByArticle(ctx, aid)
+}
+
+func (r *ArticleReviewRepositoryImpl) toEntity(review domain.ArticleReview) dao.ArticleReview {
+	var hits string
+	if len(review.Hits) > 0 {
+		// []string 序列化不会失败
+		val, _ := json.Marshal(review.Hits)
+		hits = string(val)
+	}
+	return dao.ArticleReview{
+		ArticleId: review.ArticleId,
+		AuthorId:  review.Author.Id,
+		Title:     review.Title,
+		Status:    review.Status.ToUint8(),
+		Hits:      hits,
+		Reason:    review.Reason,
+		Reviewer:  review.Reviewer,
+	}
+}
+
+func (r *ArticleReviewRepositoryImpl) toDomain(review dao.ArticleReview) domain.ArticleReview {
+	var hits []string
+	if review.Hits != "" {
+		// 解析失败只是看不到命中了哪些词
+		_ = json.Unmarshal([]byte(review.Hits), &hits)
+	}
+	return domain.ArticleReview{
+		ArticleId: review.ArticleId,
+		Author: domain.Author{
+			Id: review.AuthorId,
+		},
+		Title:    review.Title,
+		Status:   domain.ArticleReviewStatus(review.Status),
+		Hits:     hits,
+		Reason:   review.Reason,
+		Reviewer: review.Reviewer,
+		Ctime:    time.UnixMilli(review.Ctime),
+		Utime:    time.UnixMilli(review.Utime),
+	}
+}
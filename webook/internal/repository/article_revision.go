@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrArticleRevisionNotFound = dao.ErrRecordNotFound
+
+type ArticleRevisionRepository interface {
+	Create(ctx context.Context, rev domain.ArticleRevision) (int64, error)
+	GetById(ctx context.Context, id int64) (domain.ArticleRevision, error)
+	ListByArticle(ctx context.Context, aid int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	DeleteByArticle(ctx context.Context, aid int64) error
+}
+
+// ArticleRevisionRepositoryImpl 历史版本读得很少，不需要缓存
+type ArticleRevisionRepositoryImpl struct {
+	dao dao.ArticleRevisionDao
+}
+
+func NewArticleRevisionRepository(dao dao.ArticleRevisionDao) ArticleRevisionRepository {
+	return &ArticleRevisionRepositoryImpl{
+		dao: dao,
+	}
+}
+
+func (r *ArticleRevisionRepositoryImpl) Create(ctx context.Context, rev domain.ArticleRevision) (int64, error) {
+	return r.dao.Insert(ctx, r.toEntity(rev))
+}
+
+func (r *ArticleRevisionRepositoryImpl) GetById(ctx context.Context, id int64) (domain.ArticleRevision, error) {
+	rev, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return r.toDomain(rev), nil
+}
+
+func (r *ArticleRevisionRepositoryImpl) ListByArticle(ctx context.Context, aid int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	revs, err := r.dao.ListByArticle(ctx, aid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleRevision, domain.ArticleRevision](revs, func(idx int, src dao.ArticleRevision) domain.ArticleRevision {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *ArticleRevisionRepositoryImpl) DeleteByArticle(ctx context.Context, aid int64) error {
+	return r.dao.DeleteByArticle(ctx, aid)
+}
+
+func (r *ArticleRevisionRepositoryImpl) toEntity(rev domain.ArticleRevision) dao.ArticleRevision {
+	return dao.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		AuthorId:  rev.Author.Id,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Format:    rev.Format.ToUint8(),
+		Status:    rev.Status.ToUint8(),
+	}
+}
+
+func (r *ArticleRevisionRepositoryImpl) toDomain(rev dao.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Format:    domain.ArticleFormat(rev.Format),
+		Author: domain.Author{
+			Id: rev.AuthorId,
+		},
+		Status: domain.ArticleStatus(rev.Status),
+		Ctime:  time.UnixMilli(rev.Ctime),
+	}
+}
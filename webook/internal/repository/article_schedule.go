@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrArticleScheduleNotFound = dao.ErrRecordNotFound
+
+type ArticleScheduleRepository interface {
+	Upsert(ctx context.Context, s domain.ArticleSchedule) error
+	ListWaiting(ctx context.Context, uid int64, offset int, limit int) ([]domain.ArticleSchedule, error)
+	Cancel(ctx context.Context, uid int64, aid int64) error
+	FindDue(ctx context.Context, now time.Time, timeout time.Duration, limit int) ([]domain.ArticleSchedule, error)
+	Claim(ctx context.Context, id int64, now time.Time, timeout time.Duration) (bool, error)
+	Complete(ctx context.Context, id int64) error
+	Release(ctx context.Context, id int64) error
+}
+
+type ArticleScheduleRepositoryImpl struct {
+	dao dao.ArticleScheduleDao
+}
+
+func NewArticleScheduleRepository(dao dao.ArticleScheduleDao) ArticleScheduleRepository {
+	return &ArticleScheduleRepositoryImpl{
+		dao: dao,
+	}
+}
+
+func (r *ArticleScheduleRepositoryImpl) Upsert(ctx context.Context, s domain.ArticleSchedule) error {
+	return r.dao.Upsert(ctx, dao.ArticleSchedule{
+		ArticleId: s.ArticleId,
+		AuthorId:  s.Author.Id,
+		PublishAt: s.PublishAt.UnixMilli(),
+	})
+}
+
+func (r *ArticleScheduleRepositoryImpl) ListWaiting(ctx context.Context, uid int64, offset int, limit int) ([]domain.ArticleSchedule, error) {
+	res, err := r.dao.ListByAuthor(ctx, uid, domain.ArticleScheduleStatusWaiting.ToUint8(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(res), nil
+}
+
+func (r *ArticleScheduleRepositoryImpl) Cancel(ctx context.Context, uid int64, aid int64) error {
+	return r.dao.Cancel(ctx, uid, aid)
+}
+
+func (r *ArticleScheduleRepositoryImpl) FindDue(ctx context.Context, now time.Time, timeout time.Duration, limit int) ([]domain.ArticleSchedule, error) {
+	res, err := r.dao.FindDue(ctx, now.UnixMilli(), timeout, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(res), nil
+}
+
+func (r *ArticleScheduleRepositoryImpl) Claim(ctx context.Context, id int64, now time.Time, timeout time.Duration) (bool, error) {
+	return r.dao.Claim(ctx, id, now.UnixMilli(), timeout)
+}
+
+func (r *ArticleScheduleRepositoryImpl) Complete(ctx context.Context, id int64) error {
+	return r.dao.Complete(ctx, id)
+}
+
+func (r *ArticleScheduleRepositoryImpl) Release(ctx context.Context, id int64) error {
+	return r.dao.Release(ctx, id)
+}
+
+func (r *ArticleScheduleRepositoryImpl) toDomains(schedules []dao.ArticleSchedule) []domain.ArticleSchedule {
+	return slice.Map[dao.ArticleSchedule, domain.ArticleSchedule](schedules, func(idx int, src dao.ArticleSchedule) domain.ArticleSchedule {
+		return domain.ArticleSchedule{
+			Id:        src.Id,
+			ArticleId: src.ArticleId,
+			Author: domain.Author{
+				Id: src.AuthorId,
+			},
+			PublishAt: time.UnixMilli(src.PublishAt),
+			Status:    domain.ArticleScheduleStatus(src.Status),
+			Ctime:     time.UnixMilli(src.Ctime),
+			Utime:     time.UnixMilli(src.Utime),
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var (
+	ErrArticleSeriesNotFound = dao.ErrRecordNotFound
+	ErrArticleInSeries       = dao.ErrArticleInSeries
+	ErrArticleSeriesMismatch = dao.ErrArticleSeriesMismatch
+)
+
+type ArticleSeriesRepository interface {
+	Create(ctx context.Context, s domain.ArticleSeries) (int64, error)
+	// Update 只修改标题和简介
+	Update(ctx context.Context, s domain.ArticleSeries) error
+	Delete(ctx context.Context, id int64, uid int64) error
+	// GetById 不包含系列里面的文章
+	GetById(ctx context.Context, id int64) (domain.ArticleSeries, error)
+	ListByAuthor(ctx context.Context, uid int64) ([]domain.ArticleSeries, error)
+	AddArticle(ctx context.Context, sid int64, aid int64) error
+	RemoveArticle(ctx context.Context, sid int64, aid int64) error
+	Reorder(ctx context.Context, sid int64, aids []int64) error
+	// ListArticleIds 按照系列里面的顺序
+	ListArticleIds(ctx context.Context, sid int64) ([]int64, error)
+	// FindByArticle 文章所在的系列的 id，不在任何系列里面返回 ErrArticleSeriesNotFound
+	FindByArticle(ctx context.Context, aid int64) (int64, error)
+	DeleteByArticle(ctx context.Context, aid int64) error
+}
+
+// ArticleSeriesRepositoryImpl 系列里面的文章不多，都是按照主键或者索引查，暂时不需要缓存
+type ArticleSeriesRepositoryImpl struct {
+	dao dao.ArticleSeriesDao
+}
+
+func NewArticleSeriesRepository(dao dao.ArticleSeriesDao) ArticleSeriesRepository {
+	return &ArticleSeriesRepositoryImpl{
+		dao: dao,
+	}
+}
+
+func (r *ArticleSeriesRepositoryImpl) Create(ctx context.Context, s domain.ArticleSeries) (int64, error) {
+	return r.dao.Insert(ctx, r.toEntity(s))
+}
+
+func (r *ArticleSeriesRepositoryImpl) Update(ctx context.Context, s domain.ArticleSeries) error {
+	return r.dao.Update(ctx, r.toEntity(s))
+}
+
+func (r *ArticleSeriesRepositoryImpl) Delete(ctx context.Context, id int64, uid int64) error {
+	return r.dao.Delete(ctx, id, uid)
+}
+
+func (r *ArticleSeriesRepositoryImpl) GetById(ctx context.Context, id int64) (domain.ArticleSeries, error) {
+	s, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.ArticleSeries{}, err
+	}
+	return r.toDomain(s), nil
+}
+
+func (r *ArticleSeriesRepositoryImpl) ListByAuthor(ctx context.Context, uid int64) ([]domain.ArticleSeries, error) {
+	ss, err := r.dao.ListByAuthor(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleSeries, domain.ArticleSeries](ss, func(idx int, src dao.ArticleSeries) domain.ArticleSeries {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *ArticleSeriesRepositoryImpl) AddArticle(ctx context.Context, sid int64, aid int64) error {
+	return r.dao.AddItem(ctx, sid, aid)
+}
+
+func (r *ArticleSeriesRepositoryImpl) RemoveArticle(ctx context.Context, sid int64, aid int64) error {
+	return r.dao.RemoveItem(ctx, sid, aid)
+}
+
+func (r *ArticleSeriesRepositoryImpl) Reorder(ctx context.Context, sid int64, aids []int64) error {
+	return r.dao.Reorder(ctx, sid, aids)
+}
+
+func (r *ArticleSeriesRepositoryImpl) ListArticleIds(ctx context.Context, sid int64) ([]int64, error) {
+	items, err := r.dao.ListItems(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleSeriesItem, int64](items, func(idx int, src dao.ArticleSeriesItem) int64 {
+		return src.ArticleId
+	}), nil
+}
+
+func (r *ArticleSeriesRepositoryImpl) FindByArticle(ctx context.Context, aid int64) (int64, error) {
+	item, err := r.dao.FindItemByArticle(ctx, aid)
+	if err != nil {
+		return 0, err
+	}
+	return item.SeriesId, nil
+}
+
+func (r *ArticleSeriesRepositoryImpl) DeleteByArticle(ctx context.Context, aid int64) error {
+	return r.dao.DeleteItemByArticle(ctx, aid)
+}
+
+func (r *ArticleSeriesRepositoryImpl) toEntity(s domain.ArticleSeries) dao.ArticleSeries {
+	return dao.ArticleSeries{
+		Id:          s.Id,
+		AuthorId:    s.Author.Id,
+		Title:       s.Title,
+		Description: s.Description,
+	}
+}
+
+func (r *ArticleSeriesRepositoryImpl) toDomain(s dao.ArticleSeries) domain.ArticleSeries {
+	return domain.ArticleSeries{
+		Id: s.Id,
+		Author: domain.Author{
+			Id: s.AuthorId,
+		},
+		Title:       s.Title,
+		Description: s.Description,
+		Ctime:       time.UnixMilli(s.Ctime),
+		Utime:       time.UnixMilli(s.Utime),
+	}
+}
@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/cache"
+	cachemocks "geek-basic-go/webook/internal/repository/cache/mocks"
 	"geek-basic-go/webook/internal/repository/dao"
 	daomocks "geek-basic-go/webook/internal/repository/dao/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 func TestCachedArticleRepository_SyncV1(t *testing.T) {
@@ -90,3 +94,178 @@ func TestCachedArticleRepository_SyncV1(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedArticleRepository_Sync(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache)
+		art  domain.Article
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "发表成功，删掉线上库的缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache) {
+				d := daomocks.NewMockArticleDao(ctrl)
+				d.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(11), nil)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().DeleteFirstPage(gomock.Any(), int64(123)).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), int64(11)).Return(nil)
+				c.EXPECT().DeleteFeed(gomock.Any(), int64(123)).Return(nil)
+				c.EXPECT().DeleteFeed(gomock.Any(), int64(0)).Return(nil)
+				// 不能用请求里面的文章设置缓存，标签可能是 nil
+				c.EXPECT().DeletePub(gomock.Any(), int64(11)).Return(nil)
+				return d, c
+			},
+			art: domain.Article{
+				Id:     11,
+				Title:  "标题",
+				Author: domain.Author{Id: 123},
+				Status: domain.ArticleStatusPendingReview,
+			},
+			wantId: 11,
+		},
+		{
+			name: "发表失败，不动缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache) {
+				d := daomocks.NewMockArticleDao(ctrl)
+				d.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("mock db error"))
+				return d, cachemocks.NewMockArticleCache(ctrl)
+			},
+			art: domain.Article{
+				Id:     11,
+				Title:  "标题",
+				Author: domain.Author{Id: 123},
+				Status: domain.ArticleStatusPublished,
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewArticleRepository(d, dao.NewMemoryArticleSearchDao(), nil, c)
+			id, err := repo.Sync(context.Background(), tc.art)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestCachedArticleRepository_GetByAuthor(t *testing.T) {
+	// window 按照 (utime, id) 倒序，id 越大越新
+	window := func(n int) []domain.Article {
+		res := make([]domain.Article, 0, n)
+		for i := n; i > 0; i-- {
+			res = append(res, domain.Article{
+				Id:     int64(i),
+				Author: domain.Author{Id: 123},
+				Utime:  time.UnixMilli(int64(i) * 1000),
+				Ctime:  time.UnixMilli(0),
+			})
+		}
+		return res
+	}
+	daoArts := func(arts []domain.Article) []dao.Article {
+		res := make([]dao.Article, 0, len(arts))
+		for _, art := range arts {
+			res = append(res, dao.Article{Id: art.Id, AuthorId: 123, Utime: art.Utime.UnixMilli()})
+		}
+		return res
+	}
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache)
+		cursor domain.ArticleCursor
+		limit  int
+
+		wantIds []int64
+		wantErr error
+	}{
+		{
+			name: "第一页命中缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetFirstPage(gomock.Any(), int64(123)).Return(window(5), nil)
+				return daomocks.NewMockArticleDao(ctrl), c
+			},
+			limit:   2,
+			wantIds: []int64{5, 4},
+		},
+		{
+			name: "后面的页落在缓存窗口里面",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetFirstPage(gomock.Any(), int64(123)).Return(window(5), nil)
+				return daomocks.NewMockArticleDao(ctrl), c
+			},
+			cursor:  domain.ArticleCursor{Utime: time.UnixMilli(4000), Id: 4},
+			limit:   10,
+			wantIds: []int64{3, 2, 1},
+		},
+		{
+			name: "第一页没有命中缓存，查出整个窗口",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetFirstPage(gomock.Any(), int64(123)).Return(nil, errors.New("缓存未命中"))
+				c.EXPECT().SetFirstPage(gomock.Any(), int64(123), gomock.Any()).AnyTimes().Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+				d := daomocks.NewMockArticleDao(ctrl)
+				d.EXPECT().GetByAuthor(gomock.Any(), int64(123), int64(0), int64(0), firstPageWindow).
+					Return(daoArts(window(3)), nil)
+				return d, c
+			},
+			limit:   2,
+			wantIds: []int64{3, 2},
+		},
+		{
+			name: "窗口满了而且剩下的不够一页，查数据库",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetFirstPage(gomock.Any(), int64(123)).Return(window(firstPageWindow + 1)[:firstPageWindow], nil)
+				d := daomocks.NewMockArticleDao(ctrl)
+				d.EXPECT().GetByAuthor(gomock.Any(), int64(123), int64(3000), int64(3), 10).
+					Return(daoArts(window(2)), nil)
+				return d, c
+			},
+			cursor:  domain.ArticleCursor{Utime: time.UnixMilli(3000), Id: 3},
+			limit:   10,
+			wantIds: []int64{2, 1},
+		},
+		{
+			name: "查询数据库失败",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetFirstPage(gomock.Any(), int64(123)).Return(nil, errors.New("缓存未命中"))
+				d := daomocks.NewMockArticleDao(ctrl)
+				d.EXPECT().GetByAuthor(gomock.Any(), int64(123), int64(0), int64(0), firstPageWindow).
+					Return(nil, errors.New("mock db error"))
+				return d, c
+			},
+			limit:   10,
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewArticleRepository(d, nil, nil, c)
+			arts, err := repo.GetByAuthor(context.Background(), 123, tc.cursor, tc.limit)
+			assert.Equal(t, tc.wantErr, err)
+			ids := make([]int64, 0, len(arts))
+			for _, art := range arts {
+				ids = append(ids, art.Id)
+			}
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.wantIds, ids)
+			}
+			// 等待异步回写缓存
+			time.Sleep(time.Millisecond * 10)
+		})
+	}
+}
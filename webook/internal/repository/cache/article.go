@@ -16,8 +16,14 @@ type ArticleCache interface {
 	DeleteFirstPage(ctx context.Context, uid int64) error
 	Get(ctx context.Context, id int64) (domain.Article, error)
 	Set(ctx context.Context, art dao.Article) error
+	Delete(ctx context.Context, id int64) error
 	GetPub(ctx context.Context, id int64) (domain.Article, error)
 	SetPub(ctx context.Context, res domain.Article) error
+	DeletePub(ctx context.Context, id int64) error
+	// GetFeed 订阅源里面的文章，uid 是 0 代表全站最新的文章
+	GetFeed(ctx context.Context, uid int64) ([]domain.Article, error)
+	SetFeed(ctx context.Context, uid int64, arts []domain.Article) error
+	DeleteFeed(ctx context.Context, uid int64) error
 }
 
 type ArticleRedisCache struct {
@@ -48,6 +54,10 @@ func (a *ArticleRedisCache) GetPub(ctx context.Context, id int64) (domain.Articl
 	return res, err
 }
 
+func (a *ArticleRedisCache) DeletePub(ctx context.Context, id int64) error {
+	return a.client.Del(ctx, a.pubKey(id)).Err()
+}
+
 func (a *ArticleRedisCache) Get(ctx context.Context, id int64) (domain.Article, error) {
 	val, err := a.client.Get(ctx, a.key(id)).Bytes()
 	if err != nil {
@@ -68,6 +78,10 @@ func (a *ArticleRedisCache) Set(ctx context.Context, art dao.Article) error {
 	return a.client.Set(ctx, a.key(art.Id), val, time.Minute*1).Err()
 }
 
+func (a *ArticleRedisCache) Delete(ctx context.Context, id int64) error {
+	return a.client.Del(ctx, a.key(id)).Err()
+}
+
 func (a *ArticleRedisCache) DeleteFirstPage(ctx context.Context, uid int64) error {
 	key := a.firstKey(uid)
 	return a.client.Del(ctx, key).Err()
@@ -90,18 +104,56 @@ func (a *ArticleRedisCache) GetFirstPage(ctx context.Context, uid int64) ([]doma
 }
 
 func (a *ArticleRedisCache) SetFirstPage(ctx context.Context, uid int64, arts []domain.Article) error {
-	// Content只缓存摘要
-	for i := range arts {
-		arts[i].Content = arts[i].Abstract()
+	// Content只缓存摘要，复制一份，不要修改调用者的数据
+	abstracts := make([]domain.Article, len(arts))
+	for i, art := range arts {
+		art.Content = art.Abstract()
+		abstracts[i] = art
 	}
 	key := a.firstKey(uid)
-	val, err := json.Marshal(arts)
+	val, err := json.Marshal(abstracts)
 	if err != nil {
 		return err
 	}
 	return a.client.Set(ctx, key, val, time.Minute*10).Err()
 }
 
+func (a *ArticleRedisCache) GetFeed(ctx context.Context, uid int64) ([]domain.Article, error) {
+	val, err := a.client.Get(ctx, a.feedKey(uid)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var res []domain.Article
+	err = json.Unmarshal(val, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetFeed 订阅源要输出渲染之后的全文，所以 Html 也缓存起来，Content 用不上就不缓存了
+func (a *ArticleRedisCache) SetFeed(ctx context.Context, uid int64, arts []domain.Article) error {
+	feed := make([]domain.Article, len(arts))
+	for i, art := range arts {
+		art.Summary = art.Abstract()
+		art.Content = ""
+		feed[i] = art
+	}
+	val, err := json.Marshal(feed)
+	if err != nil {
+		return err
+	}
+	return a.client.Set(ctx, a.feedKey(uid), val, time.Minute*10).Err()
+}
+
+func (a *ArticleRedisCache) DeleteFeed(ctx context.Context, uid int64) error {
+	return a.client.Del(ctx, a.feedKey(uid)).Err()
+}
+
+func (a *ArticleRedisCache) feedKey(uid int64) string {
+	return fmt.Sprintf("article:feed:%d", uid)
+}
+
 func (a *ArticleRedisCache) firstKey(uid int64) string {
 	return fmt.Sprintf("article:first_page:%d", uid)
 }
@@ -13,19 +13,49 @@ import (
 var (
 	//go:embed lua/incr_cnt.lua
 	luaIncrCnt string
+	//go:embed lua/set_user_state.lua
+	luaSetUserState string
 )
 
 const fieldReadCnt = "read_cnt"
 const fieldLikeCnt = "like_cnt"
 const fieldCollectCnt = "collect_cnt"
+const fieldCommentCnt = "comment_cnt"
+
+const (
+	// userStateMaxSize 一个资源最多缓存多少个用户的点赞或者收藏状态
+	userStateMaxSize = 10000
+	userStateTTL     = time.Minute * 15
+)
 
 type InteractiveCache interface {
 	IncrReadCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	// BatchIncrReadCntIfPresent 用 pipeline 一次发过去，阅读数分别加上 cnts
+	BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error
 	IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, id int64) error
+	DecrCollectCntIfPresent(ctx context.Context, biz string, id int64) error
+	IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error
+	// GetByIds 用 pipeline 一次查完，缓存里面没有的不在结果里面
+	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
+	BatchSet(ctx context.Context, biz string, intrs map[int64]domain.Interactive) error
+	Delete(ctx context.Context, biz string, bizId int64) error
+
+	// GetLiked 和 GetCollected 没有缓存这个用户的时候返回 ErrKeyNotExist
+	GetLiked(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
+	// SetLiked 和 SetCollected 点赞、收藏之后更新。
+	// 一个资源缓存的用户数量有上限，满了之后只更新已经缓存了的用户
+	SetLiked(ctx context.Context, biz string, bizId int64, uid int64, liked bool) error
+	// FillLiked 和 FillCollected 查了数据库之后回写，已经缓存了就不覆盖
+	FillLiked(ctx context.Context, biz string, bizId int64, uid int64, liked bool) error
+	GetCollected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
+	SetCollected(ctx context.Context, biz string, bizId int64, uid int64, collected bool) error
+	FillCollected(ctx context.Context, biz string, bizId int64, uid int64, collected bool) error
+	// DeleteUserState 删掉一个资源所有用户的点赞和收藏状态
+	DeleteUserState(ctx context.Context, biz string, bizId int64) error
 }
 
 type InteractiveRedisCache struct {
@@ -41,14 +71,41 @@ func (i *InteractiveRedisCache) Get(ctx context.Context, biz string, bizId int64
 	if len(res) == 0 {
 		return domain.Interactive{}, ErrKeyNotExist
 	}
+	return i.toDomain(res), nil
+}
+
+func (i *InteractiveRedisCache) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	pipe := i.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, 0, len(bizIds))
+	for _, id := range bizIds {
+		cmds = append(cmds, pipe.HGetAll(ctx, i.key(biz, id)))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.Interactive, len(bizIds))
+	for j, cmd := range cmds {
+		val := cmd.Val()
+		if len(val) == 0 {
+			continue
+		}
+		res[bizIds[j]] = i.toDomain(val)
+	}
+	return res, nil
+}
+
+func (i *InteractiveRedisCache) toDomain(res map[string]string) domain.Interactive {
 	readCnt, _ := strconv.ParseInt(res[fieldReadCnt], 10, 64)
 	likeCnt, _ := strconv.ParseInt(res[fieldLikeCnt], 10, 64)
 	collectCnt, _ := strconv.ParseInt(res[fieldCollectCnt], 10, 64)
+	commentCnt, _ := strconv.ParseInt(res[fieldCommentCnt], 10, 64)
 	return domain.Interactive{
 		ReadCnt:    readCnt,
 		LikeCnt:    likeCnt,
 		CollectCnt: collectCnt,
-	}, nil
+		CommentCnt: commentCnt,
+	}
 }
 
 func (i *InteractiveRedisCache) Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error {
@@ -57,6 +114,7 @@ func (i *InteractiveRedisCache) Set(ctx context.Context, biz string, bizId int64
 		fieldReadCnt, intr.ReadCnt,
 		fieldLikeCnt, intr.LikeCnt,
 		fieldCollectCnt, intr.CollectCnt,
+		fieldCommentCnt, intr.CommentCnt,
 	).Err()
 	if err != nil {
 		return err
@@ -64,6 +122,22 @@ func (i *InteractiveRedisCache) Set(ctx context.Context, biz string, bizId int64
 	return i.client.Expire(ctx, key, time.Minute*13).Err()
 }
 
+func (i *InteractiveRedisCache) BatchSet(ctx context.Context, biz string, intrs map[int64]domain.Interactive) error {
+	pipe := i.client.Pipeline()
+	for id, intr := range intrs {
+		key := i.key(biz, id)
+		pipe.HSet(ctx, key,
+			fieldReadCnt, intr.ReadCnt,
+			fieldLikeCnt, intr.LikeCnt,
+			fieldCollectCnt, intr.CollectCnt,
+			fieldCommentCnt, intr.CommentCnt,
+		)
+		pipe.Expire(ctx, key, time.Minute*13)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func NewInteractiveRedisCache(client redis.Cmdable) InteractiveCache {
 	return &InteractiveRedisCache{
 		client: client,
@@ -75,6 +149,16 @@ func (i *InteractiveRedisCache) IncrCollectCntIfPresent(ctx context.Context, biz
 	return i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCollectCnt, 1).Err()
 }
 
+func (i *InteractiveRedisCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	key := i.key(biz, bizId)
+	return i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCollectCnt, -1).Err()
+}
+
+func (i *InteractiveRedisCache) IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	key := i.key(biz, bizId)
+	return i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCommentCnt, 1).Err()
+}
+
 func (i *InteractiveRedisCache) IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	key := i.key(biz, bizId)
 	return i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldLikeCnt, 1).Err()
@@ -92,6 +176,77 @@ func (i *InteractiveRedisCache) IncrReadCntIfPresent(ctx context.Context, biz st
 	return i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldReadCnt, 1).Err()
 }
 
+func (i *InteractiveRedisCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error {
+	pipe := i.client.Pipeline()
+	for j := range bizIds {
+		pipe.Eval(ctx, luaIncrCnt, []string{i.key(bizs[j], bizIds[j])}, fieldReadCnt, cnts[j])
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (i *InteractiveRedisCache) Delete(ctx context.Context, biz string, bizId int64) error {
+	return i.client.Del(ctx, i.key(biz, bizId)).Err()
+}
+
+func (i *InteractiveRedisCache) GetLiked(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
+	return i.getUserState(ctx, i.likedKey(biz, bizId), uid)
+}
+
+func (i *InteractiveRedisCache) SetLiked(ctx context.Context, biz string, bizId int64, uid int64, liked bool) error {
+	return i.setUserState(ctx, i.likedKey(biz, bizId), uid, liked, false)
+}
+
+func (i *InteractiveRedisCache) FillLiked(ctx context.Context, biz string, bizId int64, uid int64, liked bool) error {
+	return i.setUserState(ctx, i.likedKey(biz, bizId), uid, liked, true)
+}
+
+func (i *InteractiveRedisCache) GetCollected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
+	return i.getUserState(ctx, i.collectedKey(biz, bizId), uid)
+}
+
+func (i *InteractiveRedisCache) SetCollected(ctx context.Context, biz string, bizId int64, uid int64, collected bool) error {
+	return i.setUserState(ctx, i.collectedKey(biz, bizId), uid, collected, false)
+}
+
+func (i *InteractiveRedisCache) FillCollected(ctx context.Context, biz string, bizId int64, uid int64, collected bool) error {
+	return i.setUserState(ctx, i.collectedKey(biz, bizId), uid, collected, true)
+}
+
+func (i *InteractiveRedisCache) DeleteUserState(ctx context.Context, biz string, bizId int64) error {
+	return i.client.Del(ctx, i.likedKey(biz, bizId), i.collectedKey(biz, bizId)).Err()
+}
+
+func (i *InteractiveRedisCache) getUserState(ctx context.Context, key string, uid int64) (bool, error) {
+	// 没有缓存这个用户的时候是 redis.Nil，也就是 ErrKeyNotExist
+	val, err := i.client.HGet(ctx, key, strconv.FormatInt(uid, 10)).Result()
+	if err != nil {
+		return false, err
+	}
+	return val == "1", nil
+}
+
+func (i *InteractiveRedisCache) setUserState(ctx context.Context, key string, uid int64, state bool, onlyAbsent bool) error {
+	return i.client.Eval(ctx, luaSetUserState, []string{key},
+		strconv.FormatInt(uid, 10), boolToInt(state), userStateMaxSize,
+		int(userStateTTL.Seconds()), boolToInt(onlyAbsent)).Err()
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (i *InteractiveRedisCache) likedKey(biz string, bizId int64) string {
+	return fmt.Sprintf("interactive:liked:%s:%d", biz, bizId)
+}
+
+func (i *InteractiveRedisCache) collectedKey(biz string, bizId int64) string {
+	return fmt.Sprintf("interactive:collected:%s:%d", biz, bizId)
+}
+
 func (i *InteractiveRedisCache) key(biz string, bizId int64) string {
 	return fmt.Sprintf("interactive:%s:%d", biz, bizId)
 }
@@ -0,0 +1,29 @@
+-- 某个资源上用户的点赞或者收藏状态，hash 的 field 是 uid
+local key = KEYS[1]
+local uid = ARGV[1]
+-- 1 是点赞了或者收藏了，0 是没有
+local val = ARGV[2]
+-- 最多缓存多少个用户，满了之后新用户不再缓存，直接查数据库
+local maxSize = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+-- 1 是查数据库之后回写，已经缓存了就不覆盖，避免盖掉并发的点赞或者收藏
+local onlyAbsent = ARGV[5] == "1"
+
+if redis.call("HEXISTS", key, uid) == 1 then
+    if onlyAbsent then
+        return 0
+    end
+    -- 点赞或者收藏之后，已经缓存了的一定要更新，不然就是脏数据
+    redis.call("HSET", key, uid, val)
+    return 1
+end
+local size = redis.call("HLEN", key)
+if size >= maxSize then
+    return 0
+end
+redis.call("HSET", key, uid, val)
+if size == 0 then
+    -- 新建的 key，过期时间从第一个用户开始算，热门资源也不会一直不过期
+    redis.call("EXPIRE", key, ttl)
+end
+return 1
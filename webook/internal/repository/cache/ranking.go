@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"sync"
+	"time"
+)
+
+var ErrRankingNotFound = errors.New("热榜还没有计算出来或者已经过期")
+
+// RankingRedisCache 所有实例共享的热榜
+type RankingRedisCache struct {
+	client redis.Cmdable
+	key    string
+	// expiration 要比计算热榜的间隔长很多，计算失败几次也不会没有热榜
+	expiration time.Duration
+}
+
+func NewRankingRedisCache(client redis.Cmdable) *RankingRedisCache {
+	return &RankingRedisCache{
+		client:     client,
+		key:        "ranking:article",
+		expiration: time.Hour,
+	}
+}
+
+func (r *RankingRedisCache) Set(ctx context.Context, arts []domain.HotArticle) error {
+	val, err := json.Marshal(arts)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.key, val, r.expiration).Err()
+}
+
+func (r *RankingRedisCache) Get(ctx context.Context) ([]domain.HotArticle, error) {
+	val, err := r.client.Get(ctx, r.key).Bytes()
+	if err == redis.Nil {
+		return nil, ErrRankingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var res []domain.HotArticle
+	err = json.Unmarshal(val, &res)
+	return res, err
+}
+
+// RankingLocalCache 本地的热榜，热榜接口的访问量很大，大部分请求不用访问 Redis，Redis 挂了也还有热榜
+type RankingLocalCache struct {
+	mu   sync.RWMutex
+	arts []domain.HotArticle
+	ddl  time.Time
+	// expiration 过了这个时间就要重新从 Redis 里面加载，别的实例计算的热榜要同步过来
+	expiration time.Duration
+}
+
+func NewRankingLocalCache() *RankingLocalCache {
+	return &RankingLocalCache{
+		expiration: time.Minute,
+	}
+}
+
+func (r *RankingLocalCache) Set(ctx context.Context, arts []domain.HotArticle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.arts = arts
+	r.ddl = time.Now().Add(r.expiration)
+	return nil
+}
+
+func (r *RankingLocalCache) Get(ctx context.Context) ([]domain.HotArticle, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.arts == nil || time.Now().After(r.ddl) {
+		return nil, ErrRankingNotFound
+	}
+	return r.arts, nil
+}
+
+// ForceGet 不管有没有过期都返回，Redis 不可用的时候兜底
+func (r *RankingLocalCache) ForceGet(ctx context.Context) ([]domain.HotArticle, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.arts == nil {
+		return nil, ErrRankingNotFound
+	}
+	return r.arts, nil
+}
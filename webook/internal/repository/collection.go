@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/cache"
+	"geek-basic-go/webook/internal/repository/dao"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrCollectionNotFound = dao.ErrRecordNotFound
+
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	// Rename c 里面只用到 Id、Uid 和 Name
+	Rename(ctx context.Context, c domain.Collection) error
+	// SetPublic c 里面只用到 Id、Uid 和 Public
+	SetPublic(ctx context.Context, c domain.Collection) error
+	GetById(ctx context.Context, id int64) (domain.Collection, error)
+	ListByUser(ctx context.Context, uid int64, onlyPublic bool) ([]domain.Collection, error)
+	// Delete 连里面的收藏一起删掉，收藏数会减一
+	Delete(ctx context.Context, id int64, uid int64) error
+	// DeleteAndMove 里面的收藏移到收藏夹 to
+	DeleteAndMove(ctx context.Context, id int64, uid int64, to int64) error
+	// ListItems 按照收藏时间倒序，Article 是空的，由 service 补充
+	ListItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error)
+}
+
+// CollectionRepositoryImpl 收藏夹都是按照主键或者索引查，暂时不需要缓存，但是删除的时候要更新收藏数的缓存
+type CollectionRepositoryImpl struct {
+	dao       dao.CollectionDao
+	intrCache cache.InteractiveCache
+	l         logger.LoggerV1
+}
+
+func NewCollectionRepository(dao dao.CollectionDao, intrCache cache.InteractiveCache,
+	l logger.LoggerV1) CollectionRepository {
+	return &CollectionRepositoryImpl{
+		dao:       dao,
+		intrCache: intrCache,
+		l:         l,
+	}
+}
+
+func (r *CollectionRepositoryImpl) Create(ctx context.Context, c domain.Collection) (int64, error) {
+	return r.dao.Insert(ctx, r.toEntity(c))
+}
+
+func (r *CollectionRepositoryImpl) Rename(ctx context.Context, c domain.Collection) error {
+	return r.dao.UpdateName(ctx, c.Id, c.Uid, c.Name)
+}
+
+func (r *CollectionRepositoryImpl) SetPublic(ctx context.Context, c domain.Collection) error {
+	return r.dao.UpdatePublic(ctx, c.Id, c.Uid, c.Public)
+}
+
+func (r *CollectionRepositoryImpl) GetById(ctx context.Context, id int64) (domain.Collection, error) {
+	c, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return r.toDomain(c), nil
+}
+
+func (r *CollectionRepositoryImpl) ListByUser(ctx context.Context, uid int64, onlyPublic bool) ([]domain.Collection, error) {
+	cs, err := r.dao.ListByUser(ctx, uid, onlyPublic)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Collection, domain.Collection](cs, func(idx int, src dao.Collection) domain.Collection {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *CollectionRepositoryImpl) Delete(ctx context.Context, id int64, uid int64) error {
+	items, err := r.dao.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		// 缓存更新失败只是收藏数和收藏状态暂时不准，过期之后就好了
+		er := r.intrCache.SetCollected(ctx, item.Biz, item.BizId, uid, false)
+		if er != nil {
+			r.l.Error("更新收藏状态缓存失败",
+				logger.String("biz", item.Biz),
+				logger.Int64("bizId", item.BizId),
+				logger.Int64("uid", uid),
+				logger.Error(er))
+		}
+		er = r.intrCache.DecrCollectCntIfPresent(ctx, item.Biz, item.BizId)
+		if er != nil {
+			r.l.Error("更新收藏数缓存失败",
+				logger.String("biz", item.Biz),
+				logger.Int64("bizId", item.BizId),
+				logger.Error(er))
+		}
+	}
+	return nil
+}
+
+func (r *CollectionRepositoryImpl) DeleteAndMove(ctx context.Context, id int64, uid int64, to int64) error {
+	return r.dao.DeleteAndMove(ctx, id, uid, to)
+}
+
+func (r *CollectionRepositoryImpl) ListItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error) {
+	items, err := r.dao.ListItems(ctx, uid, cid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserCollectionBiz, domain.CollectionItem](items, func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+		return domain.CollectionItem{
+			Cid:   src.Cid,
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Ctime: time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
+
+func (r *CollectionRepositoryImpl) toEntity(c domain.Collection) dao.Collection {
+	return dao.Collection{
+		Id:     c.Id,
+		Uid:    c.Uid,
+		Name:   c.Name,
+		Public: c.Public,
+	}
+}
+
+func (r *CollectionRepositoryImpl) toDomain(c dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:     c.Id,
+		Uid:    c.Uid,
+		Name:   c.Name,
+		Public: c.Public,
+		Ctime:  time.UnixMilli(c.Ctime),
+		Utime:  time.UnixMilli(c.Utime),
+	}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/cache"
+	"geek-basic-go/webook/internal/repository/dao"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrCommentNotFound = dao.ErrRecordNotFound
+
+type CommentRepository interface {
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	Delete(ctx context.Context, c domain.Comment) error
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	ListRoots(ctx context.Context, biz string, bizId int64, offset int, limit int) ([]domain.Comment, error)
+	ListReplies(ctx context.Context, rid int64, offset int, limit int) ([]domain.Comment, error)
+}
+
+// CachedCommentRepository 评论本身不缓存，只维护 interactive 缓存里面的评论数量
+type CachedCommentRepository struct {
+	dao       dao.CommentDao
+	intrCache cache.InteractiveCache
+	l         logger.LoggerV1
+}
+
+func NewCachedCommentRepository(dao dao.CommentDao,
+	intrCache cache.InteractiveCache, l logger.LoggerV1) CommentRepository {
+	return &CachedCommentRepository{
+		dao:       dao,
+		intrCache: intrCache,
+		l:         l,
+	}
+}
+
+func (r *CachedCommentRepository) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	id, err := r.dao.Insert(ctx, r.toEntity(c))
+	if err != nil {
+		return 0, err
+	}
+	err = r.intrCache.IncrCommentCntIfPresent(ctx, c.Biz, c.BizId)
+	if err != nil {
+		// 评论已经保存了，计数不准影响不大
+		r.l.Error("更新缓存的评论数失败",
+			logger.String("biz", c.Biz),
+			logger.Int64("bizId", c.BizId),
+			logger.Error(err))
+	}
+	return id, nil
+}
+
+func (r *CachedCommentRepository) Delete(ctx context.Context, c domain.Comment) error {
+	err := r.dao.Delete(ctx, c.Id)
+	if err != nil {
+		return err
+	}
+	// 删除根评论的时候不知道一起删了多少条回复，直接删缓存
+	return r.intrCache.Delete(ctx, c.Biz, c.BizId)
+}
+
+func (r *CachedCommentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := r.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return r.toDomain(c), nil
+}
+
+func (r *CachedCommentRepository) ListRoots(ctx context.Context, biz string, bizId int64, offset int, limit int) ([]domain.Comment, error) {
+	cs, err := r.dao.ListRoots(ctx, biz, bizId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Comment, domain.Comment](cs, func(idx int, src dao.Comment) domain.Comment {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *CachedCommentRepository) ListReplies(ctx context.Context, rid int64, offset int, limit int) ([]domain.Comment, error) {
+	cs, err := r.dao.ListReplies(ctx, rid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Comment, domain.Comment](cs, func(idx int, src dao.Comment) domain.Comment {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *CachedCommentRepository) toEntity(c domain.Comment) dao.Comment {
+	return dao.Comment{
+		Id:       c.Id,
+		Biz:      c.Biz,
+		BizId:    c.BizId,
+		ParentId: c.ParentId,
+		RootId:   c.RootId,
+		Uid:      c.Commentator.Id,
+		Content:  c.Content,
+	}
+}
+
+func (r *CachedCommentRepository) toDomain(c dao.Comment) domain.Comment {
+	return domain.Comment{
+		Id:    c.Id,
+		Biz:   c.Biz,
+		BizId: c.BizId,
+		Commentator: domain.Author{
+			Id: c.Uid,
+		},
+		Content:  c.Content,
+		ParentId: c.ParentId,
+		RootId:   c.RootId,
+		ReplyCnt: c.ReplyCnt,
+		Ctime:    time.UnixMilli(c.Ctime),
+		Utime:    time.UnixMilli(c.Utime),
+	}
+}
@@ -9,16 +9,51 @@ import (
 	"time"
 )
 
+// ErrArticleVersionConflict 修改的时候带的版本号不是最新的，说明别的地方已经修改过了
+var ErrArticleVersionConflict = errors.New("文章版本冲突")
+
 type ArticleDao interface {
+	// Insert 新建的文章版本号是 1
 	Insert(ctx context.Context, art Article) (int64, error)
+	// UpdateById art.Version 必须是当前的版本号，否则返回 ErrArticleVersionConflict，成功之后版本号加一
 	UpdateById(ctx context.Context, art Article) error
+	// Sync 保存并发表，版本号的处理和 Insert、UpdateById 一样
 	Sync(ctx context.Context, art Article) (int64, error)
-	SyncStatus(ctx context.Context, uid int64, id int64, status domain.ArticleStatus) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error)
+	// SyncStatus 不校验作者，有没有权限是 service 层根据协作者角色判断的
+	SyncStatus(ctx context.Context, id int64, status domain.ArticleStatus) error
+	// GetByAuthor 按照 (utime, id) 倒序翻页，utime 和 id 是上一页最后一篇文章的，都是 0 代表第一页
+	GetByAuthor(ctx context.Context, uid int64, utime int64, id int64, limit int) ([]Article, error)
+	// CountByAuthor 作者的文章数量，不包括回收站里面的
+	CountByAuthor(ctx context.Context, uid int64) (int64, error)
 	GetById(ctx context.Context, id int64) (Article, error)
 	GetPubById(ctx context.Context, id int64) (PublishedArticle, error)
+	// ListPubByTag 按照标签查找已发表的文章，按照更新时间倒序
+	ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]PublishedArticle, error)
+	// ListPubByAuthor 作者已发表的文章，按照更新时间倒序
+	ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]PublishedArticle, error)
+	// ListPubLatest 全站最新发表的文章，按照更新时间倒序
+	ListPubLatest(ctx context.Context, limit int) ([]PublishedArticle, error)
+	// ListPubSince 第一次发表的时间（线上库的 ctime）不早于 since、id 大于 id 的已发表文章，
+	// 按照 id 正序，不包括内容和标签。重新发表只会修改 utime，不会让老文章重新排到前面
+	ListPubSince(ctx context.Context, since int64, id int64, limit int) ([]PublishedArticle, error)
+	// ScanPub 线上库里面 id 大于 id 的文章，不管什么状态，按照 id 正序，包括内容，不包括标签
+	ScanPub(ctx context.Context, id int64, limit int) ([]PublishedArticle, error)
+	// UpdatePubStats 只修改线上库的长度统计，不修改 utime，免得回填打乱最新文章的顺序
+	UpdatePubStats(ctx context.Context, art PublishedArticle) error
+	// Trash 移到回收站，线上库也改成删除状态，版本号加一。不存在或者已经在回收站里面返回 ErrRecordNotFound
+	Trash(ctx context.Context, id int64) error
+	// Restore 从回收站恢复成草稿，线上库改成仅自己可见，要重新发表。不在回收站里面返回 ErrRecordNotFound
+	Restore(ctx context.Context, id int64) error
+	// ListTrash 作者回收站里面的文章，按照删除时间倒序
+	ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]Article, error)
+	// ListTrashBefore 所有作者的回收站里面删除时间早于 dtime 的文章，按照删除时间正序
+	ListTrashBefore(ctx context.Context, dtime int64, limit int) ([]Article, error)
+	// Purge 彻底删除制作库和线上库里面的文章和标签，只删除回收站里面的文章，已经删掉了也不是错误
+	Purge(ctx context.Context, id int64) error
 }
 
+var statusDeleted = uint8(domain.ArticleStatusDeleted)
+
 type ArticleGormDao struct {
 	db *gorm.DB
 }
@@ -26,30 +61,164 @@ type ArticleGormDao struct {
 func (a *ArticleGormDao) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
 	var res PublishedArticle
 	err := a.db.WithContext(ctx).Where("id=?", id).First(&res).Error
+	if err != nil {
+		return res, err
+	}
+	tags, err := findTagNames(a.db.WithContext(ctx), "published_article_tags", []int64{id})
+	res.Tags = tags[id]
 	return res, err
 }
 
+func (a *ArticleGormDao) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]PublishedArticle, error) {
+	var arts []PublishedArticle
+	err := a.db.WithContext(ctx).
+		Joins("JOIN published_article_tags ON published_article_tags.article_id = published_articles.id").
+		Joins("JOIN tags ON tags.id = published_article_tags.tag_id").
+		Where("tags.name = ? AND published_articles.status = ?", tag, domain.ArticleStatusPublished).
+		Offset(offset).
+		Limit(limit).
+		Order("published_articles.utime DESC, published_articles.id DESC").
+		Find(&arts).Error
+	if err != nil || len(arts) == 0 {
+		return arts, err
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	tags, err := findTagNames(a.db.WithContext(ctx), "published_article_tags", ids)
+	for i := range arts {
+		arts[i].Tags = tags[arts[i].Id]
+	}
+	return arts, err
+}
+
+func (a *ArticleGormDao) ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]PublishedArticle, error) {
+	var arts []PublishedArticle
+	err := a.db.WithContext(ctx).
+		Where("author_id = ? AND status = ?", uid, domain.ArticleStatusPublished).
+		Offset(offset).
+		Limit(limit).
+		Order("utime DESC, id DESC").
+		Find(&arts).Error
+	if err != nil || len(arts) == 0 {
+		return arts, err
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	tags, err := findTagNames(a.db.WithContext(ctx), "published_article_tags", ids)
+	for i := range arts {
+		arts[i].Tags = tags[arts[i].Id]
+	}
+	return arts, err
+}
+
+func (a *ArticleGormDao) ListPubLatest(ctx context.Context, limit int) ([]PublishedArticle, error) {
+	var arts []PublishedArticle
+	err := a.db.WithContext(ctx).
+		Where("status = ?", domain.ArticleStatusPublished).
+		Limit(limit).
+		Order("utime DESC, id DESC").
+		Find(&arts).Error
+	if err != nil || len(arts) == 0 {
+		return arts, err
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	tags, err := findTagNames(a.db.WithContext(ctx), "published_article_tags", ids)
+	for i := range arts {
+		arts[i].Tags = tags[arts[i].Id]
+	}
+	return arts, err
+}
+
+func (a *ArticleGormDao) ListPubSince(ctx context.Context, since int64, id int64, limit int) ([]PublishedArticle, error) {
+	var arts []PublishedArticle
+	err := a.db.WithContext(ctx).
+		Select("id", "title", "summary", "author_id", "status", "ctime", "utime",
+			"word_cnt", "read_minutes", "image_cnt", "code_block_cnt").
+		Where("id > ? AND status = ? AND ctime >= ?", id, domain.ArticleStatusPublished, since).
+		Limit(limit).
+		Order("id ASC").
+		Find(&arts).Error
+	return arts, err
+}
+
+func (a *ArticleGormDao) ScanPub(ctx context.Context, id int64, limit int) ([]PublishedArticle, error) {
+	var arts []PublishedArticle
+	err := a.db.WithContext(ctx).
+		Where("id > ?", id).
+		Order("id ASC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (a *ArticleGormDao) UpdatePubStats(ctx context.Context, art PublishedArticle) error {
+	return a.db.WithContext(ctx).Model(&PublishedArticle{}).
+		Where("id = ?", art.Id).
+		Updates(map[string]any{
+			"word_cnt":       art.WordCnt,
+			"read_minutes":   art.ReadMinutes,
+			"image_cnt":      art.ImageCnt,
+			"code_block_cnt": art.CodeBlockCnt,
+		}).Error
+}
+
 func (a *ArticleGormDao) GetById(ctx context.Context, id int64) (Article, error) {
 	var art Article
 	err := a.db.WithContext(ctx).Where("id=?", id).First(&art).Error
+	if err != nil {
+		return art, err
+	}
+	tags, err := findTagNames(a.db.WithContext(ctx), "article_tags", []int64{id})
+	art.Tags = tags[id]
 	return art, err
 }
 
-func (a *ArticleGormDao) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
+func (a *ArticleGormDao) GetByAuthor(ctx context.Context, uid int64, utime int64, id int64, limit int) ([]Article, error) {
 	var arts []Article
-	err := a.db.WithContext(ctx).Where("author_id=?", uid).
-		Offset(offset).
+	// 回收站里面的文章单独列出来
+	query := a.db.WithContext(ctx).Where("author_id=? AND status<>?", uid, statusDeleted)
+	if id > 0 {
+		// 不用 OFFSET，翻多少页都能走索引，翻页的过程中文章被修改也不会重复或者遗漏
+		query = query.Where("utime < ? OR (utime = ? AND id < ?)", utime, utime, id)
+	}
+	err := query.
 		Limit(limit).
-		Order("utime DESC").
+		Order("utime DESC, id DESC").
 		Find(&arts).Error
+	if err != nil || len(arts) == 0 {
+		return arts, err
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	tags, err := findTagNames(a.db.WithContext(ctx), "article_tags", ids)
+	for i := range arts {
+		arts[i].Tags = tags[arts[i].Id]
+	}
 	return arts, err
 }
 
-func (a *ArticleGormDao) SyncStatus(ctx context.Context, uid int64, id int64, status domain.ArticleStatus) error {
+func (a *ArticleGormDao) CountByAuthor(ctx context.Context, uid int64) (int64, error) {
+	var cnt int64
+	err := a.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id=? AND status<>?", uid, statusDeleted).
+		Count(&cnt).Error
+	return cnt, err
+}
+
+func (a *ArticleGormDao) SyncStatus(ctx context.Context, id int64, status domain.ArticleStatus) error {
 	now := time.Now().UnixMilli()
 	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		res := tx.Model(&Article{}).
-			Where("id=? and author_id=?", id, uid).
+			Where("id=?", id).
 			Updates(map[string]any{
 				"utime":  now,
 				"status": status,
@@ -58,7 +227,7 @@ func (a *ArticleGormDao) SyncStatus(ctx context.Context, uid int64, id int64, st
 			return res.Error
 		}
 		if res.RowsAffected == 0 {
-			return errors.New("更新失败，ID不对或作者不对")
+			return ErrRecordNotFound
 		}
 
 		return tx.Model(&PublishedArticle{}).
@@ -71,6 +240,112 @@ func (a *ArticleGormDao) SyncStatus(ctx context.Context, uid int64, id int64, st
 	return err
 }
 
+func (a *ArticleGormDao) Trash(ctx context.Context, id int64) error {
+	return a.trash(ctx, &PublishedArticle{}, id)
+}
+
+// trash 线上库用哪张表由 pubModel 决定，ArticleBlobDao 的线上库是 PublishedArticleV2
+func (a *ArticleGormDao) trash(ctx context.Context, pubModel any, id int64) error {
+	now := time.Now().UnixMilli()
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id=? AND status<>?", id, statusDeleted).
+			Updates(map[string]any{
+				"status": statusDeleted,
+				"dtime":  now,
+				// 拿着删除之前的版本号修改会冲突，不会把回收站里面的文章改回草稿
+				"version": gorm.Expr("version + 1"),
+				"utime":   now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return tx.Model(pubModel).
+			Where("id=?", id).
+			Updates(map[string]any{
+				"status": statusDeleted,
+				"utime":  now,
+			}).Error
+	})
+}
+
+func (a *ArticleGormDao) Restore(ctx context.Context, id int64) error {
+	return a.restore(ctx, &PublishedArticle{}, id)
+}
+
+func (a *ArticleGormDao) restore(ctx context.Context, pubModel any, id int64) error {
+	now := time.Now().UnixMilli()
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id=? AND status=?", id, statusDeleted).
+			Updates(map[string]any{
+				"status":  domain.ArticleStatusUnpublished,
+				"dtime":   0,
+				"version": gorm.Expr("version + 1"),
+				"utime":   now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return tx.Model(pubModel).
+			Where("id=?", id).
+			Updates(map[string]any{
+				"status": domain.ArticleStatusPrivate,
+				"utime":  now,
+			}).Error
+	})
+}
+
+func (a *ArticleGormDao) ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
+	var arts []Article
+	err := a.db.WithContext(ctx).
+		Where("author_id=? AND status=?", uid, statusDeleted).
+		Offset(offset).
+		Limit(limit).
+		Order("dtime DESC, id DESC").
+		Find(&arts).Error
+	return arts, err
+}
+
+func (a *ArticleGormDao) ListTrashBefore(ctx context.Context, dtime int64, limit int) ([]Article, error) {
+	var arts []Article
+	err := a.db.WithContext(ctx).
+		Where("status=? AND dtime<?", statusDeleted, dtime).
+		Limit(limit).
+		Order("dtime ASC, id ASC").
+		Find(&arts).Error
+	return arts, err
+}
+
+func (a *ArticleGormDao) Purge(ctx context.Context, id int64) error {
+	return a.purge(ctx, &PublishedArticle{}, id)
+}
+
+func (a *ArticleGormDao) purge(ctx context.Context, pubModel any, id int64) error {
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先删制作库，顺便确认文章还在回收站里面，没有被恢复
+		res := tx.Where("id=? AND status=?", id, statusDeleted).Delete(&Article{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		err := tx.Where("article_id=?", id).Delete(&ArticleTag{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("id=?", id).Delete(pubModel).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("article_id=?", id).Delete(&PublishedArticleTag{}).Error
+	})
+}
+
 func (a *ArticleGormDao) Sync(ctx context.Context, art Article) (int64, error) {
 	var id = art.Id
 	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -79,6 +354,7 @@ func (a *ArticleGormDao) Sync(ctx context.Context, art Article) (int64, error) {
 			err error
 		)
 		if id > 0 {
+			// UpdateById 会排除回收站里面的文章，这样也不会把它同步到线上库
 			err = dao.UpdateById(ctx, art)
 		} else {
 			id, err = dao.Insert(ctx, art)
@@ -89,6 +365,8 @@ func (a *ArticleGormDao) Sync(ctx context.Context, art Article) (int64, error) {
 		}
 		art.Id = id
 		pubArt := PublishedArticle(art)
+		// 线上库的版本号和制作库保持一致
+		pubArt.Version = art.Version + 1
 		now := time.Now().UnixMilli()
 		pubArt.Ctime = now
 		pubArt.Utime = now
@@ -98,12 +376,24 @@ func (a *ArticleGormDao) Sync(ctx context.Context, art Article) (int64, error) {
 			// 其它方言：sqlite INSERT xxx ON CONFLICT DO UPDATES WHERE
 			Columns: []clause.Column{{Name: "id"}},
 			DoUpdates: clause.Assignments(map[string]interface{}{
-				"title":   pubArt.Title,
-				"content": pubArt.Content,
-				"utime":   now,
+				"title":          pubArt.Title,
+				"content":        pubArt.Content,
+				"format":         pubArt.Format,
+				"html":           pubArt.Html,
+				"summary":        pubArt.Summary,
+				"word_cnt":       pubArt.WordCnt,
+				"read_minutes":   pubArt.ReadMinutes,
+				"image_cnt":      pubArt.ImageCnt,
+				"code_block_cnt": pubArt.CodeBlockCnt,
+				"status":         pubArt.Status,
+				"version":        pubArt.Version,
+				"utime":          now,
 			}),
 		}).Create(&pubArt).Error
-		return err
+		if err != nil {
+			return err
+		}
+		return syncPublishedTags(tx, id)
 	})
 	return id, err
 }
@@ -164,37 +454,85 @@ func (a *ArticleGormDao) Insert(ctx context.Context, art Article) (int64, error)
 	now := time.Now().UnixMilli()
 	art.Ctime = now
 	art.Utime = now
-	err := a.db.WithContext(ctx).Create(&art).Error
+	art.Version = 1
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&art).Error
+		if err != nil || len(art.Tags) == 0 {
+			return err
+		}
+		return replaceArticleTags(tx, &ArticleTag{}, art.Id, art.Tags)
+	})
 	return art.Id, err
 }
 
 func (a *ArticleGormDao) UpdateById(ctx context.Context, art Article) error {
 	now := time.Now().UnixMilli()
-	res := a.db.WithContext(ctx).Model(&Article{}).
-		Where("id=? AND author_id=?", art.Id, art.AuthorId).
-		Updates(map[string]any{
-			"title":   art.Title,
-			"content": art.Content,
-			"status":  art.Status,
-			"utime":   now,
-		})
-	if res.Error != nil {
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
-		return errors.New("更新失败，ID不对或作者不对")
-	}
-	return nil
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 回收站里面的文章不允许修改，只能先 Restore
+		res := tx.Model(&Article{}).
+			Where("id=? AND version=? AND status<>?", art.Id, art.Version, statusDeleted).
+			Updates(map[string]any{
+				"title":          art.Title,
+				"content":        art.Content,
+				"format":         art.Format,
+				"html":           art.Html,
+				"summary":        art.Summary,
+				"word_cnt":       art.WordCnt,
+				"read_minutes":   art.ReadMinutes,
+				"image_cnt":      art.ImageCnt,
+				"code_block_cnt": art.CodeBlockCnt,
+				"status":         art.Status,
+				"version":        gorm.Expr("version + 1"),
+				"utime":          now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// 区分一下是版本不对还是文章不存在
+			var cnt int64
+			err := tx.Model(&Article{}).
+				Where("id=? AND status<>?", art.Id, statusDeleted).
+				Count(&cnt).Error
+			if err != nil {
+				return err
+			}
+			if cnt > 0 {
+				return ErrArticleVersionConflict
+			}
+			return ErrRecordNotFound
+		}
+		// nil 代表不修改标签，空切片代表清空标签
+		if art.Tags == nil {
+			return nil
+		}
+		return replaceArticleTags(tx, &ArticleTag{}, art.Id, art.Tags)
+	})
 }
 
 type Article struct {
 	Id       int64  `gorm:"primaryKey, autoIncrement" bson:"id,omitempty"`
 	Title    string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
 	Content  string `gorm:"type=BLOB" bson:"content,omitempty"`
-	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8  `bson:"status,omitempty"`
+	AuthorId int64  `gorm:"index;index:,composite:author_utime,priority:1" bson:"author_id,omitempty"`
+	Status   uint8  `gorm:"index:,composite:status_dtime,priority:1" bson:"status,omitempty"`
 	Ctime    int64  `bson:"ctime,omitempty"`
-	Utime    int64  `bson:"utime,omitempty"`
+	Utime    int64  `gorm:"index:,composite:author_utime,priority:2" bson:"utime,omitempty"`
+	// Tags 在关系型数据库里面是单独的表
+	Tags []string `gorm:"-" bson:"tags,omitempty"`
+	// Format 内容的格式，Html 和 Summary 是根据格式渲染出来的
+	Format  uint8  `bson:"format,omitempty"`
+	Html    string `bson:"html,omitempty"`
+	Summary string `gorm:"type:varchar(512)" bson:"summary,omitempty"`
+	// Version 乐观锁，每次修改加一
+	Version int64 `gorm:"not null;default:1" bson:"version,omitempty"`
+	// Dtime 移到回收站的时间，彻底删除的任务按照它扫描
+	Dtime int64 `gorm:"index:,composite:status_dtime,priority:2" bson:"dtime,omitempty"`
+	// 长度统计和 Html 一起渲染出来的
+	WordCnt      int `bson:"word_cnt,omitempty"`
+	ReadMinutes  int `bson:"read_minutes,omitempty"`
+	ImageCnt     int `bson:"image_cnt,omitempty"`
+	CodeBlockCnt int `bson:"code_block_cnt,omitempty"`
 }
 
 // PublishedArticle 衍生类型
@@ -0,0 +1,87 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+type ArticleAssetDao interface {
+	// Insert 相同的 key 已经存在的时候返回已有的记录
+	Insert(ctx context.Context, asset ArticleAsset) (ArticleAsset, error)
+	GetByKey(ctx context.Context, key string) (ArticleAsset, error)
+	// ListBefore 按照 id 升序返回 ctime 之前上传的资源，用来做垃圾回收
+	ListBefore(ctx context.Context, ctime int64, startId int64, limit int) ([]ArticleAsset, error)
+	// IsReferenced 文章的任何一个历史版本里面出现了 needle 就认为还在使用
+	IsReferenced(ctx context.Context, aid int64, needle string) (bool, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type GormArticleAssetDao struct {
+	db *gorm.DB
+}
+
+func NewGormArticleAssetDao(db *gorm.DB) ArticleAssetDao {
+	return &GormArticleAssetDao{
+		db: db,
+	}
+}
+
+func (dao *GormArticleAssetDao) Insert(ctx context.Context, asset ArticleAsset) (ArticleAsset, error) {
+	asset.Ctime = time.Now().UnixMilli()
+	err := dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoNothing: true,
+	}).Create(&asset).Error
+	if err != nil {
+		return ArticleAsset{}, err
+	}
+	// 冲突的时候拿不到 id，重新查一次
+	return dao.GetByKey(ctx, asset.Key)
+}
+
+func (dao *GormArticleAssetDao) GetByKey(ctx context.Context, key string) (ArticleAsset, error) {
+	var res ArticleAsset
+	err := dao.db.WithContext(ctx).Where("`key` = ?", key).First(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleAssetDao) ListBefore(ctx context.Context, ctime int64, startId int64, limit int) ([]ArticleAsset, error) {
+	var res []ArticleAsset
+	err := dao.db.WithContext(ctx).
+		Where("ctime < ? AND id > ?", ctime, startId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleAssetDao) IsReferenced(ctx context.Context, aid int64, needle string) (bool, error) {
+	var cnt int64
+	// 每次保存都会生成历史版本，所以草稿和线上的内容都在历史版本里面
+	err := dao.db.WithContext(ctx).Model(&ArticleRevision{}).
+		Where("article_id = ? AND content LIKE ? ESCAPE '!'", aid, "%"+escapeLike(needle)+"%").
+		Limit(1).
+		Count(&cnt).Error
+	return cnt > 0, err
+}
+
+func (dao *GormArticleAssetDao) Delete(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Where("id = ?", id).Delete(&ArticleAsset{}).Error
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
+type ArticleAsset struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	ArticleId   int64  `gorm:"index"`
+	AuthorId    int64  `gorm:"index"`
+	Key         string `gorm:"type:varchar(256);uniqueIndex"`
+	ContentType string `gorm:"type:varchar(128)"`
+	Size        int64
+	Ctime       int64 `gorm:"index"`
+}
@@ -0,0 +1,52 @@
+package dao
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGormArticleAssetDao(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webook.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&ArticleAsset{}, &ArticleRevision{}))
+	dao := NewGormArticleAssetDao(db)
+	revDao := NewGormArticleRevisionDao(db)
+	ctx := context.Background()
+
+	a, err := dao.Insert(ctx, ArticleAsset{ArticleId: 11, AuthorId: 123, Key: "11/a.png", ContentType: "image/png", Size: 10})
+	require.NoError(t, err)
+	// 重复上传拿到的是同一条记录
+	again, err := dao.Insert(ctx, ArticleAsset{ArticleId: 11, AuthorId: 123, Key: "11/a.png", ContentType: "image/png", Size: 10})
+	require.NoError(t, err)
+	assert.Equal(t, a.Id, again.Id)
+	b, err := dao.Insert(ctx, ArticleAsset{ArticleId: 11, AuthorId: 123, Key: "11/b.png", ContentType: "image/png", Size: 10})
+	require.NoError(t, err)
+
+	_, err = revDao.Insert(ctx, ArticleRevision{ArticleId: 11, Content: "![图](/articles/assets/11/a.png)"})
+	require.NoError(t, err)
+	ok, err := dao.IsReferenced(ctx, 11, a.Key)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = dao.IsReferenced(ctx, 11, b.Key)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	// 别的文章引用了不算
+	ok, err = dao.IsReferenced(ctx, 12, a.Key)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assets, err := dao.ListBefore(ctx, time.Now().Add(time.Second).UnixMilli(), a.Id, 10)
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	assert.Equal(t, b.Id, assets[0].Id)
+
+	require.NoError(t, dao.Delete(ctx, b.Id))
+	_, err = dao.GetByKey(ctx, b.Key)
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+}
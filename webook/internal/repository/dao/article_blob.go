@@ -0,0 +1,385 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/pkg/blobx"
+	"github.com/ecodeclub/ekit/slice"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"time"
+)
+
+// ArticleBlobDao 制作库和线上库的元数据在数据库里面，线上库的内容和渲染好的 HTML 放在对象存储上
+type ArticleBlobDao struct {
+	ArticleGormDao
+	store blobx.BlobStore
+}
+
+func NewArticleBlobDao(db *gorm.DB, store blobx.BlobStore) *ArticleBlobDao {
+	return &ArticleBlobDao{
+		ArticleGormDao: ArticleGormDao{
+			db: db,
+		},
+		store: store,
+	}
+}
+
+func (a *ArticleBlobDao) Sync(ctx context.Context, art Article) (int64, error) {
+	var id = art.Id
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		dao := NewGormDBArticleDao(tx)
+		var (
+			err error
+		)
+		if id > 0 {
+			err = dao.UpdateById(ctx, art)
+		} else {
+			id, err = dao.Insert(ctx, art)
+		}
+
+		if err != nil {
+			return err
+		}
+		art.Id = id
+		pubArt := PublishedArticleV2{
+			Id:       art.Id,
+			Title:    art.Title,
+			AuthorId: art.AuthorId,
+			Utime:    art.Utime,
+			Ctime:    art.Ctime,
+			Status:   art.Status,
+			Format:   art.Format,
+			Summary:  art.Summary,
+			Version:  art.Version + 1,
+
+			WordCnt:      art.WordCnt,
+			ReadMinutes:  art.ReadMinutes,
+			ImageCnt:     art.ImageCnt,
+			CodeBlockCnt: art.CodeBlockCnt,
+		}
+		now := time.Now().UnixMilli()
+		pubArt.Ctime = now
+		pubArt.Utime = now
+		err = tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"title":          pubArt.Title,
+				"format":         pubArt.Format,
+				"summary":        pubArt.Summary,
+				"word_cnt":       pubArt.WordCnt,
+				"read_minutes":   pubArt.ReadMinutes,
+				"image_cnt":      pubArt.ImageCnt,
+				"code_block_cnt": pubArt.CodeBlockCnt,
+				"version":        pubArt.Version,
+				"utime":          now,
+				"status":         pubArt.Status,
+			}),
+		}).Create(&pubArt).Error
+		if err != nil {
+			return err
+		}
+		err = syncPublishedTags(tx, id)
+		if err != nil {
+			return err
+		}
+		// 内容在事务提交之前写，写失败的时候元数据一起回滚，不会出现有元数据没有内容的文章。
+		// 反过来提交失败的时候对象存储里面多了一份内容，下一次发表会覆盖掉
+		err = a.store.Put(ctx, a.contentKey(id), []byte(art.Content), "text/plain;charset=utf-8")
+		if err != nil {
+			return err
+		}
+		// HTML 也要覆盖，不然修改之后读到的还是上一次渲染的结果
+		return a.store.Put(ctx, a.htmlKey(id), []byte(art.Html), "text/html;charset=utf-8")
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (a *ArticleBlobDao) SyncStatus(ctx context.Context, id int64, status domain.ArticleStatus) error {
+	now := time.Now().UnixMilli()
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id=?", id).
+			Updates(map[string]any{
+				"utime":  now,
+				"status": status,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+
+		return tx.Model(&PublishedArticleV2{}).
+			Where("id=?", id).
+			Updates(map[string]any{
+				"utime":  now,
+				"status": status,
+			}).Error
+	})
+	if err != nil {
+		return err
+	}
+	if status != domain.ArticleStatusPublished {
+		// 撤回的文章不再保留线上的内容，删除失败的时候重试撤回就可以了
+		err = a.deleteContent(ctx, id)
+	}
+	return err
+}
+
+// Trash 和撤回一样，删掉线上的内容
+func (a *ArticleBlobDao) Trash(ctx context.Context, id int64) error {
+	err := a.trash(ctx, &PublishedArticleV2{}, id)
+	if err != nil {
+		return err
+	}
+	return a.deleteContent(ctx, id)
+}
+
+func (a *ArticleBlobDao) Restore(ctx context.Context, id int64) error {
+	return a.restore(ctx, &PublishedArticleV2{}, id)
+}
+
+func (a *ArticleBlobDao) Purge(ctx context.Context, id int64) error {
+	err := a.purge(ctx, &PublishedArticleV2{}, id)
+	if err != nil {
+		return err
+	}
+	// 移到回收站的时候应该已经删掉了，这里兜底
+	return a.deleteContent(ctx, id)
+}
+
+// GetPubById 元数据在数据库里面，内容在对象存储上
+func (a *ArticleBlobDao) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
+	var pubArt PublishedArticleV2
+	err := a.db.WithContext(ctx).Where("id=?", id).First(&pubArt).Error
+	if err != nil {
+		return PublishedArticle{}, err
+	}
+	res := pubArt.toPublished()
+	tags, err := findTagNames(a.db.WithContext(ctx), "published_article_tags", []int64{id})
+	if err != nil {
+		return res, err
+	}
+	res.Tags = tags[id]
+	if domain.ArticleStatus(res.Status) != domain.ArticleStatusPublished {
+		// 撤回的时候内容已经删掉了
+		return res, nil
+	}
+	content, err := a.store.Get(ctx, a.contentKey(id))
+	if err != nil {
+		return res, err
+	}
+	res.Content = string(content)
+	res.Html, err = a.getHtml(ctx, id)
+	return res, err
+}
+
+// ListPubByTag 列表只需要摘要，不读对象存储
+func (a *ArticleBlobDao) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]PublishedArticle, error) {
+	var pubArts []PublishedArticleV2
+	err := a.db.WithContext(ctx).
+		Joins("JOIN published_article_tags ON published_article_tags.article_id = published_article_v2.id").
+		Joins("JOIN tags ON tags.id = published_article_tags.tag_id").
+		Where("tags.name = ? AND published_article_v2.status = ?", tag, domain.ArticleStatusPublished).
+		Offset(offset).
+		Limit(limit).
+		Order("published_article_v2.utime DESC, published_article_v2.id DESC").
+		Find(&pubArts).Error
+	if err != nil || len(pubArts) == 0 {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(pubArts))
+	for _, pubArt := range pubArts {
+		ids = append(ids, pubArt.Id)
+	}
+	tags, err := findTagNames(a.db.WithContext(ctx), "published_article_tags", ids)
+	arts := make([]PublishedArticle, 0, len(pubArts))
+	for _, pubArt := range pubArts {
+		art := pubArt.toPublished()
+		art.Tags = tags[art.Id]
+		arts = append(arts, art)
+	}
+	return arts, err
+}
+
+// contentKey 对象存储里面还有别的业务的数据，文章内容放在 articles/ 下面
+func (a *ArticleBlobDao) contentKey(id int64) string {
+	return "articles/" + strconv.FormatInt(id, 10)
+}
+
+// htmlKey 渲染好的 HTML 和内容放在一起
+func (a *ArticleBlobDao) htmlKey(id int64) string {
+	return a.contentKey(id) + ".html"
+}
+
+// getHtml 渲染功能上线之前发表的文章没有 HTML，返回空的，由上层在读的时候渲染
+func (a *ArticleBlobDao) getHtml(ctx context.Context, id int64) (string, error) {
+	html, err := a.store.Get(ctx, a.htmlKey(id))
+	if errors.Is(err, blobx.ErrBlobNotFound) {
+		return "", nil
+	}
+	return string(html), err
+}
+
+func (a *ArticleBlobDao) deleteContent(ctx context.Context, id int64) error {
+	err := a.store.Delete(ctx, a.contentKey(id))
+	if err != nil {
+		return err
+	}
+	return a.store.Delete(ctx, a.htmlKey(id))
+}
+
+type PublishedArticleV2 struct {
+	Id       int64  `gorm:"primaryKey, autoIncrement" bson:"id,omitempty"`
+	Title    string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
+	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
+	Status   uint8  `bson:"status,omitempty"`
+	Ctime    int64  `bson:"ctime,omitempty"`
+	Utime    int64  `bson:"utime,omitempty"`
+	Format   uint8  `bson:"format,omitempty"`
+	Summary  string `gorm:"type:varchar(512)" bson:"summary,omitempty"`
+	Version  int64  `bson:"version,omitempty"`
+
+	WordCnt      int `bson:"word_cnt,omitempty"`
+	ReadMinutes  int `bson:"read_minutes,omitempty"`
+	ImageCnt     int `bson:"image_cnt,omitempty"`
+	CodeBlockCnt int `bson:"code_block_cnt,omitempty"`
+}
+
+// ListPubByAuthor 和 ListPubByTag 一样，不读对象存储
+func (a *ArticleBlobDao) ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]PublishedArticle, error) {
+	var pubArts []PublishedArticleV2
+	err := a.db.WithContext(ctx).
+		Where("author_id = ? AND status = ?", uid, domain.ArticleStatusPublished).
+		Offset(offset).
+		Limit(limit).
+		Order("utime DESC, id DESC").
+		Find(&pubArts).Error
+	if err != nil || len(pubArts) == 0 {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(pubArts))
+	for _, pubArt := range pubArts {
+		ids = append(ids, pubArt.Id)
+	}
+	tags, err := findTagNames(a.db.WithContext(ctx), "published_article_tags", ids)
+	arts := make([]PublishedArticle, 0, len(pubArts))
+	for _, pubArt := range pubArts {
+		art := pubArt.toPublished()
+		art.Tags = tags[art.Id]
+		arts = append(arts, art)
+	}
+	return arts, err
+}
+
+// ListPubSince 线上库的表不一样，不读对象存储
+func (a *ArticleBlobDao) ListPubSince(ctx context.Context, since int64, id int64, limit int) ([]PublishedArticle, error) {
+	var pubArts []PublishedArticleV2
+	err := a.db.WithContext(ctx).
+		Where("id > ? AND status = ? AND ctime >= ?", id, domain.ArticleStatusPublished, since).
+		Limit(limit).
+		Order("id ASC").
+		Find(&pubArts).Error
+	return slice.Map[PublishedArticleV2, PublishedArticle](pubArts, func(idx int, src PublishedArticleV2) PublishedArticle {
+		return src.toPublished()
+	}), err
+}
+
+// ScanPub 只有已发表的文章在对象存储里面有内容和 HTML
+func (a *ArticleBlobDao) ScanPub(ctx context.Context, id int64, limit int) ([]PublishedArticle, error) {
+	var pubArts []PublishedArticleV2
+	err := a.db.WithContext(ctx).
+		Where("id > ?", id).
+		Order("id ASC").
+		Limit(limit).
+		Find(&pubArts).Error
+	if err != nil {
+		return nil, err
+	}
+	arts := make([]PublishedArticle, 0, len(pubArts))
+	for _, pubArt := range pubArts {
+		art := pubArt.toPublished()
+		if domain.ArticleStatus(art.Status) == domain.ArticleStatusPublished {
+			content, err := a.store.Get(ctx, a.contentKey(art.Id))
+			if err != nil {
+				return nil, err
+			}
+			art.Content = string(content)
+			art.Html, err = a.getHtml(ctx, art.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
+		arts = append(arts, art)
+	}
+	return arts, nil
+}
+
+func (a *ArticleBlobDao) UpdatePubStats(ctx context.Context, art PublishedArticle) error {
+	return a.db.WithContext(ctx).Model(&PublishedArticleV2{}).
+		Where("id = ?", art.Id).
+		Updates(map[string]any{
+			"word_cnt":       art.WordCnt,
+			"read_minutes":   art.ReadMinutes,
+			"image_cnt":      art.ImageCnt,
+			"code_block_cnt": art.CodeBlockCnt,
+		}).Error
+}
+
+// ListPubLatest 订阅源要展示全文，每篇文章都要从对象存储读 HTML，FeedSize 很小，开销可以接受
+func (a *ArticleBlobDao) ListPubLatest(ctx context.Context, limit int) ([]PublishedArticle, error) {
+	var pubArts []PublishedArticleV2
+	err := a.db.WithContext(ctx).
+		Where("status = ?", domain.ArticleStatusPublished).
+		Limit(limit).
+		Order("utime DESC, id DESC").
+		Find(&pubArts).Error
+	if err != nil || len(pubArts) == 0 {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(pubArts))
+	for _, pubArt := range pubArts {
+		ids = append(ids, pubArt.Id)
+	}
+	tags, err := findTagNames(a.db.WithContext(ctx), "published_article_tags", ids)
+	if err != nil {
+		return nil, err
+	}
+	arts := make([]PublishedArticle, 0, len(pubArts))
+	for _, pubArt := range pubArts {
+		art := pubArt.toPublished()
+		art.Tags = tags[art.Id]
+		art.Html, err = a.getHtml(ctx, art.Id)
+		if err != nil {
+			return nil, err
+		}
+		arts = append(arts, art)
+	}
+	return arts, nil
+}
+
+func (p PublishedArticleV2) toPublished() PublishedArticle {
+	return PublishedArticle{
+		Id:       p.Id,
+		Title:    p.Title,
+		AuthorId: p.AuthorId,
+		Status:   p.Status,
+		Ctime:    p.Ctime,
+		Utime:    p.Utime,
+		Format:   p.Format,
+		Summary:  p.Summary,
+		Version:  p.Version,
+
+		WordCnt:      p.WordCnt,
+		ReadMinutes:  p.ReadMinutes,
+		ImageCnt:     p.ImageCnt,
+		CodeBlockCnt: p.CodeBlockCnt,
+	}
+}
@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type ArticleCollaboratorDao interface {
+	// Upsert 已经是协作者的时候修改角色
+	Upsert(ctx context.Context, c ArticleCollaborator) error
+	// Delete 不是协作者返回 ErrRecordNotFound
+	Delete(ctx context.Context, aid int64, uid int64) error
+	Get(ctx context.Context, aid int64, uid int64) (ArticleCollaborator, error)
+	// ListByArticle 按照加入的先后顺序
+	ListByArticle(ctx context.Context, aid int64) ([]ArticleCollaborator, error)
+	// DeleteByArticle 文章被彻底删除的时候，协作者也一起删掉
+	DeleteByArticle(ctx context.Context, aid int64) error
+}
+
+type GormArticleCollaboratorDao struct {
+	db *gorm.DB
+}
+
+func NewGormArticleCollaboratorDao(db *gorm.DB) ArticleCollaboratorDao {
+	return &GormArticleCollaboratorDao{
+		db: db,
+	}
+}
+
+func (dao *GormArticleCollaboratorDao) Upsert(ctx context.Context, c ArticleCollaborator) error {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "article_id"}, {Name: "uid"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"role":  c.Role,
+			"utime": now,
+		}),
+	}).Create(&c).Error
+}
+
+func (dao *GormArticleCollaboratorDao) Delete(ctx context.Context, aid int64, uid int64) error {
+	res := dao.db.WithContext(ctx).
+		Where("article_id=? AND uid=?", aid, uid).
+		Delete(&ArticleCollaborator{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (dao *GormArticleCollaboratorDao) Get(ctx context.Context, aid int64, uid int64) (ArticleCollaborator, error) {
+	var res ArticleCollaborator
+	err := dao.db.WithContext(ctx).
+		Where("article_id=? AND uid=?", aid, uid).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleCollaboratorDao) ListByArticle(ctx context.Context, aid int64) ([]ArticleCollaborator, error) {
+	var res []ArticleCollaborator
+	err := dao.db.WithContext(ctx).
+		Where("article_id=?", aid).
+		Order("id ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleCollaboratorDao) DeleteByArticle(ctx context.Context, aid int64) error {
+	return dao.db.WithContext(ctx).Where("article_id=?", aid).Delete(&ArticleCollaborator{}).Error
+}
+
+// ArticleCollaborator 文章的作者本人不在这张表里面，作者就是 owner
+type ArticleCollaborator struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:aid_uid"`
+	// 查我参与的文章
+	Uid   int64 `gorm:"uniqueIndex:aid_uid;index"`
+	Role  uint8
+	Ctime int64
+	Utime int64
+}
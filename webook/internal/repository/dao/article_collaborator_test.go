@@ -0,0 +1,48 @@
+package dao
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+func TestGormArticleCollaboratorDao(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webook.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&ArticleCollaborator{}))
+	dao := NewGormArticleCollaboratorDao(db)
+	ctx := context.Background()
+
+	require.NoError(t, dao.Upsert(ctx, ArticleCollaborator{ArticleId: 11, Uid: 456, Role: 1}))
+	require.NoError(t, dao.Upsert(ctx, ArticleCollaborator{ArticleId: 11, Uid: 789, Role: 2}))
+	require.NoError(t, dao.Upsert(ctx, ArticleCollaborator{ArticleId: 12, Uid: 456, Role: 2}))
+	// 重复邀请修改角色
+	require.NoError(t, dao.Upsert(ctx, ArticleCollaborator{ArticleId: 11, Uid: 456, Role: 2}))
+
+	c, err := dao.Get(ctx, 11, 456)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(2), c.Role)
+	_, err = dao.Get(ctx, 11, 123)
+	assert.Equal(t, ErrRecordNotFound, err)
+
+	cs, err := dao.ListByArticle(ctx, 11)
+	require.NoError(t, err)
+	require.Len(t, cs, 2)
+	assert.Equal(t, int64(456), cs[0].Uid)
+	assert.Equal(t, int64(789), cs[1].Uid)
+
+	require.NoError(t, dao.Delete(ctx, 11, 789))
+	assert.Equal(t, ErrRecordNotFound, dao.Delete(ctx, 11, 789))
+
+	require.NoError(t, dao.DeleteByArticle(ctx, 11))
+	cs, err = dao.ListByArticle(ctx, 11)
+	require.NoError(t, err)
+	assert.Empty(t, cs)
+	// 别的文章不受影响
+	_, err = dao.Get(ctx, 12, 456)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,148 @@
+package dao
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+var (
+	exportStatusPending = domain.ArticleExportStatusPending.ToUint8()
+	exportStatusRunning = domain.ArticleExportStatusRunning.ToUint8()
+	exportStatusDone    = domain.ArticleExportStatusDone.ToUint8()
+	exportStatusFailed  = domain.ArticleExportStatusFailed.ToUint8()
+)
+
+type ArticleExportDao interface {
+	Insert(ctx context.Context, e ArticleExport) (int64, error)
+	// FindActive 用户等待执行或者正在执行的导出，没有返回 ErrRecordNotFound
+	FindActive(ctx context.Context, uid int64) (ArticleExport, error)
+	GetById(ctx context.Context, id int64) (ArticleExport, error)
+	// ListByUser 最近的导出在前面
+	ListByUser(ctx context.Context, uid int64, limit int) ([]ArticleExport, error)
+	// FindRunnable 等待执行的导出，包括被抢占了但是超过 timeout 没有更新进度的导出
+	FindRunnable(ctx context.Context, now int64, timeout time.Duration, limit int) ([]ArticleExport, error)
+	// Claim 抢占导出，返回 false 说明被别的实例抢走了
+	Claim(ctx context.Context, id int64, now int64, timeout time.Duration) (bool, error)
+	// UpdateProgress 顺便更新 utime，相当于心跳，避免被别的实例当成超时抢走
+	UpdateProgress(ctx context.Context, id int64, total int, done int) error
+	Complete(ctx context.Context, id int64, key string) error
+	Fail(ctx context.Context, id int64, reason string) error
+}
+
+type GormArticleExportDao struct {
+	db *gorm.DB
+}
+
+func NewGormArticleExportDao(db *gorm.DB) ArticleExportDao {
+	return &GormArticleExportDao{
+		db: db,
+	}
+}
+
+func (dao *GormArticleExportDao) Insert(ctx context.Context, e ArticleExport) (int64, error) {
+	now := time.Now().UnixMilli()
+	e.Status = exportStatusPending
+	e.Ctime = now
+	e.Utime = now
+	err := dao.db.WithContext(ctx).Create(&e).Error
+	return e.Id, err
+}
+
+func (dao *GormArticleExportDao) FindActive(ctx context.Context, uid int64) (ArticleExport, error) {
+	var res ArticleExport
+	err := dao.db.WithContext(ctx).
+		Where("uid=? AND (status=? OR status=?)", uid, exportStatusPending, exportStatusRunning).
+		Order("id DESC").
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleExportDao) GetById(ctx context.Context, id int64) (ArticleExport, error) {
+	var res ArticleExport
+	err := dao.db.WithContext(ctx).Where("id=?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleExportDao) ListByUser(ctx context.Context, uid int64, limit int) ([]ArticleExport, error) {
+	var res []ArticleExport
+	err := dao.db.WithContext(ctx).
+		Where("uid=?", uid).
+		Order("id DESC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleExportDao) FindRunnable(ctx context.Context, now int64, timeout time.Duration, limit int) ([]ArticleExport, error) {
+	var res []ArticleExport
+	err := dao.db.WithContext(ctx).
+		Where("status = ? OR (status = ? AND utime < ?)",
+			exportStatusPending,
+			exportStatusRunning, now-timeout.Milliseconds()).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleExportDao) Claim(ctx context.Context, id int64, now int64, timeout time.Duration) (bool, error) {
+	// 和定时发表一样用乐观锁抢占
+	res := dao.db.WithContext(ctx).Model(&ArticleExport{}).
+		Where("id = ? AND (status = ? OR (status = ? AND utime < ?))",
+			id, exportStatusPending,
+			exportStatusRunning, now-timeout.Milliseconds()).
+		Updates(map[string]any{
+			"status": exportStatusRunning,
+			"total":  0,
+			"done":   0,
+			"utime":  now,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (dao *GormArticleExportDao) UpdateProgress(ctx context.Context, id int64, total int, done int) error {
+	return dao.update(ctx, id, map[string]any{
+		"total": total,
+		"done":  done,
+	})
+}
+
+func (dao *GormArticleExportDao) Complete(ctx context.Context, id int64, key string) error {
+	return dao.update(ctx, id, map[string]any{
+		"status": exportStatusDone,
+		"key":    key,
+	})
+}
+
+func (dao *GormArticleExportDao) Fail(ctx context.Context, id int64, reason string) error {
+	return dao.update(ctx, id, map[string]any{
+		"status": exportStatusFailed,
+		"reason": reason,
+	})
+}
+
+// update 只更新正在执行的导出
+func (dao *GormArticleExportDao) update(ctx context.Context, id int64, values map[string]any) error {
+	values["utime"] = time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Model(&ArticleExport{}).
+		Where("id=? AND status=?", id, exportStatusRunning).
+		Updates(values).Error
+}
+
+type ArticleExport struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"index"`
+	// 扫描等待执行的导出用的是 status
+	Status uint8 `gorm:"index"`
+	Total  int
+	Done   int
+	Key    string `gorm:"type:varchar(256)"`
+	Reason string `gorm:"type:varchar(256)"`
+	Ctime  int64
+	Utime  int64
+}
@@ -0,0 +1,93 @@
+package dao
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGormArticleExportDao(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webook.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&ArticleExport{}))
+	dao := NewGormArticleExportDao(db)
+	ctx := context.Background()
+
+	_, err = dao.FindActive(ctx, 123)
+	assert.Equal(t, ErrRecordNotFound, err)
+	id, err := dao.Insert(ctx, ArticleExport{Uid: 123})
+	require.NoError(t, err)
+	e, err := dao.FindActive(ctx, 123)
+	require.NoError(t, err)
+	assert.Equal(t, id, e.Id)
+	assert.Equal(t, domain.ArticleExportStatusPending.ToUint8(), e.Status)
+
+	now := time.Now().UnixMilli()
+	es, err := dao.FindRunnable(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, es, 1)
+	ok, err := dao.Claim(ctx, id, now, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	// 被抢占了，还没有超时
+	ok, err = dao.Claim(ctx, id, now, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	es, err = dao.FindRunnable(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, es)
+
+	require.NoError(t, dao.UpdateProgress(ctx, id, 10, 3))
+	e, err = dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleExportStatusRunning.ToUint8(), e.Status)
+	assert.Equal(t, 10, e.Total)
+	assert.Equal(t, 3, e.Done)
+
+	// 执行导出的实例挂了，超时之后别的实例可以重新抢占，进度从头开始
+	later := e.Utime + time.Minute.Milliseconds() + 1
+	es, err = dao.FindRunnable(ctx, later, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, es, 1)
+	ok, err = dao.Claim(ctx, id, later, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	e, err = dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, 0, e.Done)
+
+	require.NoError(t, dao.Complete(ctx, id, "exports/123/1.zip"))
+	e, err = dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleExportStatusDone.ToUint8(), e.Status)
+	assert.Equal(t, "exports/123/1.zip", e.Key)
+	_, err = dao.FindActive(ctx, 123)
+	assert.Equal(t, ErrRecordNotFound, err)
+	// 已经完成的导出不会再被改掉
+	require.NoError(t, dao.Fail(ctx, id, "超时"))
+	e, err = dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleExportStatusDone.ToUint8(), e.Status)
+
+	id2, err := dao.Insert(ctx, ArticleExport{Uid: 123})
+	require.NoError(t, err)
+	ok, err = dao.Claim(ctx, id2, time.Now().UnixMilli(), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.NoError(t, dao.Fail(ctx, id2, "对象存储不可用"))
+	_, err = dao.Insert(ctx, ArticleExport{Uid: 456})
+	require.NoError(t, err)
+
+	es, err = dao.ListByUser(ctx, 123, 10)
+	require.NoError(t, err)
+	require.Len(t, es, 2)
+	assert.Equal(t, id2, es[0].Id)
+	assert.Equal(t, domain.ArticleExportStatusFailed.ToUint8(), es[0].Status)
+	assert.Equal(t, "对象存储不可用", es[0].Reason)
+}
@@ -0,0 +1,101 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type ArticleReviewDao interface {
+	// Upsert 重新提交审核的时候覆盖上一次的审核结果
+	Upsert(ctx context.Context, r ArticleReview) error
+	Get(ctx context.Context, aid int64) (ArticleReview, error)
+	// ListByStatus 先提交的排在前面
+	ListByStatus(ctx context.Context, status uint8, offset int, limit int) ([]ArticleReview, error)
+	// Review 只有还在 from 状态的审核才会更新，已经被别人审核过了返回 ErrRecordNotFound
+	Review(ctx context.Context, aid int64, from uint8, r ArticleReview) error
+	DeleteByArticle(ctx context.Context, aid int64) error
+}
+
+type GormArticleReviewDao struct {
+	db *gorm.DB
+}
+
+func NewGormArticleReviewDao(db *gorm.DB) ArticleReviewDao {
+	return &GormArticleReviewDao{
+		db: db,
+	}
+}
+
+func (dao *GormArticleReviewDao) Upsert(ctx context.Context, r ArticleReview) error {
+	now := time.Now().UnixMilli()
+	r.Ctime = now
+	r.Utime = now
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "article_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"author_id": r.AuthorId,
+			"title":     r.Title,
+			"status":    r.Status,
+			"hits":      r.Hits,
+			"reason":    r.Reason,
+			"reviewer":  r.Reviewer,
+			"ctime":     now,
+			"utime":     now,
+		}),
+	}).Create(&r).Error
+}
+
+func (dao *GormArticleReviewDao) Get(ctx context.Context, aid int64) (ArticleReview, error) {
+	var res ArticleReview
+	err := dao.db.WithContext(ctx).Where("article_id=?", aid).First(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleReviewDao) ListByStatus(ctx context.Context, status uint8, offset int, limit int) ([]ArticleReview, error) {
+	var res []ArticleReview
+	err := dao.db.WithContext(ctx).
+		Where("status=?", status).
+		Order("utime ASC, id ASC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleReviewDao) Review(ctx context.Context, aid int64, from uint8, r ArticleReview) error {
+	res := dao.db.WithContext(ctx).Model(&ArticleReview{}).
+		Where("article_id=? AND status=?", aid, from).
+		Updates(map[string]any{
+			"status":   r.Status,
+			"reason":   r.Reason,
+			"reviewer": r.Reviewer,
+			"utime":    time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (dao *GormArticleReviewDao) DeleteByArticle(ctx context.Context, aid int64) error {
+	return dao.db.WithContext(ctx).Where("article_id=?", aid).Delete(&ArticleReview{}).Error
+}
+
+type ArticleReview struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex"`
+	AuthorId  int64
+	Title     string `gorm:"type:varchar(4096)"`
+	// Status 审核队列按照 (status, utime) 查询
+	Status uint8 `gorm:"index:status_utime,priority:1"`
+	// Hits 命中的敏感词，JSON 数组
+	Hits     string `gorm:"type:varchar(4096)"`
+	Reason   string `gorm:"type:varchar(1024)"`
+	Reviewer int64
+	Ctime    int64
+	Utime    int64 `gorm:"index:status_utime,priority:2"`
+}
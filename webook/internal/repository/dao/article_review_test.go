@@ -0,0 +1,48 @@
+package dao
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+func TestGormArticleReviewDao(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webook.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&ArticleReview{}))
+	dao := NewGormArticleReviewDao(db)
+	ctx := context.Background()
+	const pending, approved, rejected = 1, 2, 3
+
+	require.NoError(t, dao.Upsert(ctx, ArticleReview{ArticleId: 11, AuthorId: 123, Status: pending, Hits: `["赌博"]`}))
+	require.NoError(t, dao.Upsert(ctx, ArticleReview{ArticleId: 12, AuthorId: 123, Status: pending}))
+
+	rs, err := dao.ListByStatus(ctx, pending, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, rs, 2)
+	assert.Equal(t, int64(11), rs[0].ArticleId)
+
+	// 两个人同时审核，只有一个能成功
+	require.NoError(t, dao.Review(ctx, 11, pending, ArticleReview{Status: rejected, Reason: "广告", Reviewer: 1}))
+	assert.Equal(t, ErrRecordNotFound, dao.Review(ctx, 11, pending, ArticleReview{Status: approved, Reviewer: 2}))
+	r, err := dao.Get(ctx, 11)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(rejected), r.Status)
+	assert.Equal(t, "广告", r.Reason)
+
+	// 重新提交审核覆盖上一次的结果
+	require.NoError(t, dao.Upsert(ctx, ArticleReview{ArticleId: 11, AuthorId: 123, Status: pending}))
+	r, err = dao.Get(ctx, 11)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(pending), r.Status)
+	assert.Equal(t, "", r.Reason)
+	assert.Equal(t, int64(0), r.Reviewer)
+
+	require.NoError(t, dao.DeleteByArticle(ctx, 11))
+	_, err = dao.Get(ctx, 11)
+	assert.Equal(t, ErrRecordNotFound, err)
+}
@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ArticleRevisionDao interface {
+	Insert(ctx context.Context, rev ArticleRevision) (int64, error)
+	GetById(ctx context.Context, id int64) (ArticleRevision, error)
+	ListByArticle(ctx context.Context, aid int64, offset int, limit int) ([]ArticleRevision, error)
+	// DeleteByArticle 文章被彻底删除的时候，历史版本也一起删掉
+	DeleteByArticle(ctx context.Context, aid int64) error
+}
+
+type GormArticleRevisionDao struct {
+	db *gorm.DB
+}
+
+func NewGormArticleRevisionDao(db *gorm.DB) ArticleRevisionDao {
+	return &GormArticleRevisionDao{
+		db: db,
+	}
+}
+
+// Insert 历史版本只插入，不更新
+func (dao *GormArticleRevisionDao) Insert(ctx context.Context, rev ArticleRevision) (int64, error) {
+	rev.Ctime = time.Now().UnixMilli()
+	err := dao.db.WithContext(ctx).Create(&rev).Error
+	return rev.Id, err
+}
+
+func (dao *GormArticleRevisionDao) GetById(ctx context.Context, id int64) (ArticleRevision, error) {
+	var res ArticleRevision
+	err := dao.db.WithContext(ctx).Where("id=?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleRevisionDao) ListByArticle(ctx context.Context, aid int64, offset int, limit int) ([]ArticleRevision, error) {
+	var res []ArticleRevision
+	// 列表不需要返回内容，内容在看diff的时候才查
+	err := dao.db.WithContext(ctx).
+		Select("id", "article_id", "author_id", "title", "format", "status", "ctime").
+		Where("article_id=?", aid).
+		Offset(offset).
+		Limit(limit).
+		Order("id DESC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleRevisionDao) DeleteByArticle(ctx context.Context, aid int64) error {
+	return dao.db.WithContext(ctx).Where("article_id=?", aid).Delete(&ArticleRevision{}).Error
+}
+
+type ArticleRevision struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64  `gorm:"index"`
+	AuthorId  int64  `gorm:"index"`
+	Title     string `gorm:"type=varchar(4096)"`
+	Content   string `gorm:"type=BLOB"`
+	Format    uint8
+	Status    uint8
+	Ctime     int64
+}
@@ -0,0 +1,139 @@
+package dao
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var (
+	scheduleStatusWaiting   = domain.ArticleScheduleStatusWaiting.ToUint8()
+	scheduleStatusRunning   = domain.ArticleScheduleStatusRunning.ToUint8()
+	scheduleStatusDone      = domain.ArticleScheduleStatusDone.ToUint8()
+	scheduleStatusCancelled = domain.ArticleScheduleStatusCancelled.ToUint8()
+)
+
+type ArticleScheduleDao interface {
+	// Upsert 一篇文章只有一个定时任务，重复设置会覆盖发表时间
+	Upsert(ctx context.Context, s ArticleSchedule) error
+	ListByAuthor(ctx context.Context, uid int64, status uint8, offset int, limit int) ([]ArticleSchedule, error)
+	// Cancel 只能取消还在等待的任务
+	Cancel(ctx context.Context, uid int64, aid int64) error
+	// FindDue 找到已经到点的任务，包括被抢占了但是超过 timeout 还没完成的任务
+	FindDue(ctx context.Context, now int64, timeout time.Duration, limit int) ([]ArticleSchedule, error)
+	// Claim 抢占任务，返回 false 说明被别的实例抢走了
+	Claim(ctx context.Context, id int64, now int64, timeout time.Duration) (bool, error)
+	Complete(ctx context.Context, id int64) error
+	// Release 发表失败的时候释放任务，等待下一轮重试
+	Release(ctx context.Context, id int64) error
+}
+
+type GormArticleScheduleDao struct {
+	db *gorm.DB
+}
+
+func NewGormArticleScheduleDao(db *gorm.DB) ArticleScheduleDao {
+	return &GormArticleScheduleDao{
+		db: db,
+	}
+}
+
+func (dao *GormArticleScheduleDao) Upsert(ctx context.Context, s ArticleSchedule) error {
+	now := time.Now().UnixMilli()
+	s.Status = scheduleStatusWaiting
+	s.Ctime = now
+	s.Utime = now
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "article_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"publish_at": s.PublishAt,
+			"status":     scheduleStatusWaiting,
+			"utime":      now,
+		}),
+	}).Create(&s).Error
+}
+
+func (dao *GormArticleScheduleDao) ListByAuthor(ctx context.Context, uid int64, status uint8, offset int, limit int) ([]ArticleSchedule, error) {
+	var res []ArticleSchedule
+	err := dao.db.WithContext(ctx).
+		Where("author_id=? AND status=?", uid, status).
+		Offset(offset).
+		Limit(limit).
+		Order("publish_at ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleScheduleDao) Cancel(ctx context.Context, uid int64, aid int64) error {
+	res := dao.db.WithContext(ctx).Model(&ArticleSchedule{}).
+		Where("article_id=? AND author_id=? AND status=?", aid, uid, scheduleStatusWaiting).
+		Updates(map[string]any{
+			"status": scheduleStatusCancelled,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (dao *GormArticleScheduleDao) FindDue(ctx context.Context, now int64, timeout time.Duration, limit int) ([]ArticleSchedule, error) {
+	var res []ArticleSchedule
+	err := dao.db.WithContext(ctx).
+		Where("publish_at <= ? AND (status = ? OR (status = ? AND utime < ?))",
+			now, scheduleStatusWaiting,
+			scheduleStatusRunning, now-timeout.Milliseconds()).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleScheduleDao) Claim(ctx context.Context, id int64, now int64, timeout time.Duration) (bool, error) {
+	// 乐观锁，同一时刻只有一个实例能把任务从等待状态改成运行中
+	res := dao.db.WithContext(ctx).Model(&ArticleSchedule{}).
+		Where("id = ? AND (status = ? OR (status = ? AND utime < ?))",
+			id, scheduleStatusWaiting,
+			scheduleStatusRunning, now-timeout.Milliseconds()).
+		Updates(map[string]any{
+			"status": scheduleStatusRunning,
+			"utime":  now,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (dao *GormArticleScheduleDao) Complete(ctx context.Context, id int64) error {
+	return dao.updateStatus(ctx, id, scheduleStatusRunning, scheduleStatusDone)
+}
+
+func (dao *GormArticleScheduleDao) Release(ctx context.Context, id int64) error {
+	return dao.updateStatus(ctx, id, scheduleStatusRunning, scheduleStatusWaiting)
+}
+
+func (dao *GormArticleScheduleDao) updateStatus(ctx context.Context, id int64, from uint8, to uint8) error {
+	return dao.db.WithContext(ctx).Model(&ArticleSchedule{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]any{
+			"status": to,
+			"utime":  time.Now().UnixMilli(),
+		}).Error
+}
+
+type ArticleSchedule struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex"`
+	AuthorId  int64 `gorm:"index"`
+	// 扫描到点任务用的是 publish_at 和 status
+	PublishAt int64 `gorm:"index:publish_at_status"`
+	Status    uint8 `gorm:"index:publish_at_status"`
+	Ctime     int64
+	Utime     int64
+}
@@ -0,0 +1,118 @@
+package dao
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/pkg/markdown"
+	"geek-basic-go/webook/pkg/searchx"
+	"sync"
+)
+
+// ArticleSearchDao 已发表文章的全文索引，后面换成 elasticsearch 之类的只需要换掉实现
+type ArticleSearchDao interface {
+	// Upsert 建立或者覆盖文章的索引
+	Upsert(ctx context.Context, art PublishedArticle) error
+	Delete(ctx context.Context, id int64) error
+	// Search 按照相关度从高到低返回文章
+	Search(ctx context.Context, query string, offset int, limit int) ([]PublishedArticle, error)
+}
+
+const (
+	// 标题命中比内容命中更重要
+	searchTitleWeight   = 3
+	searchContentWeight = 1
+	// 搜索结果只展示摘要，内存里面不保存完整的内容
+	searchAbstractLen = 128
+)
+
+// MemoryArticleSearchDao 进程内的索引，重启之后要通过 Load 从数据库重建。
+// 每个实例各有一份索引，Upsert 和 Delete 只会修改当前实例的索引，
+// 别的实例上发表或者撤回的文章要等下一次 Load 才能搜到或者搜不到，所以要定时 Load
+type MemoryArticleSearchDao struct {
+	// mu 保护 idx 和 arts，Load 会整个替换掉它们
+	mu  sync.RWMutex
+	idx *searchx.Index
+	// arts 搜索结果需要的文章信息
+	arts map[int64]PublishedArticle
+}
+
+func NewMemoryArticleSearchDao() *MemoryArticleSearchDao {
+	return &MemoryArticleSearchDao{
+		idx:  searchx.NewIndex(),
+		arts: make(map[int64]PublishedArticle),
+	}
+}
+
+func (m *MemoryArticleSearchDao) Upsert(ctx context.Context, art PublishedArticle) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	upsertSearchIndex(m.idx, m.arts, art)
+	return nil
+}
+
+func upsertSearchIndex(idx *searchx.Index, arts map[int64]PublishedArticle, art PublishedArticle) {
+	text := art.Content
+	if art.Html != "" {
+		// 索引渲染之后的纯文本，不然 markdown 的语法也会被搜到
+		text = markdown.Text(art.Html)
+	}
+	idx.Upsert(art.Id,
+		searchx.Field{Text: art.Title, Weight: searchTitleWeight},
+		searchx.Field{Text: text, Weight: searchContentWeight})
+	content := []rune(art.Content)
+	if len(content) > searchAbstractLen {
+		art.Content = string(content[:searchAbstractLen])
+	}
+	art.Html = ""
+	arts[art.Id] = art
+}
+
+func (m *MemoryArticleSearchDao) Delete(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.idx.Delete(id)
+	delete(m.arts, id)
+	return nil
+}
+
+func (m *MemoryArticleSearchDao) Search(ctx context.Context, query string, offset int, limit int) ([]PublishedArticle, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	hits, _ := m.idx.Search(query, offset, limit)
+	res := make([]PublishedArticle, 0, len(hits))
+	for _, hit := range hits {
+		if art, ok := m.arts[hit.Id]; ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+// Load 通过 ArticleDao 分批扫描线上库，重建整个索引之后再替换掉旧的索引，
+// 这样撤回或者删除的文章也会从索引里面消失。
+// 重建期间当前实例上的 Upsert 和 Delete 会被覆盖掉，下一次 Load 的时候会补上
+func (m *MemoryArticleSearchDao) Load(ctx context.Context, artDao ArticleDao) error {
+	const batchSize = 500
+	idx := searchx.NewIndex()
+	arts := make(map[int64]PublishedArticle)
+	var maxId int64
+	for {
+		batch, err := artDao.ScanPub(ctx, maxId, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range batch {
+			if art.Status == domain.ArticleStatusPublished {
+				upsertSearchIndex(idx, arts, art)
+			}
+		}
+		if len(batch) < batchSize {
+			break
+		}
+		maxId = batch[len(batch)-1].Id
+	}
+	m.mu.Lock()
+	m.idx, m.arts = idx, arts
+	m.mu.Unlock()
+	return nil
+}
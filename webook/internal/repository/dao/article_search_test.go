@@ -0,0 +1,31 @@
+package dao
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMemoryArticleSearchDao_Load(t *testing.T) {
+	db := newSuiteDB(t)
+	require.NoError(t, db.Create([]PublishedArticle{
+		{Id: 1, Title: "Go 并发", Content: "goroutine", Status: domain.ArticleStatusPublished},
+		{Id: 2, Title: "Go 撤回", Content: "goroutine", Status: domain.ArticleStatusPrivate},
+		{Id: 3, Title: "Go 删除", Content: "goroutine", Status: domain.ArticleStatusDeleted},
+	}).Error)
+	searchDao := NewMemoryArticleSearchDao()
+	// 已经撤回的文章还留在旧的索引里面
+	require.NoError(t, searchDao.Upsert(context.Background(), PublishedArticle{Id: 2, Title: "Go 撤回"}))
+
+	err := searchDao.Load(context.Background(), NewGormDBArticleDao(db))
+	require.NoError(t, err)
+	arts, err := searchDao.Search(context.Background(), "go", 0, 10)
+	require.NoError(t, err)
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	assert.Equal(t, []int64{1}, ids)
+}
@@ -0,0 +1,210 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+var (
+	// ErrArticleInSeries 一篇文章只能在一个系列里面
+	ErrArticleInSeries = errors.New("文章已经在系列里面了")
+	// ErrArticleSeriesMismatch 调整顺序的时候给的文章和系列里面的文章对不上
+	ErrArticleSeriesMismatch = errors.New("系列里面的文章对不上")
+)
+
+type ArticleSeriesDao interface {
+	Insert(ctx context.Context, s ArticleSeries) (int64, error)
+	// Update 只能修改自己的系列，不存在或者不是自己的返回 ErrRecordNotFound
+	Update(ctx context.Context, s ArticleSeries) error
+	// Delete 系列里面的文章不会被删除，只是不在系列里面了
+	Delete(ctx context.Context, id int64, uid int64) error
+	GetById(ctx context.Context, id int64) (ArticleSeries, error)
+	// ListByAuthor 按照创建时间倒序
+	ListByAuthor(ctx context.Context, uid int64) ([]ArticleSeries, error)
+	// AddItem 加到系列的最后面，文章已经在系列里面返回 ErrArticleInSeries
+	AddItem(ctx context.Context, sid int64, aid int64) error
+	// RemoveItem 不在这个系列里面返回 ErrRecordNotFound
+	RemoveItem(ctx context.Context, sid int64, aid int64) error
+	// Reorder aids 必须是系列里面所有的文章，否则返回 ErrArticleSeriesMismatch
+	Reorder(ctx context.Context, sid int64, aids []int64) error
+	// ListItems 按照系列里面的顺序
+	ListItems(ctx context.Context, sid int64) ([]ArticleSeriesItem, error)
+	// FindItemByArticle 文章不在任何系列里面返回 ErrRecordNotFound
+	FindItemByArticle(ctx context.Context, aid int64) (ArticleSeriesItem, error)
+	// DeleteItemByArticle 文章被彻底删除的时候，从系列里面移除
+	DeleteItemByArticle(ctx context.Context, aid int64) error
+}
+
+type GormArticleSeriesDao struct {
+	db *gorm.DB
+}
+
+func NewGormArticleSeriesDao(db *gorm.DB) ArticleSeriesDao {
+	return &GormArticleSeriesDao{
+		db: db,
+	}
+}
+
+func (dao *GormArticleSeriesDao) Insert(ctx context.Context, s ArticleSeries) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	err := dao.db.WithContext(ctx).Create(&s).Error
+	return s.Id, err
+}
+
+func (dao *GormArticleSeriesDao) Update(ctx context.Context, s ArticleSeries) error {
+	res := dao.db.WithContext(ctx).Model(&ArticleSeries{}).
+		Where("id=? AND author_id=?", s.Id, s.AuthorId).
+		Updates(map[string]any{
+			"title":       s.Title,
+			"description": s.Description,
+			"utime":       time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (dao *GormArticleSeriesDao) Delete(ctx context.Context, id int64, uid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id=? AND author_id=?", id, uid).Delete(&ArticleSeries{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return tx.Where("series_id=?", id).Delete(&ArticleSeriesItem{}).Error
+	})
+}
+
+func (dao *GormArticleSeriesDao) GetById(ctx context.Context, id int64) (ArticleSeries, error) {
+	var res ArticleSeries
+	err := dao.db.WithContext(ctx).Where("id=?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleSeriesDao) ListByAuthor(ctx context.Context, uid int64) ([]ArticleSeries, error) {
+	var res []ArticleSeries
+	err := dao.db.WithContext(ctx).
+		Where("author_id=?", uid).
+		Order("id DESC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleSeriesDao) AddItem(ctx context.Context, sid int64, aid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		err := tx.Model(&ArticleSeriesItem{}).Where("article_id=?", aid).Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrArticleInSeries
+		}
+		var last ArticleSeriesItem
+		err = tx.Where("series_id=?", sid).Order("position DESC").First(&last).Error
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return err
+		}
+		// 并发加入同一篇文章的时候，还有唯一索引兜底
+		return tx.Create(&ArticleSeriesItem{
+			SeriesId:  sid,
+			ArticleId: aid,
+			Position:  last.Position + 1,
+			Ctime:     time.Now().UnixMilli(),
+		}).Error
+	})
+}
+
+func (dao *GormArticleSeriesDao) RemoveItem(ctx context.Context, sid int64, aid int64) error {
+	res := dao.db.WithContext(ctx).
+		Where("series_id=? AND article_id=?", sid, aid).
+		Delete(&ArticleSeriesItem{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (dao *GormArticleSeriesDao) Reorder(ctx context.Context, sid int64, aids []int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var items []ArticleSeriesItem
+		err := tx.Where("series_id=?", sid).Find(&items).Error
+		if err != nil {
+			return err
+		}
+		if len(items) != len(aids) {
+			return ErrArticleSeriesMismatch
+		}
+		positions := make(map[int64]int, len(aids))
+		for i, aid := range aids {
+			positions[aid] = i + 1
+		}
+		for _, item := range items {
+			pos, ok := positions[item.ArticleId]
+			if !ok {
+				return ErrArticleSeriesMismatch
+			}
+			if pos == item.Position {
+				continue
+			}
+			err = tx.Model(&ArticleSeriesItem{}).
+				Where("id=?", item.Id).
+				Update("position", pos).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (dao *GormArticleSeriesDao) ListItems(ctx context.Context, sid int64) ([]ArticleSeriesItem, error) {
+	var res []ArticleSeriesItem
+	err := dao.db.WithContext(ctx).
+		Where("series_id=?", sid).
+		Order("position ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleSeriesDao) FindItemByArticle(ctx context.Context, aid int64) (ArticleSeriesItem, error) {
+	var res ArticleSeriesItem
+	err := dao.db.WithContext(ctx).Where("article_id=?", aid).First(&res).Error
+	return res, err
+}
+
+func (dao *GormArticleSeriesDao) DeleteItemByArticle(ctx context.Context, aid int64) error {
+	return dao.db.WithContext(ctx).Where("article_id=?", aid).Delete(&ArticleSeriesItem{}).Error
+}
+
+type ArticleSeries struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	AuthorId    int64  `gorm:"index"`
+	Title       string `gorm:"type:varchar(256)"`
+	Description string `gorm:"type:varchar(1024)"`
+	Ctime       int64
+	Utime       int64
+}
+
+// ArticleSeriesItem 系列里面的一篇文章，Position 从 1 开始，越小越靠前
+type ArticleSeriesItem struct {
+	Id       int64 `gorm:"primaryKey,autoIncrement"`
+	SeriesId int64 `gorm:"index"`
+	// 一篇文章只能在一个系列里面
+	ArticleId int64 `gorm:"uniqueIndex"`
+	Position  int
+	Ctime     int64
+}
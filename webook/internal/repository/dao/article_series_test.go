@@ -0,0 +1,85 @@
+package dao
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+func TestGormArticleSeriesDao(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webook.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&ArticleSeries{}, &ArticleSeriesItem{}))
+	dao := NewGormArticleSeriesDao(db)
+	ctx := context.Background()
+
+	sid, err := dao.Insert(ctx, ArticleSeries{AuthorId: 123, Title: "Go 入门"})
+	require.NoError(t, err)
+	other, err := dao.Insert(ctx, ArticleSeries{AuthorId: 123, Title: "Go 进阶"})
+	require.NoError(t, err)
+
+	// 别人的系列改不了
+	assert.Equal(t, ErrRecordNotFound, dao.Update(ctx, ArticleSeries{Id: sid, AuthorId: 456, Title: "改掉"}))
+	require.NoError(t, dao.Update(ctx, ArticleSeries{Id: sid, AuthorId: 123, Title: "Go 入门教程", Description: "从零开始"}))
+	s, err := dao.GetById(ctx, sid)
+	require.NoError(t, err)
+	assert.Equal(t, "Go 入门教程", s.Title)
+	assert.Equal(t, "从零开始", s.Description)
+
+	ss, err := dao.ListByAuthor(ctx, 123)
+	require.NoError(t, err)
+	require.Len(t, ss, 2)
+	assert.Equal(t, other, ss[0].Id)
+
+	for _, aid := range []int64{11, 12, 13} {
+		require.NoError(t, dao.AddItem(ctx, sid, aid))
+	}
+	// 一篇文章只能在一个系列里面
+	assert.Equal(t, ErrArticleInSeries, dao.AddItem(ctx, other, 12))
+	assert.Equal(t, []int64{11, 12, 13}, seriesItemIds(t, dao, sid))
+
+	item, err := dao.FindItemByArticle(ctx, 12)
+	require.NoError(t, err)
+	assert.Equal(t, sid, item.SeriesId)
+	assert.Equal(t, 2, item.Position)
+	_, err = dao.FindItemByArticle(ctx, 14)
+	assert.Equal(t, ErrRecordNotFound, err)
+
+	// 少了文章、多了文章、重复的文章都不行
+	assert.Equal(t, ErrArticleSeriesMismatch, dao.Reorder(ctx, sid, []int64{13, 11}))
+	assert.Equal(t, ErrArticleSeriesMismatch, dao.Reorder(ctx, sid, []int64{13, 11, 14}))
+	assert.Equal(t, ErrArticleSeriesMismatch, dao.Reorder(ctx, sid, []int64{13, 11, 11}))
+	require.NoError(t, dao.Reorder(ctx, sid, []int64{13, 11, 12}))
+	assert.Equal(t, []int64{13, 11, 12}, seriesItemIds(t, dao, sid))
+
+	// 移除之后再加到最后面
+	require.NoError(t, dao.RemoveItem(ctx, sid, 13))
+	assert.Equal(t, ErrRecordNotFound, dao.RemoveItem(ctx, sid, 13))
+	require.NoError(t, dao.AddItem(ctx, sid, 13))
+	assert.Equal(t, []int64{11, 12, 13}, seriesItemIds(t, dao, sid))
+
+	require.NoError(t, dao.DeleteItemByArticle(ctx, 11))
+	assert.Equal(t, []int64{12, 13}, seriesItemIds(t, dao, sid))
+
+	// 删除系列之后文章可以加到别的系列
+	assert.Equal(t, ErrRecordNotFound, dao.Delete(ctx, sid, 456))
+	require.NoError(t, dao.Delete(ctx, sid, 123))
+	_, err = dao.GetById(ctx, sid)
+	assert.Equal(t, ErrRecordNotFound, err)
+	assert.Empty(t, seriesItemIds(t, dao, sid))
+	require.NoError(t, dao.AddItem(ctx, other, 12))
+}
+
+func seriesItemIds(t *testing.T, dao ArticleSeriesDao, sid int64) []int64 {
+	items, err := dao.ListItems(context.Background(), sid)
+	require.NoError(t, err)
+	res := make([]int64, 0, len(items))
+	for _, item := range items {
+		res = append(res, item.ArticleId)
+	}
+	return res
+}
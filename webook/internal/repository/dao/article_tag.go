@@ -0,0 +1,144 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+type Tag struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Name  string `gorm:"type:varchar(64);uniqueIndex"`
+	Ctime int64
+}
+
+// ArticleTag 制作库的文章和标签的多对多关系
+type ArticleTag struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:article_tag"`
+	TagId     int64 `gorm:"uniqueIndex:article_tag;index"`
+	Ctime     int64
+}
+
+// PublishedArticleTag 线上库的文章和标签的多对多关系，按照标签查找文章用的是这张表
+type PublishedArticleTag struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:pub_article_tag"`
+	TagId     int64 `gorm:"uniqueIndex:pub_article_tag;index"`
+	Ctime     int64
+}
+
+// findOrCreateTags 按顺序返回标签名字对应的 id，不存在的标签会被创建。
+// MySQL 默认的排序规则不区分大小写，Go 和 go 是同一个标签，这种时候同一个 id 只返回一次
+func findOrCreateTags(tx *gorm.DB, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	now := time.Now().UnixMilli()
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name, Ctime: now})
+	}
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	var found []Tag
+	err = tx.Where("name IN ?", names).Find(&found).Error
+	if err != nil {
+		return nil, err
+	}
+	idx := make(map[string]int64, len(found))
+	foldIdx := make(map[string]int64, len(found))
+	for _, tag := range found {
+		idx[tag.Name] = tag.Id
+		foldIdx[strings.ToLower(tag.Name)] = tag.Id
+	}
+	// 保持标签原本的顺序
+	ids := make([]int64, 0, len(names))
+	seen := make(map[int64]struct{}, len(names))
+	for _, name := range names {
+		id, ok := idx[name]
+		if !ok {
+			id, ok = foldIdx[strings.ToLower(name)]
+		}
+		if !ok {
+			// 排序规则还可能忽略重音之类的，交给数据库按照它的规则去找
+			var tag Tag
+			err = tx.Where("name = ?", name).First(&tag).Error
+			if err != nil {
+				return nil, err
+			}
+			id = tag.Id
+		}
+		if _, ok = seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// replaceArticleTags 用 names 覆盖掉文章原本的标签，model 是 ArticleTag 或者 PublishedArticleTag
+func replaceArticleTags(tx *gorm.DB, model any, aid int64, names []string) error {
+	err := tx.Where("article_id=?", aid).Delete(model).Error
+	if err != nil {
+		return err
+	}
+	ids, err := findOrCreateTags(tx, names)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	switch model.(type) {
+	case *PublishedArticleTag:
+		links := make([]PublishedArticleTag, 0, len(ids))
+		for _, id := range ids {
+			links = append(links, PublishedArticleTag{ArticleId: aid, TagId: id, Ctime: now})
+		}
+		return tx.Create(&links).Error
+	default:
+		links := make([]ArticleTag, 0, len(ids))
+		for _, id := range ids {
+			links = append(links, ArticleTag{ArticleId: aid, TagId: id, Ctime: now})
+		}
+		return tx.Create(&links).Error
+	}
+}
+
+// findTagNames 批量查询文章的标签，linkTable 是 article_tags 或者 published_article_tags
+func findTagNames(db *gorm.DB, linkTable string, aids []int64) (map[int64][]string, error) {
+	res := make(map[int64][]string, len(aids))
+	if len(aids) == 0 {
+		return res, nil
+	}
+	type row struct {
+		ArticleId int64
+		Name      string
+	}
+	var rows []row
+	err := db.Table(linkTable).
+		Select(linkTable+".article_id, tags.name").
+		Joins("JOIN tags ON tags.id = "+linkTable+".tag_id").
+		Where(linkTable+".article_id IN ?", aids).
+		Order(linkTable + ".id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		res[r.ArticleId] = append(res[r.ArticleId], r.Name)
+	}
+	return res, nil
+}
+
+// syncPublishedTags 把制作库的标签同步到线上库，需要和同步文章在同一个事务里面
+func syncPublishedTags(tx *gorm.DB, aid int64) error {
+	tags, err := findTagNames(tx, "article_tags", []int64{aid})
+	if err != nil {
+		return err
+	}
+	return replaceArticleTags(tx, &PublishedArticleTag{}, aid, tags[aid])
+}
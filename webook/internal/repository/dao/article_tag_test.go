@@ -0,0 +1,91 @@
+package dao
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestArticleGormDao_Tags(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Article{}, &PublishedArticle{},
+		&Tag{}, &ArticleTag{}, &PublishedArticleTag{}))
+	dao := NewGormDBArticleDao(db)
+	ctx := context.Background()
+
+	// 新建草稿带标签
+	id, err := dao.Insert(ctx, Article{Title: "标题", AuthorId: 123, Tags: []string{"go", "gin"}})
+	require.NoError(t, err)
+	art, err := dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "gin"}, art.Tags)
+
+	// 草稿的标签没有同步到线上库之前，按标签查不到
+	arts, err := dao.ListPubByTag(ctx, "go", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, arts)
+
+	// 发表的时候不传标签，用的是草稿的标签
+	_, err = dao.Sync(ctx, Article{Id: id, Title: "标题", AuthorId: 123, Version: 1,
+		Status: domain.ArticleStatusPublished})
+	require.NoError(t, err)
+	arts, err = dao.ListPubByTag(ctx, "gin", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, arts, 1)
+	assert.Equal(t, id, arts[0].Id)
+	assert.Equal(t, []string{"go", "gin"}, arts[0].Tags)
+
+	// 修改标签并发表，线上库的标签也跟着变
+	_, err = dao.Sync(ctx, Article{Id: id, Title: "标题", AuthorId: 123, Version: 2,
+		Status: domain.ArticleStatusPublished, Tags: []string{"gorm"}})
+	require.NoError(t, err)
+	arts, err = dao.ListPubByTag(ctx, "go", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, arts)
+	pub, err := dao.GetPubById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gorm"}, pub.Tags)
+
+	// 撤回之后按标签查不到
+	err = dao.SyncStatus(ctx, id, domain.ArticleStatusPrivate)
+	require.NoError(t, err)
+	arts, err = dao.ListPubByTag(ctx, "gorm", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, arts)
+
+	// 空切片清空标签，nil 不修改标签
+	err = dao.UpdateById(ctx, Article{Id: id, Title: "新标题", AuthorId: 123, Version: 3})
+	require.NoError(t, err)
+	art, err = dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gorm"}, art.Tags)
+	err = dao.UpdateById(ctx, Article{Id: id, Title: "新标题", AuthorId: 123, Version: 4, Tags: []string{}})
+	require.NoError(t, err)
+	art, err = dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Empty(t, art.Tags)
+}
+
+// TestFindOrCreateTags_CaseInsensitive 模拟 MySQL 默认不区分大小写的排序规则
+func TestFindOrCreateTags_CaseInsensitive(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE TABLE `tags` (`id` integer PRIMARY KEY AUTOINCREMENT, "+
+		"`name` varchar(64) COLLATE NOCASE UNIQUE, `ctime` integer)").Error)
+	goIds, err := findOrCreateTags(db, []string{"Go"})
+	require.NoError(t, err)
+	require.Len(t, goIds, 1)
+
+	ids, err := findOrCreateTags(db, []string{"go", "GIN", "gin"})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.Equal(t, goIds[0], ids[0])
+	var cnt int64
+	require.NoError(t, db.Model(&Tag{}).Count(&cnt).Error)
+	assert.Equal(t, int64(2), cnt)
+}
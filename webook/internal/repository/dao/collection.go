@@ -0,0 +1,161 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type CollectionDao interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	// UpdateName 只能修改自己的收藏夹，不存在或者不是自己的返回 ErrRecordNotFound，UpdatePublic 也一样
+	UpdateName(ctx context.Context, id int64, uid int64, name string) error
+	UpdatePublic(ctx context.Context, id int64, uid int64, public bool) error
+	GetById(ctx context.Context, id int64) (Collection, error)
+	// ListByUser 按照创建时间倒序，onlyPublic 是 true 的时候只有公开的
+	ListByUser(ctx context.Context, uid int64, onlyPublic bool) ([]Collection, error)
+	// Delete 删除收藏夹和里面的收藏，被删除的收藏的收藏数在同一个事务里面减一，返回被删除的收藏
+	Delete(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error)
+	// DeleteAndMove 删除收藏夹，里面的收藏移到收藏夹 to，收藏数不变
+	DeleteAndMove(ctx context.Context, id int64, uid int64, to int64) error
+	// ListItems 收藏夹里面的收藏，按照收藏时间倒序
+	ListItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]UserCollectionBiz, error)
+}
+
+type GormCollectionDao struct {
+	db *gorm.DB
+}
+
+func NewGormCollectionDao(db *gorm.DB) CollectionDao {
+	return &GormCollectionDao{
+		db: db,
+	}
+}
+
+func (dao *GormCollectionDao) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := dao.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (dao *GormCollectionDao) UpdateName(ctx context.Context, id int64, uid int64, name string) error {
+	return dao.update(ctx, id, uid, map[string]any{
+		"name": name,
+	})
+}
+
+func (dao *GormCollectionDao) UpdatePublic(ctx context.Context, id int64, uid int64, public bool) error {
+	return dao.update(ctx, id, uid, map[string]any{
+		"public": public,
+	})
+}
+
+func (dao *GormCollectionDao) update(ctx context.Context, id int64, uid int64, fields map[string]any) error {
+	fields["utime"] = time.Now().UnixMilli()
+	res := dao.db.WithContext(ctx).Model(&Collection{}).
+		Where("id=? AND uid=?", id, uid).
+		Updates(fields)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (dao *GormCollectionDao) GetById(ctx context.Context, id int64) (Collection, error) {
+	var res Collection
+	err := dao.db.WithContext(ctx).Where("id=?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *GormCollectionDao) ListByUser(ctx context.Context, uid int64, onlyPublic bool) ([]Collection, error) {
+	var res []Collection
+	db := dao.db.WithContext(ctx).Where("uid=?", uid)
+	if onlyPublic {
+		db = db.Where("public=?", true)
+	}
+	err := db.Order("id DESC").Find(&res).Error
+	return res, err
+}
+
+func (dao *GormCollectionDao) Delete(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error) {
+	var deleted []UserCollectionBiz
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := deleteCollection(tx, id, uid)
+		if err != nil {
+			return err
+		}
+		var items []UserCollectionBiz
+		err = tx.Where("uid=? AND cid=?", uid, id).Find(&items).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		for _, item := range items {
+			ok, err := deleteCollectionBiz(tx, item.Id, item.Biz, item.BizId, now)
+			if err != nil {
+				return err
+			}
+			// 并发取消收藏的已经减过了
+			if ok {
+				deleted = append(deleted, item)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+func (dao *GormCollectionDao) DeleteAndMove(ctx context.Context, id int64, uid int64, to int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := deleteCollection(tx, id, uid)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&UserCollectionBiz{}).
+			Where("uid=? AND cid=?", uid, id).
+			Updates(map[string]any{
+				"cid":   to,
+				"utime": time.Now().UnixMilli(),
+			}).Error
+	})
+}
+
+func (dao *GormCollectionDao) ListItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := dao.db.WithContext(ctx).
+		Where("uid=? AND cid=?", uid, cid).
+		Order("ctime DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func deleteCollection(tx *gorm.DB, id int64, uid int64) error {
+	res := tx.Where("id=? AND uid=?", id, uid).Delete(&Collection{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// Collection 收藏夹，里面的收藏是 UserCollectionBiz
+type Collection struct {
+	Id     int64  `gorm:"primaryKey,autoIncrement"`
+	Uid    int64  `gorm:"index"`
+	Name   string `gorm:"type:varchar(128)"`
+	Public bool
+	Ctime  int64
+	Utime  int64
+}
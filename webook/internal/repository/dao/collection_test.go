@@ -0,0 +1,91 @@
+package dao
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+func TestGormCollectionDao(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webook.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Collection{}, &UserCollectionBiz{}, &Interactive{}))
+	dao := NewGormCollectionDao(db)
+	intrDao := NewGormInteractiveDao(db)
+	ctx := context.Background()
+
+	cid, err := dao.Insert(ctx, Collection{Uid: 123, Name: "Go"})
+	require.NoError(t, err)
+	other, err := dao.Insert(ctx, Collection{Uid: 123, Name: "Java"})
+	require.NoError(t, err)
+
+	// 别人的收藏夹改不了
+	assert.Equal(t, ErrRecordNotFound, dao.UpdateName(ctx, cid, 456, "改掉"))
+	require.NoError(t, dao.UpdateName(ctx, cid, 123, "Golang"))
+	require.NoError(t, dao.UpdatePublic(ctx, cid, 123, true))
+	c, err := dao.GetById(ctx, cid)
+	require.NoError(t, err)
+	assert.Equal(t, "Golang", c.Name)
+	assert.True(t, c.Public)
+
+	cs, err := dao.ListByUser(ctx, 123, false)
+	require.NoError(t, err)
+	require.Len(t, cs, 2)
+	assert.Equal(t, other, cs[0].Id)
+	cs, err = dao.ListByUser(ctx, 123, true)
+	require.NoError(t, err)
+	require.Len(t, cs, 1)
+	assert.Equal(t, cid, cs[0].Id)
+
+	// InsertCollectionBiz 用的 upsert 语法 sqlite 不支持，直接插入数据
+	for i, aid := range []int64{11, 12, 13} {
+		require.NoError(t, db.Create(&UserCollectionBiz{Uid: 123, Biz: "article", BizId: aid, Cid: cid, Ctime: int64(i)}).Error)
+		require.NoError(t, db.Create(&Interactive{Biz: "article", BizId: aid, CollectCnt: 1}).Error)
+	}
+	// 别人也收藏了 11
+	require.NoError(t, db.Create(&UserCollectionBiz{Uid: 456, Biz: "article", BizId: 11}).Error)
+	require.NoError(t, db.Model(&Interactive{}).Where("biz_id=?", 11).Update("collect_cnt", 2).Error)
+	items, err := dao.ListItems(ctx, 123, cid, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, int64(13), items[0].BizId)
+	items, err = dao.ListItems(ctx, 123, cid, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	// 取消收藏，收藏数减一，重复取消不会再减
+	require.NoError(t, intrDao.DeleteCollectionBiz(ctx, "article", 13, 123))
+	assert.Equal(t, ErrRecordNotFound, intrDao.DeleteCollectionBiz(ctx, "article", 13, 123))
+	assert.Equal(t, int64(0), collectCnt(t, intrDao, 13))
+
+	// 移到别的收藏夹
+	require.NoError(t, dao.DeleteAndMove(ctx, cid, 123, other))
+	_, err = dao.GetById(ctx, cid)
+	assert.Equal(t, ErrRecordNotFound, err)
+	items, err = dao.ListItems(ctx, 123, other, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, int64(2), collectCnt(t, intrDao, 11))
+
+	// 连收藏一起删除，别人的收藏不受影响
+	_, err = dao.Delete(ctx, other, 456)
+	assert.Equal(t, ErrRecordNotFound, err)
+	deleted, err := dao.Delete(ctx, other, 123)
+	require.NoError(t, err)
+	assert.Len(t, deleted, 2)
+	items, err = dao.ListItems(ctx, 123, other, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, items, 0)
+	assert.Equal(t, int64(1), collectCnt(t, intrDao, 11))
+	assert.Equal(t, int64(0), collectCnt(t, intrDao, 12))
+}
+
+func collectCnt(t *testing.T, dao InteractiveDao, bizId int64) int64 {
+	intr, err := dao.Get(context.Background(), "article", bizId)
+	require.NoError(t, err)
+	return intr.CollectCnt
+}
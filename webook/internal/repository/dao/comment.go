@@ -0,0 +1,179 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type CommentDao interface {
+	// Insert 同时更新根评论的回复数量和资源的评论数量
+	Insert(ctx context.Context, c Comment) (int64, error)
+	// Delete 删除根评论的时候，下面的回复也一起删掉，评论不存在返回 ErrRecordNotFound
+	Delete(ctx context.Context, id int64) error
+	FindById(ctx context.Context, id int64) (Comment, error)
+	// ListRoots 根评论，新的在前面
+	ListRoots(ctx context.Context, biz string, bizId int64, offset int, limit int) ([]Comment, error)
+	// ListReplies 根评论下面的回复，按照回复的先后顺序
+	ListReplies(ctx context.Context, rid int64, offset int, limit int) ([]Comment, error)
+}
+
+// commentBiz 评论本身在互动（点赞、收藏）里面的 biz
+const commentBiz = "comment"
+
+type GormCommentDao struct {
+	db *gorm.DB
+}
+
+func NewGormCommentDao(db *gorm.DB) CommentDao {
+	return &GormCommentDao{
+		db: db,
+	}
+}
+
+func (dao *GormCommentDao) Insert(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&c).Error
+		if err != nil {
+			return err
+		}
+		if c.RootId > 0 {
+			res := tx.Model(&Comment{}).Where("id=?", c.RootId).
+				Updates(map[string]any{
+					"reply_cnt": gorm.Expr("`reply_cnt` + 1"),
+					"utime":     now,
+				})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				// 根评论刚好被删掉了
+				return ErrRecordNotFound
+			}
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "biz_id"}, {Name: "biz"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"comment_cnt": gorm.Expr("`comment_cnt` + 1"),
+				"utime":       now,
+			}),
+		}).Create(&Interactive{
+			Biz:        c.Biz,
+			BizId:      c.BizId,
+			CommentCnt: 1,
+			Ctime:      now,
+			Utime:      now,
+		}).Error
+	})
+	return c.Id, err
+}
+
+func (dao *GormCommentDao) Delete(ctx context.Context, id int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var c Comment
+		err := tx.Where("id=?", id).First(&c).Error
+		if err != nil {
+			return err
+		}
+		ids := []int64{id}
+		if c.RootId == 0 {
+			err = tx.Model(&Comment{}).Where("root_id=?", id).Pluck("id", &ids).Error
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		res := tx.Where("id IN ?", ids).Delete(&Comment{})
+		if res.Error != nil {
+			return res.Error
+		}
+		cnt := res.RowsAffected
+		if cnt == 0 {
+			return ErrRecordNotFound
+		}
+		err = deleteCommentInteractives(tx, ids)
+		if err != nil {
+			return err
+		}
+		if c.RootId > 0 {
+			err = tx.Model(&Comment{}).Where("id=?", c.RootId).
+				Updates(map[string]any{
+					"reply_cnt": gorm.Expr("`reply_cnt` - 1"),
+					"utime":     now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&Interactive{}).
+			Where("biz_id=? AND biz=?", c.BizId, c.Biz).
+			Updates(map[string]any{
+				"comment_cnt": gorm.Expr("`comment_cnt` - ?", cnt),
+				"utime":       now,
+			}).Error
+	})
+}
+
+func (dao *GormCommentDao) FindById(ctx context.Context, id int64) (Comment, error) {
+	var res Comment
+	err := dao.db.WithContext(ctx).Where("id=?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *GormCommentDao) ListRoots(ctx context.Context, biz string, bizId int64, offset int, limit int) ([]Comment, error) {
+	var res []Comment
+	err := dao.db.WithContext(ctx).
+		Where("biz=? AND biz_id=? AND parent_id=?", biz, bizId, 0).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormCommentDao) ListReplies(ctx context.Context, rid int64, offset int, limit int) ([]Comment, error) {
+	var res []Comment
+	err := dao.db.WithContext(ctx).
+		Where("root_id=?", rid).
+		Order("id ASC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// Comment 只有两层，回复的回复也挂在根评论下面，ParentId 记录回复的是哪条
+type Comment struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Biz   string `gorm:"index:biz_type_id_parent;type:varchar(128)"`
+	BizId int64  `gorm:"index:biz_type_id_parent"`
+	// ParentId 根评论是 0
+	ParentId int64 `gorm:"index:biz_type_id_parent"`
+	// RootId 根评论是 0
+	RootId int64 `gorm:"index"`
+	Uid    int64
+	// Content 最多 domain.CommentMaxLength 个字符，utf8mb4 一个字符最多 4 个字节
+	Content  string `gorm:"type:varchar(4096)"`
+	ReplyCnt int64
+	Ctime    int64
+	Utime    int64
+}
+
+// deleteCommentInteractives 评论也可以点赞、收藏，删除评论的时候一起删掉评论自己的计数和用户的记录
+func deleteCommentInteractives(tx *gorm.DB, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := tx.Where("biz=? AND biz_id IN ?", commentBiz, ids).Delete(&UserLikeBiz{}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("biz=? AND biz_id IN ?", commentBiz, ids).Delete(&UserCollectionBiz{}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Where("biz=? AND biz_id IN ?", commentBiz, ids).Delete(&Interactive{}).Error
+}
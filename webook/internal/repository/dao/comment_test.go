@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+func TestGormCommentDao(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webook.db")), &gorm.Config{})
+	require.NoError(t, err)
+	migrateCommentTables(t, db)
+	dao := NewGormCommentDao(db)
+	ctx := context.Background()
+	commentCnt := func() int64 {
+		var intr Interactive
+		require.NoError(t, db.Where("biz=? AND biz_id=?", "article", 11).First(&intr).Error)
+		return intr.CommentCnt
+	}
+
+	root1, err := dao.Insert(ctx, Comment{Biz: "article", BizId: 11, Uid: 123, Content: "第一条"})
+	require.NoError(t, err)
+	root2, err := dao.Insert(ctx, Comment{Biz: "article", BizId: 11, Uid: 456, Content: "第二条"})
+	require.NoError(t, err)
+	reply1, err := dao.Insert(ctx, Comment{Biz: "article", BizId: 11, Uid: 456, Content: "回复",
+		ParentId: root1, RootId: root1})
+	require.NoError(t, err)
+	_, err = dao.Insert(ctx, Comment{Biz: "article", BizId: 11, Uid: 123, Content: "回复的回复",
+		ParentId: reply1, RootId: root1})
+	require.NoError(t, err)
+	// 根评论不存在
+	_, err = dao.Insert(ctx, Comment{Biz: "article", BizId: 11, Uid: 123, Content: "回复",
+		ParentId: 1000, RootId: 1000})
+	assert.Equal(t, ErrRecordNotFound, err)
+	assert.Equal(t, int64(4), commentCnt())
+
+	roots, err := dao.ListRoots(ctx, "article", 11, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, roots, 2)
+	assert.Equal(t, root2, roots[0].Id)
+	assert.Equal(t, root1, roots[1].Id)
+	assert.Equal(t, int64(2), roots[1].ReplyCnt)
+
+	replies, err := dao.ListReplies(ctx, root1, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, replies, 2)
+	assert.Equal(t, reply1, replies[0].Id)
+
+	// 删除回复
+	require.NoError(t, dao.Delete(ctx, reply1))
+	c, err := dao.FindById(ctx, root1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), c.ReplyCnt)
+	assert.Equal(t, int64(3), commentCnt())
+
+	// 删除根评论，回复一起删掉，回复的点赞也一起删掉
+	require.NoError(t, db.Create(&UserLikeBiz{Uid: 123, Biz: "comment", BizId: replies[1].Id, Status: 1}).Error)
+	require.NoError(t, dao.Delete(ctx, root1))
+	replies, err = dao.ListReplies(ctx, root1, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, replies)
+	assert.Equal(t, int64(1), commentCnt())
+	var likeCnt int64
+	require.NoError(t, db.Model(&UserLikeBiz{}).Where("biz=?", "comment").Count(&likeCnt).Error)
+	assert.Equal(t, int64(0), likeCnt)
+
+	assert.Equal(t, ErrRecordNotFound, dao.Delete(ctx, root1))
+}
@@ -18,6 +18,20 @@ func InitTables(db *gorm.DB) error {
 		&Interactive{},
 		&UserLikeBiz{},
 		&UserCollectionBiz{},
+		&PublishedArticleV2{},
+		&ArticleRevision{},
+		&ArticleSchedule{},
+		&ArticleAsset{},
+		&Tag{},
+		&ArticleTag{},
+		&PublishedArticleTag{},
+		&ArticleCollaborator{},
+		&Comment{},
+		&ArticleReview{},
+		&ArticleSeries{},
+		&ArticleSeriesItem{},
+		&ArticleExport{},
+		&Collection{},
 	)
 }
 
@@ -33,6 +47,14 @@ func InitCollection(mdb *mongo.Database) error {
 		{
 			Keys: bson.D{bson.E{Key: "author_id", Value: 1}},
 		},
+		{
+			// 作者的文章列表按照 (utime, id) 倒序翻页
+			Keys: bson.D{bson.E{Key: "author_id", Value: 1}, bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}},
+		},
+		{
+			// 彻底删除回收站里面过期的文章
+			Keys: bson.D{bson.E{Key: "status", Value: 1}, bson.E{Key: "dtime", Value: 1}},
+		},
 	})
 	if err != nil {
 		return err
@@ -47,6 +69,9 @@ func InitCollection(mdb *mongo.Database) error {
 		{
 			Keys: bson.D{bson.E{Key: "author_id", Value: 1}},
 		},
+		{
+			Keys: bson.D{bson.E{Key: "tags", Value: 1}, bson.E{Key: "utime", Value: -1}},
+		},
 	})
 	return err
 }
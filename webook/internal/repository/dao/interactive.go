@@ -4,17 +4,29 @@ import (
 	"context"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"sort"
 	"time"
 )
 
 type InteractiveDao interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
+	// BatchIncrReadCnt 一条语句插入或者更新多行，阅读数分别加上 cnts，要么全部成功要么全部失败
+	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error
 	InsertLikeInfo(ctx context.Context, biz string, aid int64, uid int64) error
 	DeleteLikeInfo(ctx context.Context, biz string, aid int64, uid int64) error
 	InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error
+	// DeleteCollectionBiz 取消收藏，收藏数在同一个事务里面减一，没有收藏过返回 ErrRecordNotFound
+	DeleteCollectionBiz(ctx context.Context, biz string, bizId int64, uid int64) error
 	Get(ctx context.Context, biz string, id int64) (Interactive, error)
+	// GetByIds 没有计数的资源不在结果里面，顺序不保证
+	GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error)
 	GetLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserLikeBiz, error)
 	GetCollectInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserCollectionBiz, error)
+	// GetLikeInfos 和 GetCollectInfos 只返回 uid 点赞了或者收藏了的记录
+	GetLikeInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserLikeBiz, error)
+	GetCollectInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserCollectionBiz, error)
+	// DeleteByBiz 资源被彻底删除的时候，删掉计数和所有用户的点赞、收藏、评论记录，评论自己的计数和点赞、收藏也一起删掉
+	DeleteByBiz(ctx context.Context, biz string, bizId int64) error
 }
 
 type GormInteractiveDao struct {
@@ -43,6 +55,28 @@ func (dao *GormInteractiveDao) Get(ctx context.Context, biz string, bizId int64)
 	return res, err
 }
 
+func (dao *GormInteractiveDao) GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error) {
+	var res []Interactive
+	err := dao.db.WithContext(ctx).Where("biz=? AND biz_id IN ?", biz, ids).Find(&res).Error
+	return res, err
+}
+
+func (dao *GormInteractiveDao) GetLikeInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := dao.db.WithContext(ctx).
+		Where("uid=? AND biz=? AND biz_id IN ? AND status=?", uid, biz, bizIds, 1).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GormInteractiveDao) GetCollectInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := dao.db.WithContext(ctx).
+		Where("uid=? AND biz=? AND biz_id IN ?", uid, biz, bizIds).
+		Find(&res).Error
+	return res, err
+}
+
 func NewGormInteractiveDao(db *gorm.DB) InteractiveDao {
 	return &GormInteractiveDao{
 		db: db,
@@ -74,6 +108,40 @@ func (dao *GormInteractiveDao) InsertCollectionBiz(ctx context.Context, cb UserC
 	return err
 }
 
+func (dao *GormInteractiveDao) DeleteCollectionBiz(ctx context.Context, biz string, bizId int64, uid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cb UserCollectionBiz
+		err := tx.Where("uid=? AND biz_id=? AND biz=?", uid, bizId, biz).First(&cb).Error
+		if err != nil {
+			return err
+		}
+		ok, err := deleteCollectionBiz(tx, cb.Id, biz, bizId, time.Now().UnixMilli())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// 并发取消收藏，被别的请求删掉了
+			return ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// deleteCollectionBiz 真的删掉了收藏才把收藏数减一，避免并发取消收藏的时候减多了
+func deleteCollectionBiz(tx *gorm.DB, id int64, biz string, bizId int64, now int64) (bool, error) {
+	res := tx.Where("id=?", id).Delete(&UserCollectionBiz{})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+	err := tx.Model(&Interactive{}).
+		Where("biz_id=? AND biz=?", bizId, biz).
+		Updates(map[string]interface{}{
+			"collect_cnt": gorm.Expr("`collect_cnt` - 1"),
+			"utime":       now,
+		}).Error
+	return err == nil, err
+}
+
 func (dao *GormInteractiveDao) InsertLikeInfo(ctx context.Context, biz string, aid int64, uid int64) error {
 	now := time.Now().UnixMilli()
 	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -133,6 +201,33 @@ func (dao *GormInteractiveDao) DeleteLikeInfo(ctx context.Context, biz string, a
 	return err
 }
 
+func (dao *GormInteractiveDao) DeleteByBiz(ctx context.Context, biz string, bizId int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("biz_id=? AND biz=?", bizId, biz).Delete(&UserLikeBiz{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz_id=? AND biz=?", bizId, biz).Delete(&UserCollectionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		var cids []int64
+		err = tx.Model(&Comment{}).Where("biz_id=? AND biz=?", bizId, biz).Pluck("id", &cids).Error
+		if err != nil {
+			return err
+		}
+		err = deleteCommentInteractives(tx, cids)
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz_id=? AND biz=?", bizId, biz).Delete(&Comment{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("biz_id=? AND biz=?", bizId, biz).Delete(&Interactive{}).Error
+	})
+}
+
 func (dao *GormInteractiveDao) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
 	now := time.Now().UnixMilli()
 	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
@@ -149,6 +244,33 @@ func (dao *GormInteractiveDao) IncrReadCnt(ctx context.Context, biz string, bizI
 	}).Error
 }
 
+func (dao *GormInteractiveDao) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error {
+	now := time.Now().UnixMilli()
+	intrs := make([]Interactive, 0, len(bizIds))
+	for i := range bizIds {
+		intrs = append(intrs, Interactive{
+			BizId:   bizIds[i],
+			Biz:     bizs[i],
+			ReadCnt: cnts[i],
+			Utime:   now,
+			Ctime:   now,
+		})
+	}
+	// 按照唯一索引排序，并发的批次加锁顺序一样，不容易死锁
+	sort.Slice(intrs, func(i, j int) bool {
+		if intrs[i].Biz != intrs[j].Biz {
+			return intrs[i].Biz < intrs[j].Biz
+		}
+		return intrs[i].BizId < intrs[j].BizId
+	})
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"read_cnt": gorm.Expr("`read_cnt` + VALUES(`read_cnt`)"),
+			"utime":    now,
+		}),
+	}).Create(&intrs).Error
+}
+
 // Interactive 使用了联合主键<bizId, biz>
 type Interactive struct {
 	Id         int64  `gorm:"primaryKey,autoincrement"`
@@ -157,6 +279,7 @@ type Interactive struct {
 	ReadCnt    int64
 	LikeCnt    int64
 	CollectCnt int64
+	CommentCnt int64
 	Ctime      int64
 	Utime      int64
 }
@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+func TestGormInteractiveDao_DeleteByBiz(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webook.db")), &gorm.Config{})
+	require.NoError(t, err)
+	migrateCommentTables(t, db)
+	ctx := context.Background()
+	commentDao := NewGormCommentDao(db)
+	cid, err := commentDao.Insert(ctx, Comment{Biz: "article", BizId: 11, Uid: 123, Content: "评论"})
+	require.NoError(t, err)
+	other, err := commentDao.Insert(ctx, Comment{Biz: "article", BizId: 12, Uid: 123, Content: "别的文章的评论"})
+	require.NoError(t, err)
+	// InsertLikeInfo 用的是 MySQL 的 upsert，这里直接插入点赞记录
+	require.NoError(t, db.Create([]UserLikeBiz{
+		{Uid: 456, Biz: "article", BizId: 11, Status: 1},
+		{Uid: 456, Biz: "comment", BizId: cid, Status: 1},
+		{Uid: 456, Biz: "comment", BizId: other, Status: 1},
+	}).Error)
+	require.NoError(t, db.Create([]Interactive{
+		{Biz: "comment", BizId: cid, LikeCnt: 1},
+		{Biz: "comment", BizId: other, LikeCnt: 1},
+	}).Error)
+	dao := NewGormInteractiveDao(db)
+
+	err = dao.DeleteByBiz(ctx, "article", 11)
+	require.NoError(t, err)
+	count := func(model any, biz string, bizId int64) int64 {
+		var cnt int64
+		require.NoError(t, db.Model(model).Where("biz=? AND biz_id=?", biz, bizId).Count(&cnt).Error)
+		return cnt
+	}
+	assert.Equal(t, int64(0), count(&Comment{}, "article", 11))
+	assert.Equal(t, int64(0), count(&UserLikeBiz{}, "article", 11))
+	// 评论自己的点赞和计数也删掉了
+	assert.Equal(t, int64(0), count(&UserLikeBiz{}, "comment", cid))
+	assert.Equal(t, int64(0), count(&Interactive{}, "comment", cid))
+	// 别的文章的评论不受影响
+	assert.Equal(t, int64(1), count(&UserLikeBiz{}, "comment", other))
+	assert.Equal(t, int64(1), count(&Interactive{}, "comment", other))
+}
+
+// migrateCommentTables 删除评论要用到的表。SQLite 的索引名字是全局的，
+// 点赞和收藏的唯一索引同名，收藏的表手动建，不建索引
+func migrateCommentTables(t *testing.T, db *gorm.DB) {
+	require.NoError(t, db.AutoMigrate(&Comment{}, &Interactive{}, &UserLikeBiz{}))
+	require.NoError(t, db.Exec("CREATE TABLE `user_collection_bizs` (`id` integer PRIMARY KEY AUTOINCREMENT, "+
+		"`uid` integer, `biz_id` integer, `biz` varchar(128), `cid` integer, `ctime` integer, `utime` integer)").Error)
+}
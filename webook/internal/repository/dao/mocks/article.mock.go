@@ -40,6 +40,66 @@ func (m *MockArticleDao) EXPECT() *MockArticleDaoMockRecorder {
 	return m.recorder
 }
 
+// CountByAuthor mocks base method.
+func (m *MockArticleDao) CountByAuthor(ctx context.Context, uid int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByAuthor", ctx, uid)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByAuthor indicates an expected call of CountByAuthor.
+func (mr *MockArticleDaoMockRecorder) CountByAuthor(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAuthor", reflect.TypeOf((*MockArticleDao)(nil).CountByAuthor), ctx, uid)
+}
+
+// GetByAuthor mocks base method.
+func (m *MockArticleDao) GetByAuthor(ctx context.Context, uid, utime, id int64, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, utime, id, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockArticleDaoMockRecorder) GetByAuthor(ctx, uid, utime, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleDao)(nil).GetByAuthor), ctx, uid, utime, id, limit)
+}
+
+// GetById mocks base method.
+func (m *MockArticleDao) GetById(ctx context.Context, id int64) (dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleDaoMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleDao)(nil).GetById), ctx, id)
+}
+
+// GetPubById mocks base method.
+func (m *MockArticleDao) GetPubById(ctx context.Context, id int64) (dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubById", ctx, id)
+	ret0, _ := ret[0].(dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubById indicates an expected call of GetPubById.
+func (mr *MockArticleDaoMockRecorder) GetPubById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubById", reflect.TypeOf((*MockArticleDao)(nil).GetPubById), ctx, id)
+}
+
 // Insert mocks base method.
 func (m *MockArticleDao) Insert(ctx context.Context, art dao.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +115,139 @@ func (mr *MockArticleDaoMockRecorder) Insert(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockArticleDao)(nil).Insert), ctx, art)
 }
 
+// ListPubByAuthor mocks base method.
+func (m *MockArticleDao) ListPubByAuthor(ctx context.Context, uid int64, offset, limit int) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleDaoMockRecorder) ListPubByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleDao)(nil).ListPubByAuthor), ctx, uid, offset, limit)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleDao) ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, offset, limit)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleDaoMockRecorder) ListPubByTag(ctx, tag, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleDao)(nil).ListPubByTag), ctx, tag, offset, limit)
+}
+
+// ListPubLatest mocks base method.
+func (m *MockArticleDao) ListPubLatest(ctx context.Context, limit int) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubLatest", ctx, limit)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubLatest indicates an expected call of ListPubLatest.
+func (mr *MockArticleDaoMockRecorder) ListPubLatest(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubLatest", reflect.TypeOf((*MockArticleDao)(nil).ListPubLatest), ctx, limit)
+}
+
+// ListPubSince mocks base method.
+func (m *MockArticleDao) ListPubSince(ctx context.Context, since, id int64, limit int) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubSince", ctx, since, id, limit)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubSince indicates an expected call of ListPubSince.
+func (mr *MockArticleDaoMockRecorder) ListPubSince(ctx, since, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubSince", reflect.TypeOf((*MockArticleDao)(nil).ListPubSince), ctx, since, id, limit)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleDao) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleDaoMockRecorder) ListTrash(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleDao)(nil).ListTrash), ctx, uid, offset, limit)
+}
+
+// ListTrashBefore mocks base method.
+func (m *MockArticleDao) ListTrashBefore(ctx context.Context, dtime int64, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrashBefore", ctx, dtime, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrashBefore indicates an expected call of ListTrashBefore.
+func (mr *MockArticleDaoMockRecorder) ListTrashBefore(ctx, dtime, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashBefore", reflect.TypeOf((*MockArticleDao)(nil).ListTrashBefore), ctx, dtime, limit)
+}
+
+// Purge mocks base method.
+func (m *MockArticleDao) Purge(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleDaoMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleDao)(nil).Purge), ctx, id)
+}
+
+// Restore mocks base method.
+func (m *MockArticleDao) Restore(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleDaoMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleDao)(nil).Restore), ctx, id)
+}
+
+// ScanPub mocks base method.
+func (m *MockArticleDao) ScanPub(ctx context.Context, id int64, limit int) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanPub", ctx, id, limit)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanPub indicates an expected call of ScanPub.
+func (mr *MockArticleDaoMockRecorder) ScanPub(ctx, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanPub", reflect.TypeOf((*MockArticleDao)(nil).ScanPub), ctx, id, limit)
+}
+
 // Sync mocks base method.
 func (m *MockArticleDao) Sync(ctx context.Context, art dao.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -71,17 +264,31 @@ func (mr *MockArticleDaoMockRecorder) Sync(ctx, art any) *gomock.Call {
 }
 
 // SyncStatus mocks base method.
-func (m *MockArticleDao) SyncStatus(ctx context.Context, uid, id int64, status domain.ArticleStatus) error {
+func (m *MockArticleDao) SyncStatus(ctx context.Context, id int64, status domain.ArticleStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SyncStatus", ctx, uid, id, status)
+	ret := m.ctrl.Call(m, "SyncStatus", ctx, id, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SyncStatus indicates an expected call of SyncStatus.
-func (mr *MockArticleDaoMockRecorder) SyncStatus(ctx, uid, id, status any) *gomock.Call {
+func (mr *MockArticleDaoMockRecorder) SyncStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleDao)(nil).SyncStatus), ctx, id, status)
+}
+
+// Trash mocks base method.
+func (m *MockArticleDao) Trash(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trash", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trash indicates an expected call of Trash.
+func (mr *MockArticleDaoMockRecorder) Trash(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleDao)(nil).SyncStatus), ctx, uid, id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trash", reflect.TypeOf((*MockArticleDao)(nil).Trash), ctx, id)
 }
 
 // UpdateById mocks base method.
@@ -97,3 +304,17 @@ func (mr *MockArticleDaoMockRecorder) UpdateById(ctx, art any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockArticleDao)(nil).UpdateById), ctx, art)
 }
+
+// UpdatePubStats mocks base method.
+func (m *MockArticleDao) UpdatePubStats(ctx context.Context, art dao.PublishedArticle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePubStats", ctx, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePubStats indicates an expected call of UpdatePubStats.
+func (mr *MockArticleDaoMockRecorder) UpdatePubStats(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePubStats", reflect.TypeOf((*MockArticleDao)(nil).UpdatePubStats), ctx, art)
+}
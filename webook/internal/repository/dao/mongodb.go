@@ -11,27 +11,147 @@ import (
 	"time"
 )
 
-var ErrPossibleIncorrectAuthor = errors.New("更新失败，ID不对或作者不对")
-
 type MongoDBArticleDao struct {
 	node    *snowflake.Node
 	col     *mongo.Collection
 	liveCol *mongo.Collection
 }
 
-func (m *MongoDBArticleDao) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
-	//TODO implement me
-	panic("implement me")
+func (m *MongoDBArticleDao) GetByAuthor(ctx context.Context, uid int64, utime int64, id int64, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "author_id", Value: uid},
+		bson.E{Key: "status", Value: bson.D{bson.E{Key: "$ne", Value: statusDeleted}}}}
+	if id > 0 {
+		// 和 GORM 的实现一样按照 (utime, id) 翻页
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{bson.E{Key: "utime", Value: bson.D{bson.E{Key: "$lt", Value: utime}}}},
+			bson.D{bson.E{Key: "utime", Value: utime}, bson.E{Key: "id", Value: bson.D{bson.E{Key: "$lt", Value: id}}}},
+		}})
+	}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDao) CountByAuthor(ctx context.Context, uid int64) (int64, error) {
+	return m.col.CountDocuments(ctx, bson.D{bson.E{Key: "author_id", Value: uid},
+		bson.E{Key: "status", Value: bson.D{bson.E{Key: "$ne", Value: statusDeleted}}}})
 }
 
 func (m *MongoDBArticleDao) GetById(ctx context.Context, id int64) (Article, error) {
-	//TODO implement me
-	panic("implement me")
+	var art Article
+	err := m.col.FindOne(ctx, bson.D{bson.E{Key: "id", Value: id}}).Decode(&art)
+	return art, m.wrapNotFound(err)
 }
 
 func (m *MongoDBArticleDao) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
-	//TODO implement me
-	panic("implement me")
+	var art PublishedArticle
+	err := m.liveCol.FindOne(ctx, bson.D{bson.E{Key: "id", Value: id}}).Decode(&art)
+	return art, m.wrapNotFound(err)
+}
+
+// wrapNotFound 和 GORM 的实现保持一致，上层只需要判断 ErrRecordNotFound
+func (m *MongoDBArticleDao) wrapNotFound(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrRecordNotFound
+	}
+	return err
+}
+
+func (m *MongoDBArticleDao) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]PublishedArticle, error) {
+	filter := bson.D{bson.E{Key: "tags", Value: tag},
+		bson.E{Key: "status", Value: domain.ArticleStatusPublished}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDao) ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]PublishedArticle, error) {
+	filter := bson.D{bson.E{Key: "author_id", Value: uid},
+		bson.E{Key: "status", Value: domain.ArticleStatusPublished}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDao) ListPubLatest(ctx context.Context, limit int) ([]PublishedArticle, error) {
+	filter := bson.D{bson.E{Key: "status", Value: domain.ArticleStatusPublished}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDao) ListPubSince(ctx context.Context, since int64, id int64, limit int) ([]PublishedArticle, error) {
+	filter := bson.D{bson.E{Key: "id", Value: bson.D{bson.E{Key: "$gt", Value: id}}},
+		bson.E{Key: "status", Value: domain.ArticleStatusPublished},
+		bson.E{Key: "ctime", Value: bson.D{bson.E{Key: "$gte", Value: since}}}}
+	opts := options.Find().
+		SetProjection(bson.D{bson.E{Key: "content", Value: 0}, bson.E{Key: "html", Value: 0}}).
+		SetSort(bson.D{bson.E{Key: "id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDao) ScanPub(ctx context.Context, id int64, limit int) ([]PublishedArticle, error) {
+	filter := bson.D{bson.E{Key: "id", Value: bson.D{bson.E{Key: "$gt", Value: id}}}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+// UpdatePubStats 统计是 0 的时候 omitempty 会忽略，所以不能直接 $set 整个结构体
+func (m *MongoDBArticleDao) UpdatePubStats(ctx context.Context, art PublishedArticle) error {
+	filter := bson.D{bson.E{Key: "id", Value: art.Id}}
+	sets := bson.D{bson.E{Key: "$set", Value: bson.D{
+		bson.E{Key: "word_cnt", Value: art.WordCnt},
+		bson.E{Key: "read_minutes", Value: art.ReadMinutes},
+		bson.E{Key: "image_cnt", Value: art.ImageCnt},
+		bson.E{Key: "code_block_cnt", Value: art.CodeBlockCnt},
+	}}}
+	_, err := m.liveCol.UpdateOne(ctx, filter, sets)
+	return err
 }
 
 func NewMongoDBArticleDao(mdb *mongo.Database, node *snowflake.Node) *MongoDBArticleDao {
@@ -47,25 +167,50 @@ func (m *MongoDBArticleDao) Insert(ctx context.Context, art Article) (int64, err
 	art.Ctime = now
 	art.Utime = now
 	art.Id = m.node.Generate().Int64()
+	art.Version = 1
 	_, err := m.col.InsertOne(ctx, &art)
 	return art.Id, err
 }
 
 func (m *MongoDBArticleDao) UpdateById(ctx context.Context, art Article) error {
 	now := time.Now().UnixMilli()
-	filter := bson.D{bson.E{Key: "id", Value: art.Id}, bson.E{Key: "author_id", Value: art.AuthorId}}
-	set := bson.D{bson.E{Key: "$set", Value: bson.M{
-		"title":   art.Title,
-		"content": art.Content,
-		"status":  art.Status,
-		"utime":   now,
-	}}}
+	// 回收站里面的文章不允许修改，只能先 Restore
+	filter := bson.D{bson.E{Key: "id", Value: art.Id},
+		bson.E{Key: "status", Value: bson.D{bson.E{Key: "$ne", Value: statusDeleted}}},
+		bson.E{Key: "version", Value: art.Version}}
+	fields := bson.M{
+		"title":          art.Title,
+		"content":        art.Content,
+		"format":         art.Format,
+		"html":           art.Html,
+		"summary":        art.Summary,
+		"word_cnt":       art.WordCnt,
+		"read_minutes":   art.ReadMinutes,
+		"image_cnt":      art.ImageCnt,
+		"code_block_cnt": art.CodeBlockCnt,
+		"status":         art.Status,
+		"utime":          now,
+	}
+	// nil 代表不修改标签
+	if art.Tags != nil {
+		fields["tags"] = art.Tags
+	}
+	set := bson.D{bson.E{Key: "$set", Value: fields},
+		bson.E{Key: "$inc", Value: bson.D{bson.E{Key: "version", Value: 1}}}}
 	res, err := m.col.UpdateOne(ctx, filter, set)
 	if err != nil {
 		return err
 	}
 	if res.MatchedCount == 0 {
-		return ErrPossibleIncorrectAuthor
+		// 区分一下是版本不对还是文章不存在
+		cnt, err := m.col.CountDocuments(ctx, filter[:2])
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrArticleVersionConflict
+		}
+		return ErrRecordNotFound
 	}
 	return nil
 }
@@ -83,27 +228,159 @@ func (m *MongoDBArticleDao) Sync(ctx context.Context, art Article) (int64, error
 	if err != nil {
 		return 0, err
 	}
+	// 线上库的版本号和制作库保持一致
+	art.Version++
+	if art.Tags == nil {
+		// 没有修改标签，线上库用制作库里面的标签
+		var draft Article
+		err = m.col.FindOne(ctx, bson.D{bson.E{Key: "id", Value: id}}).Decode(&draft)
+		if err != nil {
+			return 0, err
+		}
+		art.Tags = draft.Tags
+	}
 	// liveCol insert or update
 	now := time.Now().UnixMilli()
 	art.Utime = now
 	art.Id = id
-	filter := bson.D{bson.E{Key: "id", Value: art.Id}, bson.E{Key: "author_id", Value: art.AuthorId}}
-	sets := bson.D{bson.E{Key: "$set", Value: art},
-		bson.E{Key: "$setOnInsert", Value: bson.D{bson.E{Key: "ctime", Value: now}}}}
+	filter := bson.D{bson.E{Key: "id", Value: art.Id}}
+	// omitempty 会忽略零值，格式、摘要、统计这些都可能是零值，所以不能直接 $set 整个结构体
+	fields := bson.M{
+		"title":          art.Title,
+		"content":        art.Content,
+		"author_id":      art.AuthorId,
+		"status":         art.Status,
+		"format":         art.Format,
+		"html":           art.Html,
+		"summary":        art.Summary,
+		"word_cnt":       art.WordCnt,
+		"read_minutes":   art.ReadMinutes,
+		"image_cnt":      art.ImageCnt,
+		"code_block_cnt": art.CodeBlockCnt,
+		"version":        art.Version,
+		"utime":          now,
+	}
+	sets := bson.D{bson.E{Key: "$setOnInsert", Value: bson.D{bson.E{Key: "ctime", Value: now}}}}
+	if len(art.Tags) > 0 {
+		fields["tags"] = art.Tags
+	} else {
+		// 没有标签的时候要显式清掉线上库的标签
+		sets = append(sets, bson.E{Key: "$unset", Value: bson.D{bson.E{Key: "tags", Value: ""}}})
+	}
+	sets = append(sets, bson.E{Key: "$set", Value: fields})
 	_, err = m.liveCol.UpdateOne(ctx, filter, sets, options.Update().SetUpsert(true))
 	return id, err
 }
 
-func (m *MongoDBArticleDao) SyncStatus(ctx context.Context, uid int64, id int64, status domain.ArticleStatus) error {
-	filter := bson.D{bson.E{Key: "id", Value: id}, bson.E{Key: "author_id", Value: uid}}
-	sets := bson.D{bson.E{Key: "$set", Value: bson.D{bson.E{Key: "status", Value: status}}}}
+func (m *MongoDBArticleDao) SyncStatus(ctx context.Context, id int64, status domain.ArticleStatus) error {
+	filter := bson.D{bson.E{Key: "id", Value: id}}
+	sets := bson.D{bson.E{Key: "$set", Value: bson.D{
+		bson.E{Key: "status", Value: status},
+		bson.E{Key: "utime", Value: time.Now().UnixMilli()},
+	}}}
 	res, err := m.col.UpdateOne(ctx, filter, sets)
 	if err != nil {
 		return err
 	}
-	if res.ModifiedCount != 1 {
-		return ErrPossibleIncorrectAuthor
+	// 状态没有变化的时候 ModifiedCount 是 0，所以要看 MatchedCount
+	if res.MatchedCount != 1 {
+		return ErrRecordNotFound
 	}
 	_, err = m.liveCol.UpdateOne(ctx, filter, sets)
 	return err
 }
+
+func (m *MongoDBArticleDao) Trash(ctx context.Context, id int64) error {
+	now := time.Now().UnixMilli()
+	filter := bson.D{bson.E{Key: "id", Value: id},
+		bson.E{Key: "status", Value: bson.D{bson.E{Key: "$ne", Value: statusDeleted}}}}
+	res, err := m.col.UpdateOne(ctx, filter, bson.D{
+		bson.E{Key: "$set", Value: bson.D{
+			bson.E{Key: "status", Value: statusDeleted},
+			bson.E{Key: "dtime", Value: now},
+			bson.E{Key: "utime", Value: now},
+		}},
+		bson.E{Key: "$inc", Value: bson.D{bson.E{Key: "version", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrRecordNotFound
+	}
+	_, err = m.liveCol.UpdateOne(ctx, bson.D{bson.E{Key: "id", Value: id}},
+		bson.D{bson.E{Key: "$set", Value: bson.D{
+			bson.E{Key: "status", Value: statusDeleted},
+			bson.E{Key: "utime", Value: now},
+		}}})
+	return err
+}
+
+func (m *MongoDBArticleDao) Restore(ctx context.Context, id int64) error {
+	now := time.Now().UnixMilli()
+	filter := bson.D{bson.E{Key: "id", Value: id},
+		bson.E{Key: "status", Value: statusDeleted}}
+	res, err := m.col.UpdateOne(ctx, filter, bson.D{
+		bson.E{Key: "$set", Value: bson.D{
+			bson.E{Key: "status", Value: domain.ArticleStatusUnpublished},
+			bson.E{Key: "utime", Value: now},
+		}},
+		bson.E{Key: "$unset", Value: bson.D{bson.E{Key: "dtime", Value: ""}}},
+		bson.E{Key: "$inc", Value: bson.D{bson.E{Key: "version", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrRecordNotFound
+	}
+	_, err = m.liveCol.UpdateOne(ctx, bson.D{bson.E{Key: "id", Value: id}},
+		bson.D{bson.E{Key: "$set", Value: bson.D{
+			bson.E{Key: "status", Value: domain.ArticleStatusPrivate},
+			bson.E{Key: "utime", Value: now},
+		}}})
+	return err
+}
+
+func (m *MongoDBArticleDao) ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "author_id", Value: uid}, bson.E{Key: "status", Value: statusDeleted}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "dtime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	return m.findArticles(ctx, filter, opts)
+}
+
+func (m *MongoDBArticleDao) ListTrashBefore(ctx context.Context, dtime int64, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "status", Value: statusDeleted},
+		bson.E{Key: "dtime", Value: bson.D{bson.E{Key: "$lt", Value: dtime}}}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "dtime", Value: 1}, bson.E{Key: "id", Value: 1}}).
+		SetLimit(int64(limit))
+	return m.findArticles(ctx, filter, opts)
+}
+
+func (m *MongoDBArticleDao) findArticles(ctx context.Context, filter bson.D, opts *options.FindOptions) ([]Article, error) {
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDao) Purge(ctx context.Context, id int64) error {
+	// 没有事务，先删线上库，失败了重试的时候制作库还在回收站里面，还能再扫描到
+	filter := bson.D{bson.E{Key: "id", Value: id}, bson.E{Key: "status", Value: statusDeleted}}
+	cnt, err := m.col.CountDocuments(ctx, filter)
+	if err != nil || cnt == 0 {
+		return err
+	}
+	_, err = m.liveCol.DeleteOne(ctx, bson.D{bson.E{Key: "id", Value: id}})
+	if err != nil {
+		return err
+	}
+	_, err = m.col.DeleteOne(ctx, filter)
+	return err
+}
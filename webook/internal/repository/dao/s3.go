@@ -1,123 +0,0 @@
-package dao
-
-import (
-	"bytes"
-	"context"
-	"errors"
-	"geek-basic-go/webook/internal/domain"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/ecodeclub/ekit"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
-	"strconv"
-	"time"
-)
-
-type ArticleS3Dao struct {
-	ArticleGormDao
-	oss *s3.S3
-}
-
-func NewArticleS3Dao(db *gorm.DB, oss *s3.S3) *ArticleS3Dao {
-	return &ArticleS3Dao{
-		ArticleGormDao: ArticleGormDao{
-			db: db,
-		},
-		oss: oss,
-	}
-}
-
-func (a *ArticleS3Dao) Sync(ctx context.Context, art Article) (int64, error) {
-	var id = art.Id
-	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		dao := NewGormDBArticleDao(tx)
-		var (
-			err error
-		)
-		if id > 0 {
-			err = dao.UpdateById(ctx, art)
-		} else {
-			id, err = dao.Insert(ctx, art)
-		}
-
-		if err != nil {
-			return err
-		}
-		art.Id = id
-		pubArt := PublishedArticleV2{
-			Id:       art.Id,
-			Title:    art.Title,
-			AuthorId: art.AuthorId,
-			Utime:    art.Utime,
-			Ctime:    art.Ctime,
-			Status:   art.Status,
-		}
-		now := time.Now().UnixMilli()
-		pubArt.Ctime = now
-		pubArt.Utime = now
-		err = tx.Clauses(clause.OnConflict{
-			Columns: []clause.Column{{Name: "id"}},
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"title":  pubArt.Title,
-				"utime":  now,
-				"status": pubArt.Status,
-			}),
-		}).Create(&pubArt).Error
-		return err
-	})
-	if err != nil {
-		return 0, err
-	}
-	_, err = a.oss.PutObjectWithContext(ctx, &s3.PutObjectInput{
-		Bucket:      ekit.ToPtr[string]("webook-1314583317"),
-		Key:         ekit.ToPtr[string](strconv.FormatInt(art.Id, 10)),
-		Body:        bytes.NewReader([]byte(art.Content)),
-		ContentType: ekit.ToPtr[string]("text/plain;charset=utf-8"),
-	})
-	return id, err
-}
-
-func (a *ArticleS3Dao) SyncStatus(ctx context.Context, uid int64, id int64, status domain.ArticleStatus) error {
-	now := time.Now().UnixMilli()
-	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		res := tx.Model(&Article{}).
-			Where("id=? and author_id=?", id, uid).
-			Updates(map[string]any{
-				"utime":  now,
-				"status": status,
-			})
-		if res.Error != nil {
-			return res.Error
-		}
-		if res.RowsAffected == 0 {
-			return errors.New("更新失败，ID不对或作者不对")
-		}
-
-		return tx.Model(&PublishedArticleV2{}).
-			Where("id=?", id).
-			Updates(map[string]any{
-				"utime":  now,
-				"status": status,
-			}).Error
-	})
-	if err != nil {
-		return err
-	}
-	const statusPrivate = 3
-	if status == statusPrivate {
-		_, err = a.oss.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
-			Bucket: ekit.ToPtr[string]("webook-1314583317"),
-			Key:    ekit.ToPtr[string](strconv.FormatInt(id, 10)),
-		})
-	}
-	return err
-}
-
-type PublishedArticleV2 struct {
-	Id       int64  `gorm:"primaryKey, autoIncrement" bson:"id,omitempty"`
-	Title    string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
-	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8  `bson:"status,omitempty"`
-	Ctime    int64  `bson:"ctime,omitempty"`
-	Utime    int64  `bson:"utime,omitempty"`
-}
@@ -9,14 +9,32 @@ import (
 	"geek-basic-go/webook/pkg/logger"
 )
 
+// ErrInteractiveNotFound 还没有人读过、点赞过或者收藏过
+var ErrInteractiveNotFound = dao.ErrRecordNotFound
+
+// ErrCollectionItemNotFound 没有收藏过
+var ErrCollectionItemNotFound = dao.ErrRecordNotFound
+
 type InteractiveRepository interface {
 	IncrReadCnt(ctx context.Context, biz string, id int64) error
+	// BatchIncrReadCnt 阅读数分别加上 cnts，同一个资源的要先合并好。
+	// 只有数据库失败才返回 error，缓存失败只记日志，调用方重试不会重复计数
+	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64, cnts []int64) error
 	IncrLike(ctx context.Context, biz string, id int64, uid int64) error
 	DecrLike(ctx context.Context, biz string, id int64, uid int64) error
 	AddCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) error
+	// DeleteCollectionItem 没有收藏过返回 ErrCollectionItemNotFound
+	DeleteCollectionItem(ctx context.Context, biz string, id int64, uid int64) error
 	Get(ctx context.Context, biz string, id int64) (domain.Interactive, error)
+	// GetByIds 结果里面每个 id 都有，还没有计数的是 0
+	GetByIds(ctx context.Context, biz string, ids []int64) (map[int64]domain.Interactive, error)
 	Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	Collected(ctx context.Context, biz string, id int64, uid int64) (bool, error)
+	// LikedByIds 和 CollectedByIds 只返回 uid 点赞了或者收藏了的 id
+	LikedByIds(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]bool, error)
+	CollectedByIds(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]bool, error)
+	// Delete 资源被彻底删除的时候，清理计数、点赞和收藏记录
+	Delete(ctx context.Context, biz string, id int64) error
 }
 
 type CachedInteractiveRepository struct {
@@ -56,28 +74,114 @@ func (c *CachedInteractiveRepository) Get(ctx context.Context, biz string, id in
 	return intr, err
 }
 
+func (c *CachedInteractiveRepository) GetByIds(ctx context.Context, biz string, ids []int64) (map[int64]domain.Interactive, error) {
+	if len(ids) == 0 {
+		return map[int64]domain.Interactive{}, nil
+	}
+	res, err := c.cache.GetByIds(ctx, biz, ids)
+	if err != nil {
+		// 缓存出错了就全部查数据库
+		c.l.Error("批量查询缓存失败",
+			logger.String("biz", biz),
+			logger.Error(err))
+		res = make(map[int64]domain.Interactive, len(ids))
+	}
+	missed := make([]int64, 0, len(ids)-len(res))
+	for _, id := range ids {
+		if _, ok := res[id]; !ok {
+			missed = append(missed, id)
+		}
+	}
+	if len(missed) == 0 {
+		return res, nil
+	}
+	ies, err := c.dao.GetByIds(ctx, biz, missed)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[int64]domain.Interactive, len(ies))
+	for _, ie := range ies {
+		found[ie.BizId] = c.toDomain(ie)
+	}
+	for _, id := range missed {
+		// 数据库里面也没有的就是 0，和 Get 一样不回写缓存
+		res[id] = found[id]
+	}
+	if len(found) > 0 {
+		err = c.cache.BatchSet(ctx, biz, found)
+		if err != nil {
+			c.l.Error("批量回写缓存失败",
+				logger.String("biz", biz),
+				logger.Error(err))
+		}
+	}
+	return res, nil
+}
+
+func (c *CachedInteractiveRepository) LikedByIds(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]bool, error) {
+	likes, err := c.dao.GetLikeInfos(ctx, biz, ids, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]bool, len(likes))
+	for _, like := range likes {
+		res[like.BizId] = true
+	}
+	return res, nil
+}
+
+func (c *CachedInteractiveRepository) CollectedByIds(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]bool, error) {
+	cbs, err := c.dao.GetCollectInfos(ctx, biz, ids, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]bool, len(cbs))
+	for _, cb := range cbs {
+		res[cb.BizId] = true
+	}
+	return res, nil
+}
+
 func (c *CachedInteractiveRepository) Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error) {
-	_, err := c.dao.GetLikeInfo(ctx, biz, id, uid)
+	liked, err := c.cache.GetLiked(ctx, biz, id, uid)
+	if err == nil {
+		return liked, nil
+	}
+	_, err = c.dao.GetLikeInfo(ctx, biz, id, uid)
 	switch {
 	case err == nil:
-		return true, nil
+		liked = true
 	case errors.Is(err, dao.ErrRecordNotFound):
-		return false, nil
+		liked = false
 	default:
 		return false, err
 	}
+	err = c.cache.FillLiked(ctx, biz, id, uid, liked)
+	if err != nil {
+		c.logUserStateErr("回写点赞状态缓存失败", biz, id, uid, err)
+	}
+	return liked, nil
 }
 
 func (c *CachedInteractiveRepository) Collected(ctx context.Context, biz string, id int64, uid int64) (bool, error) {
-	_, err := c.dao.GetCollectInfo(ctx, biz, id, uid)
+	collected, err := c.cache.GetCollected(ctx, biz, id, uid)
+	if err == nil {
+		return collected, nil
+	}
+	_, err = c.dao.GetCollectInfo(ctx, biz, id, uid)
 	switch {
 	case err == nil:
-		return true, nil
+		collected = true
 	case errors.Is(err, dao.ErrRecordNotFound):
-		return false, nil
+		collected = false
 	default:
 		return false, err
 	}
+	err = c.cache.FillCollected(ctx, biz, id, uid, collected)
+	if err != nil {
+		c.logUserStateErr("回写收藏状态缓存失败", biz, id, uid, err)
+	}
+	return collected, nil
 }
 
 func (c *CachedInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) error {
@@ -90,14 +194,34 @@ func (c *CachedInteractiveRepository) AddCollectionItem(ctx context.Context, biz
 	if err != nil {
 		return err
 	}
+	err = c.cache.SetCollected(ctx, biz, id, uid, true)
+	if err != nil {
+		c.logUserStateErr("更新收藏状态缓存失败", biz, id, uid, err)
+	}
 	return c.cache.IncrCollectCntIfPresent(ctx, biz, id)
 }
 
+func (c *CachedInteractiveRepository) DeleteCollectionItem(ctx context.Context, biz string, id int64, uid int64) error {
+	err := c.dao.DeleteCollectionBiz(ctx, biz, id, uid)
+	if err != nil {
+		return err
+	}
+	err = c.cache.SetCollected(ctx, biz, id, uid, false)
+	if err != nil {
+		c.logUserStateErr("更新收藏状态缓存失败", biz, id, uid, err)
+	}
+	return c.cache.DecrCollectCntIfPresent(ctx, biz, id)
+}
+
 func (c *CachedInteractiveRepository) IncrLike(ctx context.Context, biz string, id int64, uid int64) error {
 	err := c.dao.InsertLikeInfo(ctx, biz, id, uid)
 	if err != nil {
 		return err
 	}
+	err = c.cache.SetLiked(ctx, biz, id, uid, true)
+	if err != nil {
+		c.logUserStateErr("更新点赞状态缓存失败", biz, id, uid, err)
+	}
 	return c.cache.IncrLikeCntIfPresent(ctx, biz, id)
 }
 
@@ -106,6 +230,10 @@ func (c *CachedInteractiveRepository) DecrLike(ctx context.Context, biz string,
 	if err != nil {
 		return err
 	}
+	err = c.cache.SetLiked(ctx, biz, id, uid, false)
+	if err != nil {
+		c.logUserStateErr("更新点赞状态缓存失败", biz, id, uid, err)
+	}
 	return c.cache.DecrLikeCntIfPresent(ctx, biz, id)
 }
 
@@ -119,10 +247,46 @@ func (c *CachedInteractiveRepository) IncrReadCnt(ctx context.Context, biz strin
 	return c.cache.IncrReadCntIfPresent(ctx, biz, bizId)
 }
 
+func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64, cnts []int64) error {
+	err := c.dao.BatchIncrReadCnt(ctx, bizs, ids, cnts)
+	if err != nil {
+		return err
+	}
+	err = c.cache.BatchIncrReadCntIfPresent(ctx, bizs, ids, cnts)
+	if err != nil {
+		c.l.Error("批量更新缓存阅读数失败",
+			logger.Int("cnt", len(ids)),
+			logger.Error(err))
+	}
+	return nil
+}
+
+func (c *CachedInteractiveRepository) Delete(ctx context.Context, biz string, id int64) error {
+	err := c.dao.DeleteByBiz(ctx, biz, id)
+	if err != nil {
+		return err
+	}
+	err = c.cache.DeleteUserState(ctx, biz, id)
+	if err != nil {
+		return err
+	}
+	return c.cache.Delete(ctx, biz, id)
+}
+
+// logUserStateErr 点赞和收藏状态的缓存更新失败了，过期之前用户看到的状态可能不对
+func (c *CachedInteractiveRepository) logUserStateErr(msg string, biz string, id int64, uid int64, err error) {
+	c.l.Error(msg,
+		logger.String("biz", biz),
+		logger.Int64("bizId", id),
+		logger.Int64("uid", uid),
+		logger.Error(err))
+}
+
 func (c *CachedInteractiveRepository) toDomain(ie dao.Interactive) domain.Interactive {
 	return domain.Interactive{
 		ReadCnt:    ie.ReadCnt,
 		LikeCnt:    ie.LikeCnt,
 		CollectCnt: ie.CollectCnt,
+		CommentCnt: ie.CommentCnt,
 	}
 }
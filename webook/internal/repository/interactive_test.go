@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/cache"
+	cachemocks "geek-basic-go/webook/internal/repository/cache/mocks"
+	"geek-basic-go/webook/internal/repository/dao"
+	daomocks "geek-basic-go/webook/internal/repository/dao/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestCachedInteractiveRepository_GetByIds(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao)
+		ids  []int64
+
+		wantIntrs map[int64]domain.Interactive
+		wantErr   error
+	}{
+		{
+			name: "全部命中缓存",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{1: {ReadCnt: 1}, 2: {ReadCnt: 2}}, nil)
+				return c, daomocks.NewMockInteractiveDao(ctrl)
+			},
+			ids:       []int64{1, 2},
+			wantIntrs: map[int64]domain.Interactive{1: {ReadCnt: 1}, 2: {ReadCnt: 2}},
+		},
+		{
+			name: "部分命中，数据库里面没有的是 0",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d := daomocks.NewMockInteractiveDao(ctrl)
+				c.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2, 3}).
+					Return(map[int64]domain.Interactive{1: {ReadCnt: 1}}, nil)
+				d.EXPECT().GetByIds(gomock.Any(), "article", []int64{2, 3}).
+					Return([]dao.Interactive{{BizId: 2, Biz: "article", ReadCnt: 2, LikeCnt: 1}}, nil)
+				// 只回写数据库里面有的
+				c.EXPECT().BatchSet(gomock.Any(), "article",
+					map[int64]domain.Interactive{2: {ReadCnt: 2, LikeCnt: 1}}).Return(nil)
+				return c, d
+			},
+			ids: []int64{1, 2, 3},
+			wantIntrs: map[int64]domain.Interactive{
+				1: {ReadCnt: 1},
+				2: {ReadCnt: 2, LikeCnt: 1},
+				3: {},
+			},
+		},
+		{
+			name: "缓存出错查数据库",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d := daomocks.NewMockInteractiveDao(ctrl)
+				c.EXPECT().GetByIds(gomock.Any(), "article", []int64{1}).
+					Return(nil, errors.New("mock redis 错误"))
+				d.EXPECT().GetByIds(gomock.Any(), "article", []int64{1}).
+					Return([]dao.Interactive{{BizId: 1, Biz: "article", ReadCnt: 1}}, nil)
+				c.EXPECT().BatchSet(gomock.Any(), "article",
+					map[int64]domain.Interactive{1: {ReadCnt: 1}}).Return(errors.New("mock redis 错误"))
+				return c, d
+			},
+			ids:       []int64{1},
+			wantIntrs: map[int64]domain.Interactive{1: {ReadCnt: 1}},
+		},
+		{
+			name: "数据库出错",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d := daomocks.NewMockInteractiveDao(ctrl)
+				c.EXPECT().GetByIds(gomock.Any(), "article", []int64{1}).
+					Return(map[int64]domain.Interactive{}, nil)
+				d.EXPECT().GetByIds(gomock.Any(), "article", []int64{1}).
+					Return(nil, errors.New("mock db 错误"))
+				return c, d
+			},
+			ids:     []int64{1},
+			wantErr: errors.New("mock db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c, d := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(d, logger.NewNopLogger(), c)
+			intrs, err := repo.GetByIds(context.Background(), "article", tc.ids)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantIntrs, intrs)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_Liked(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao)
+
+		wantLiked bool
+		wantErr   error
+	}{
+		{
+			name: "命中缓存",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(1), int64(123)).Return(true, nil)
+				return c, daomocks.NewMockInteractiveDao(ctrl)
+			},
+			wantLiked: true,
+		},
+		{
+			name: "没有命中，数据库里面没有点赞",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d := daomocks.NewMockInteractiveDao(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(1), int64(123)).
+					Return(false, cache.ErrKeyNotExist)
+				d.EXPECT().GetLikeInfo(gomock.Any(), "article", int64(1), int64(123)).
+					Return(dao.UserLikeBiz{}, dao.ErrRecordNotFound)
+				// 没有点赞也要缓存，不然每次都查数据库
+				c.EXPECT().FillLiked(gomock.Any(), "article", int64(1), int64(123), false).Return(nil)
+				return c, d
+			},
+		},
+		{
+			name: "缓存出错查数据库，回写失败也返回结果",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d := daomocks.NewMockInteractiveDao(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(1), int64(123)).
+					Return(false, errors.New("mock redis 错误"))
+				d.EXPECT().GetLikeInfo(gomock.Any(), "article", int64(1), int64(123)).
+					Return(dao.UserLikeBiz{Uid: 123, BizId: 1, Biz: "article", Status: 1}, nil)
+				c.EXPECT().FillLiked(gomock.Any(), "article", int64(1), int64(123), true).
+					Return(errors.New("mock redis 错误"))
+				return c, d
+			},
+			wantLiked: true,
+		},
+		{
+			name: "数据库出错",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d := daomocks.NewMockInteractiveDao(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(1), int64(123)).
+					Return(false, cache.ErrKeyNotExist)
+				d.EXPECT().GetLikeInfo(gomock.Any(), "article", int64(1), int64(123)).
+					Return(dao.UserLikeBiz{}, errors.New("mock db 错误"))
+				return c, d
+			},
+			wantErr: errors.New("mock db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c, d := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(d, logger.NewNopLogger(), c)
+			liked, err := repo.Liked(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLiked, liked)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_IncrLike(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao)
+
+		wantErr error
+	}{
+		{
+			name: "点赞成功",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d := daomocks.NewMockInteractiveDao(ctrl)
+				d.EXPECT().InsertLikeInfo(gomock.Any(), "article", int64(1), int64(123)).Return(nil)
+				c.EXPECT().SetLiked(gomock.Any(), "article", int64(1), int64(123), true).Return(nil)
+				c.EXPECT().IncrLikeCntIfPresent(gomock.Any(), "article", int64(1)).Return(nil)
+				return c, d
+			},
+		},
+		{
+			name: "数据库出错不更新缓存",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, dao.InteractiveDao) {
+				d := daomocks.NewMockInteractiveDao(ctrl)
+				d.EXPECT().InsertLikeInfo(gomock.Any(), "article", int64(1), int64(123)).
+					Return(errors.New("mock db 错误"))
+				return cachemocks.NewMockInteractiveCache(ctrl), d
+			},
+			wantErr: errors.New("mock db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c, d := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(d, logger.NewNopLogger(), c)
+			err := repo.IncrLike(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
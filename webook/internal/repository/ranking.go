@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/cache"
+	"geek-basic-go/webook/pkg/logger"
+)
+
+var ErrRankingNotFound = cache.ErrRankingNotFound
+
+type RankingRepository interface {
+	// ReplaceTopN 用新计算的热榜替换旧的
+	ReplaceTopN(ctx context.Context, arts []domain.HotArticle) error
+	GetTopN(ctx context.Context) ([]domain.HotArticle, error)
+}
+
+// CachedRankingRepository 热榜只放在缓存里面，先查本地缓存，再查 Redis，Redis 不可用的时候用过期的本地缓存兜底
+type CachedRankingRepository struct {
+	redis *cache.RankingRedisCache
+	local *cache.RankingLocalCache
+	l     logger.LoggerV1
+}
+
+func NewCachedRankingRepository(redis *cache.RankingRedisCache,
+	local *cache.RankingLocalCache,
+	l logger.LoggerV1) RankingRepository {
+	return &CachedRankingRepository{
+		redis: redis,
+		local: local,
+		l:     l,
+	}
+}
+
+func (r *CachedRankingRepository) ReplaceTopN(ctx context.Context, arts []domain.HotArticle) error {
+	// 本地缓存不会失败，先写本地缓存，计算热榜的实例至少自己能用上
+	_ = r.local.Set(ctx, arts)
+	return r.redis.Set(ctx, arts)
+}
+
+func (r *CachedRankingRepository) GetTopN(ctx context.Context) ([]domain.HotArticle, error) {
+	arts, err := r.local.Get(ctx)
+	if err == nil {
+		return arts, nil
+	}
+	arts, err = r.redis.Get(ctx)
+	if err == nil {
+		_ = r.local.Set(ctx, arts)
+		return arts, nil
+	}
+	if !errors.Is(err, cache.ErrRankingNotFound) {
+		r.l.Warn("从 Redis 读取热榜失败，使用本地缓存", logger.Error(err))
+	}
+	return r.local.ForceGet(ctx)
+}
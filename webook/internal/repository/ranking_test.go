@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository/cache"
+	"geek-basic-go/webook/internal/repository/cache/redismocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestCachedRankingRepository_GetTopN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	arts := []domain.HotArticle{{Article: domain.Article{Id: 1, Title: "Go 入门"}, Score: 1}}
+	val, err := json.Marshal(arts)
+	require.NoError(t, err)
+	client := redismocks.NewMockCmdable(ctrl)
+	local := cache.NewRankingLocalCache()
+	repo := NewCachedRankingRepository(cache.NewRankingRedisCache(client), local, logger.NewNopLogger())
+	ctx := context.Background()
+
+	// 本地没有，Redis 也没有
+	client.EXPECT().Get(gomock.Any(), "ranking:article").Return(redis.NewStringResult("", redis.Nil))
+	_, err = repo.GetTopN(ctx)
+	assert.Equal(t, ErrRankingNotFound, err)
+
+	// 别的实例计算好了放在 Redis 里面，读出来之后放到本地缓存
+	client.EXPECT().Get(gomock.Any(), "ranking:article").Return(redis.NewStringResult(string(val), nil))
+	res, err := repo.GetTopN(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, arts, res)
+	// 命中本地缓存，不查 Redis
+	res, err = repo.GetTopN(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, arts, res)
+}
+
+func TestCachedRankingRepository_GetTopN_Fallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	arts := []domain.HotArticle{{Article: domain.Article{Id: 1, Title: "Go 入门"}, Score: 1}}
+	client := redismocks.NewMockCmdable(ctrl)
+	client.EXPECT().Set(gomock.Any(), "ranking:article", gomock.Any(), gomock.Any()).
+		Return(redis.NewStatusResult("OK", nil))
+	client.EXPECT().Get(gomock.Any(), "ranking:article").
+		Return(redis.NewStringResult("", errors.New("网络错误")))
+	// 零值的本地缓存没有过期时间，写进去马上就过期了
+	repo := NewCachedRankingRepository(cache.NewRankingRedisCache(client), &cache.RankingLocalCache{}, logger.NewNopLogger())
+	ctx := context.Background()
+	require.NoError(t, repo.ReplaceTopN(ctx, arts))
+
+	// 本地缓存过期了，Redis 又不可用，用过期的本地缓存兜底
+	res, err := repo.GetTopN(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, arts, res)
+}
@@ -6,20 +6,91 @@ import (
 	"geek-basic-go/webook/internal/domain"
 	"geek-basic-go/webook/internal/events/article"
 	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/diffx"
 	"geek-basic-go/webook/pkg/logger"
+	"geek-basic-go/webook/pkg/markdown"
+	"geek-basic-go/webook/pkg/sensitive"
+	"github.com/ecodeclub/ekit/slice"
+	"math"
+	"strings"
+	"time"
 )
 
+var (
+	ErrArticleAccessDenied     = errors.New("无权操作该文章")
+	ErrArticleRevisionNotFound = errors.New("文章历史版本不存在")
+	ErrArticleScheduleNotFound = errors.New("定时发表任务不存在或者已经执行")
+	// ErrArticleVersionConflict 文章已经在别的地方修改过了，需要拿最新的版本合并之后再保存
+	ErrArticleVersionConflict = repository.ErrArticleVersionConflict
+	ErrArticleNotFound        = repository.ErrArticleNotFound
+	ErrArticleNotInTrash      = errors.New("文章不在回收站里面或者已经过了保留期限")
+	// ErrArticleInTrash 回收站里面的文章只能恢复，不能修改、发表
+	ErrArticleInTrash = errors.New("文章在回收站里面，要先恢复")
+	// ErrArticleRevisionTooLarge 历史版本太长，比较的开销太大
+	ErrArticleRevisionTooLarge = errors.New("文章历史版本太长，不能比较")
+)
+
+// articleDiffMaxLines 比较历史版本的时候两个版本加起来最多多少行。
+// diff 的内存是差异行数的平方，限制在这里最坏也就几十 MB
+const articleDiffMaxLines = 2000
+
+// articleScheduleTimeout 定时任务被抢占之后超过这个时间还没完成，就认为抢占的实例挂了，允许别的实例重新抢占
+const articleScheduleTimeout = time.Minute
+
+// ArticleTrashRetention 回收站里面的文章保留这么久，过期之后彻底删除
+const ArticleTrashRetention = 30 * 24 * time.Hour
+
+// articleBiz 文章在互动（阅读、点赞、收藏）里面的 biz
+const articleBiz = "article"
+
 type ArticleService interface {
 	Save(ctx context.Context, art domain.Article) (int64, error)
 	Publish(ctx context.Context, art domain.Article) (int64, error)
 	Withdraw(ctx context.Context, uid int64, id int64) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	GetByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, id int64) (domain.Article, error)
+	// GetDraft 看文章的草稿，作者和协作者都可以看
+	GetDraft(ctx context.Context, uid int64, id int64) (domain.Article, error)
 	GetPubById(ctx context.Context, id int64, uid int64) (domain.Article, error)
+	// GetReview 最近一次审核的结果，作者和协作者都可以看，没有审核过返回 ErrArticleReviewNotFound
+	GetReview(ctx context.Context, uid int64, id int64) (domain.ArticleReview, error)
+	// SearchPub 全文搜索已发表的文章
+	SearchPub(ctx context.Context, query string, offset int, limit int) ([]domain.Article, error)
+	// ListPubByTag 分页查找带有某个标签的已发表文章
+	ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]domain.Article, error)
+	// ListPubByAuthor 作者主页上面已发表的文章，按照更新时间倒序
+	ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	ListRevisions(ctx context.Context, uid int64, aid int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	DiffRevisions(ctx context.Context, uid int64, aid int64, from int64, to int64) (domain.ArticleRevisionDiff, error)
+	// RestoreRevision 把历史版本恢复成草稿，publish 为 true 的时候直接发表
+	RestoreRevision(ctx context.Context, uid int64, aid int64, revId int64, publish bool) (int64, error)
+	// SchedulePublish 保存草稿，并且在 publishAt 的时候发表
+	SchedulePublish(ctx context.Context, art domain.Article, publishAt time.Time) (int64, error)
+	ListSchedules(ctx context.Context, uid int64, offset int, limit int) ([]domain.ArticleSchedule, error)
+	CancelSchedule(ctx context.Context, uid int64, aid int64) error
+	// PublishDue 发表已经到点的文章，返回发表成功的数量，多个实例同时调用也只会发表一次
+	PublishDue(ctx context.Context, limit int) (int, error)
+	// Trash 移到回收站，ArticleTrashRetention 之内可以恢复
+	Trash(ctx context.Context, uid int64, id int64) error
+	ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// Restore 从回收站恢复成草稿，已经发表过的文章要重新发表
+	Restore(ctx context.Context, uid int64, id int64) error
+	// PurgeTrash 彻底删除回收站里面过期的文章，连同历史版本和互动数据，每批最多 batch 篇，返回删除的数量
+	PurgeTrash(ctx context.Context, batch int) (int, error)
+	// ReconcileReviews 补上等待审核但是没有审核记录的文章，每批扫描 batch 篇，返回补上的数量
+	ReconcileReviews(ctx context.Context, batch int) (int, error)
 }
 
 type ArticleServiceImpl struct {
-	repo     repository.ArticleRepository
+	repo         repository.ArticleRepository
+	revRepo      repository.ArticleRevisionRepository
+	scheduleRepo repository.ArticleScheduleRepository
+	intrRepo     repository.InteractiveRepository
+	collabRepo   repository.ArticleCollaboratorRepository
+	reviewRepo   repository.ArticleReviewRepository
+	seriesRepo   repository.ArticleSeriesRepository
+	// filter 发表的时候检查敏感词，命中的文章要先审核
+	filter   sensitive.Filter
 	producer article.Producer
 	// v1的写法
 	readerRepo repository.ArticleReaderRepository
@@ -30,6 +101,18 @@ type ArticleServiceImpl struct {
 
 func (a *ArticleServiceImpl) GetPubById(ctx context.Context, id int64, uid int64) (domain.Article, error) {
 	res, err := a.repo.GetPubById(ctx, id)
+	if err == nil && res.Status == domain.ArticleStatusDeleted {
+		// 在回收站里面的文章，对读者来说就是不存在
+		return domain.Article{}, ErrArticleNotFound
+	}
+	if err == nil && res.Status != domain.ArticleStatusPublished && res.Author.Id != uid {
+		// 撤回的文章仅作者自己可见，没有登录的读者 uid 是 0
+		return domain.Article{}, ErrArticleNotFound
+	}
+	if err == nil && res.Html == "" {
+		// 渲染功能上线之前发表的文章，读的时候再渲染
+		res = renderContent(res)
+	}
 	go func() {
 		if err == nil {
 			er := a.producer.ProduceReadEvent(article.ReadEvent{
@@ -47,16 +130,48 @@ func (a *ArticleServiceImpl) GetPubById(ctx context.Context, id int64, uid int64
 	return res, err
 }
 
+func (a *ArticleServiceImpl) SearchPub(ctx context.Context, query string, offset int, limit int) ([]domain.Article, error) {
+	return a.repo.SearchPub(ctx, query, offset, limit)
+}
+
+func (a *ArticleServiceImpl) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]domain.Article, error) {
+	return a.repo.ListPubByTag(ctx, tag, offset, limit)
+}
+
+func (a *ArticleServiceImpl) ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	return a.repo.ListPubByAuthor(ctx, uid, offset, limit)
+}
+
 func (a *ArticleServiceImpl) GetById(ctx context.Context, id int64) (domain.Article, error) {
 	return a.repo.GetById(ctx, id)
 }
 
-func (a *ArticleServiceImpl) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
-	return a.repo.GetByAuthor(ctx, uid, offset, limit)
+func (a *ArticleServiceImpl) GetDraft(ctx context.Context, uid int64, id int64) (domain.Article, error) {
+	return a.checkRole(ctx, uid, id, domain.ArticleRoleViewer)
+}
+
+func (a *ArticleServiceImpl) GetReview(ctx context.Context, uid int64, id int64) (domain.ArticleReview, error) {
+	_, err := a.checkRole(ctx, uid, id, domain.ArticleRoleViewer)
+	if err != nil {
+		return domain.ArticleReview{}, err
+	}
+	r, err := a.reviewRepo.Get(ctx, id)
+	if errors.Is(err, repository.ErrArticleReviewNotFound) {
+		return domain.ArticleReview{}, ErrArticleReviewNotFound
+	}
+	return r, err
+}
+
+func (a *ArticleServiceImpl) GetByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return a.repo.GetByAuthor(ctx, uid, cursor, limit)
 }
 
 func (a *ArticleServiceImpl) Withdraw(ctx context.Context, uid int64, id int64) error {
-	return a.repo.SyncStatus(ctx, uid, id, domain.ArticleStatusPrivate)
+	art, err := a.checkRole(ctx, uid, id, domain.ArticleRoleEditor)
+	if err != nil {
+		return err
+	}
+	return a.repo.SyncStatus(ctx, art.Author.Id, id, domain.ArticleStatusPrivate)
 }
 
 func NewArticleServiceV1(
@@ -71,22 +186,86 @@ func NewArticleServiceV1(
 }
 
 func NewArticleService(repo repository.ArticleRepository,
-	producer article.Producer) ArticleService {
+	revRepo repository.ArticleRevisionRepository,
+	scheduleRepo repository.ArticleScheduleRepository,
+	intrRepo repository.InteractiveRepository,
+	collabRepo repository.ArticleCollaboratorRepository,
+	reviewRepo repository.ArticleReviewRepository,
+	seriesRepo repository.ArticleSeriesRepository,
+	filter sensitive.Filter,
+	producer article.Producer,
+	l logger.LoggerV1) ArticleService {
 	return &ArticleServiceImpl{
-		repo:     repo,
-		producer: producer,
+		repo:         repo,
+		revRepo:      revRepo,
+		scheduleRepo: scheduleRepo,
+		intrRepo:     intrRepo,
+		collabRepo:   collabRepo,
+		reviewRepo:   reviewRepo,
+		seriesRepo:   seriesRepo,
+		filter:       filter,
+		producer:     producer,
+		l:            l,
 	}
 }
 
 func (a *ArticleServiceImpl) Publish(ctx context.Context, art domain.Article) (int64, error) {
+	editor := art.Author
+	art, err := a.editAsOwner(ctx, art)
+	if err != nil {
+		return 0, err
+	}
 	art.Status = domain.ArticleStatusPublished
+	// 命中敏感词的文章先进入审核队列，审核通过之后读者才能看到
+	hits := a.filter.Find(art.Title + "\n" + art.Content)
+	if len(hits) > 0 {
+		art.Status = domain.ArticleStatusPendingReview
+	}
+	art = renderContent(art)
 	id, err := a.repo.Sync(ctx, art)
 	if err != nil {
 		return 0, err
 	}
+	art.Id = id
+	a.recordRevision(ctx, art, editor)
+	a.submitReview(ctx, art, hits)
+	// 已经发表了，之前设置的定时发表没有意义了
+	err = a.scheduleRepo.Cancel(ctx, art.Author.Id, id)
+	if err != nil && !errors.Is(err, repository.ErrArticleScheduleNotFound) {
+		a.l.Error("取消定时发表失败",
+			logger.Int64("aid", id),
+			logger.Error(err))
+	}
 	return id, nil
 }
 
+// submitReview 命中了敏感词就进入审核队列。文章已经保存成等待审核了，这里失败也不能让作者重新发表，
+// 不然作者拿着旧的版本号重试只会冲突，所以只记录日志，由 ReconcileReviews 补上审核记录。
+// 没有命中的时候清掉上一次的审核结果，免得作者看到过时的拒绝原因
+func (a *ArticleServiceImpl) submitReview(ctx context.Context, art domain.Article, hits []string) {
+	var err error
+	if len(hits) > 0 {
+		err = a.reviewRepo.Submit(ctx, domain.ArticleReview{
+			ArticleId: art.Id,
+			Author:    art.Author,
+			Title:     art.Title,
+			Hits:      hits,
+		})
+		if err != nil {
+			a.l.Error("提交文章审核失败",
+				logger.Int64("aid", art.Id),
+				logger.Error(err))
+		}
+		return
+	}
+	err = a.reviewRepo.DeleteByArticle(ctx, art.Id)
+	if err != nil {
+		a.l.Error("删除文章的审核记录失败",
+			logger.Int64("aid", art.Id),
+			logger.Error(err))
+	}
+}
+
 func (a *ArticleServiceImpl) PublishV1(ctx context.Context, art domain.Article) (int64, error) {
 	// 先操作制作库，再操作线上库
 	var (
@@ -123,10 +302,464 @@ func (a *ArticleServiceImpl) PublishV1(ctx context.Context, art domain.Article)
 }
 
 func (a *ArticleServiceImpl) Save(ctx context.Context, art domain.Article) (int64, error) {
+	editor := art.Author
+	art, err := a.editAsOwner(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	// 作者重新保存了草稿，之前定时发表的内容已经不是作者确认过的了，要取消掉
+	scheduled := art.Status == domain.ArticleStatusScheduled
 	art.Status = domain.ArticleStatusUnpublished
+	id, err := a.save(ctx, art, editor)
+	if err != nil || !scheduled {
+		return id, err
+	}
+	err = a.scheduleRepo.Cancel(ctx, art.Author.Id, id)
+	if err != nil && !errors.Is(err, repository.ErrArticleScheduleNotFound) {
+		return 0, err
+	}
+	return id, nil
+}
+
+// save art 的作者已经是文章的作者了，editor 是实际修改的人
+func (a *ArticleServiceImpl) save(ctx context.Context, art domain.Article, editor domain.Author) (int64, error) {
+	// 草稿也渲染，作者列表里面的摘要和预览用的是渲染之后的结果
+	art = renderContent(art)
 	if art.Id > 0 {
 		err := a.repo.Update(ctx, art)
-		return art.Id, err
+		if err != nil {
+			return 0, err
+		}
+		a.recordRevision(ctx, art, editor)
+		return art.Id, nil
+	}
+	id, err := a.repo.Create(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	art.Id = id
+	a.recordRevision(ctx, art, editor)
+	return id, nil
+}
+
+// editAsOwner 修改已有的文章至少要是 editor，不管是谁改的，文章的作者都还是原来的作者。
+// 返回的 art.Status 是文章现在的状态，回收站里面的文章返回 ErrArticleInTrash
+func (a *ArticleServiceImpl) editAsOwner(ctx context.Context, art domain.Article) (domain.Article, error) {
+	if art.Id == 0 {
+		return art, nil
+	}
+	cur, err := a.checkRole(ctx, art.Author.Id, art.Id, domain.ArticleRoleEditor)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if cur.Status == domain.ArticleStatusDeleted {
+		// 不能绕过 Restore 把回收站里面的文章改回草稿，不然会逃过保留期限的清理
+		return domain.Article{}, ErrArticleInTrash
+	}
+	art.Author = cur.Author
+	art.Status = cur.Status
+	return art, nil
+}
+
+// recordRevision 文章已经保存成功了，历史版本记录失败不影响主流程，只记录日志。
+// 历史版本记录的是实际修改的人，可能是协作者
+func (a *ArticleServiceImpl) recordRevision(ctx context.Context, art domain.Article, editor domain.Author) {
+	_, err := a.revRepo.Create(ctx, domain.ArticleRevision{
+		ArticleId: art.Id,
+		Title:     art.Title,
+		Content:   art.Content,
+		Format:    art.Format,
+		Author:    editor,
+		Status:    art.Status,
+	})
+	if err != nil {
+		a.l.Error("记录文章历史版本失败",
+			logger.Int64("aid", art.Id),
+			logger.Error(err))
+	}
+}
+
+func (a *ArticleServiceImpl) ListRevisions(ctx context.Context, uid int64, aid int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	_, err := a.checkRole(ctx, uid, aid, domain.ArticleRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return a.revRepo.ListByArticle(ctx, aid, offset, limit)
+}
+
+func (a *ArticleServiceImpl) DiffRevisions(ctx context.Context, uid int64, aid int64, from int64, to int64) (domain.ArticleRevisionDiff, error) {
+	_, err := a.checkRole(ctx, uid, aid, domain.ArticleRoleViewer)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	fromRev, err := a.getRevision(ctx, aid, from)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	toRev, err := a.getRevision(ctx, aid, to)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	if countLines(fromRev.Content)+countLines(toRev.Content) > articleDiffMaxLines {
+		return domain.ArticleRevisionDiff{}, ErrArticleRevisionTooLarge
+	}
+	return domain.ArticleRevisionDiff{
+		From:    fromRev,
+		To:      toRev,
+		Title:   a.toDiffLines(diffx.Lines(fromRev.Title, toRev.Title)),
+		Content: a.toDiffLines(diffx.Lines(fromRev.Content, toRev.Content)),
+	}, nil
+}
+
+func (a *ArticleServiceImpl) RestoreRevision(ctx context.Context, uid int64, aid int64, revId int64, publish bool) (int64, error) {
+	cur, err := a.checkRole(ctx, uid, aid, domain.ArticleRoleEditor)
+	if err != nil {
+		return 0, err
+	}
+	rev, err := a.getRevision(ctx, aid, revId)
+	if err != nil {
+		return 0, err
+	}
+	// 复用 Save 和 Publish，这样缓存和线上库的处理都是一致的，恢复本身也会生成一个新的历史版本
+	art := domain.Article{
+		Id:      aid,
+		Title:   rev.Title,
+		Content: rev.Content,
+		Format:  rev.Format,
+		Author: domain.Author{
+			Id: uid,
+		},
+		// 恢复是在最新的草稿上面操作的
+		Version: cur.Version,
+	}
+	if publish {
+		return a.Publish(ctx, art)
+	}
+	return a.Save(ctx, art)
+}
+
+func (a *ArticleServiceImpl) checkRole(ctx context.Context, uid int64, aid int64, need domain.ArticleRole) (domain.Article, error) {
+	return checkArticleRole(ctx, a.repo, a.collabRepo, uid, aid, need)
+}
+
+func (a *ArticleServiceImpl) getRevision(ctx context.Context, aid int64, revId int64) (domain.ArticleRevision, error) {
+	rev, err := a.revRepo.GetById(ctx, revId)
+	if errors.Is(err, repository.ErrArticleRevisionNotFound) {
+		return domain.ArticleRevision{}, ErrArticleRevisionNotFound
+	}
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	if rev.ArticleId != aid {
+		return domain.ArticleRevision{}, ErrArticleRevisionNotFound
+	}
+	return rev, nil
+}
+
+func countLines(s string) int {
+	return strings.Count(s, "\n") + 1
+}
+
+func (a *ArticleServiceImpl) toDiffLines(lines []diffx.Line) []domain.DiffLine {
+	return slice.Map[diffx.Line, domain.DiffLine](lines, func(idx int, src diffx.Line) domain.DiffLine {
+		return domain.DiffLine{
+			Op:   src.Op.String(),
+			Text: src.Text,
+		}
+	})
+}
+
+func (a *ArticleServiceImpl) SchedulePublish(ctx context.Context, art domain.Article, publishAt time.Time) (int64, error) {
+	editor := art.Author
+	// 定时任务记在文章作者名下，协作者设置的也是
+	art, err := a.editAsOwner(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	art.Status = domain.ArticleStatusScheduled
+	id, err := a.save(ctx, art, editor)
+	if err != nil {
+		return 0, err
+	}
+	return id, a.scheduleRepo.Upsert(ctx, domain.ArticleSchedule{
+		ArticleId: id,
+		Author:    art.Author,
+		PublishAt: publishAt,
+	})
+}
+
+func (a *ArticleServiceImpl) ListSchedules(ctx context.Context, uid int64, offset int, limit int) ([]domain.ArticleSchedule, error) {
+	return a.scheduleRepo.ListWaiting(ctx, uid, offset, limit)
+}
+
+func (a *ArticleServiceImpl) CancelSchedule(ctx context.Context, uid int64, aid int64) error {
+	art, err := a.checkRole(ctx, uid, aid, domain.ArticleRoleEditor)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return ErrArticleScheduleNotFound
+	}
+	if err != nil {
+		return err
+	}
+	err = a.scheduleRepo.Cancel(ctx, art.Author.Id, aid)
+	if errors.Is(err, repository.ErrArticleScheduleNotFound) {
+		return ErrArticleScheduleNotFound
+	}
+	if err != nil {
+		return err
+	}
+	// 文章回到草稿状态
+	art.Status = domain.ArticleStatusUnpublished
+	return a.repo.Update(ctx, art)
+}
+
+func (a *ArticleServiceImpl) PublishDue(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	schedules, err := a.scheduleRepo.FindDue(ctx, now, articleScheduleTimeout, limit)
+	if err != nil {
+		return 0, err
+	}
+	cnt := 0
+	for _, s := range schedules {
+		// 先抢占，抢占成功的实例才发表，保证多个实例之下只发表一次
+		ok, er := a.scheduleRepo.Claim(ctx, s.Id, now, articleScheduleTimeout)
+		if er != nil {
+			a.l.Error("抢占定时发表任务失败",
+				logger.Int64("aid", s.ArticleId),
+				logger.Error(er))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		er = a.publishScheduled(ctx, s)
+		if er != nil {
+			a.l.Error("定时发表文章失败",
+				logger.Int64("aid", s.ArticleId),
+				logger.Error(er))
+			// 释放掉，下一轮重试
+			er = a.scheduleRepo.Release(ctx, s.Id)
+			if er != nil {
+				a.l.Error("释放定时发表任务失败",
+					logger.Int64("aid", s.ArticleId),
+					logger.Error(er))
+			}
+			continue
+		}
+		// 如果这里失败了，超时之后会被重新抢占再发表一次，Sync 本身是幂等的
+		er = a.scheduleRepo.Complete(ctx, s.Id)
+		if er != nil {
+			a.l.Error("完成定时发表任务失败",
+				logger.Int64("aid", s.ArticleId),
+				logger.Error(er))
+		}
+		cnt++
+	}
+	return cnt, nil
+}
+
+func (a *ArticleServiceImpl) publishScheduled(ctx context.Context, s domain.ArticleSchedule) error {
+	// 发表的是到点时候的最新草稿
+	art, err := a.repo.GetById(ctx, s.ArticleId)
+	if err != nil {
+		return err
+	}
+	if art.Author.Id != s.Author.Id {
+		return ErrArticleAccessDenied
+	}
+	_, err = a.Publish(ctx, domain.Article{
+		Id:      art.Id,
+		Title:   art.Title,
+		Content: art.Content,
+		Format:  art.Format,
+		Author:  art.Author,
+		Version: art.Version,
+	})
+	return err
+}
+
+func (a *ArticleServiceImpl) Trash(ctx context.Context, uid int64, id int64) error {
+	art, err := a.checkRole(ctx, uid, id, domain.ArticleRoleOwner)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return ErrArticleAccessDenied
+	}
+	if err != nil {
+		return err
+	}
+	// 协作者的 owner 删除的也是作者的文章
+	uid = art.Author.Id
+	err = a.repo.Trash(ctx, uid, id)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		// 已经在回收站里面
+		return ErrArticleAccessDenied
+	}
+	if err != nil {
+		return err
+	}
+	// 删掉的文章不能到点之后又被发表出去
+	err = a.scheduleRepo.Cancel(ctx, uid, id)
+	if err != nil && !errors.Is(err, repository.ErrArticleScheduleNotFound) {
+		a.l.Error("取消定时发表失败",
+			logger.Int64("aid", id),
+			logger.Error(err))
+	}
+	return nil
+}
+
+func (a *ArticleServiceImpl) ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	return a.repo.ListTrash(ctx, uid, offset, limit)
+}
+
+func (a *ArticleServiceImpl) Restore(ctx context.Context, uid int64, id int64) error {
+	art, err := a.checkRole(ctx, uid, id, domain.ArticleRoleOwner)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return ErrArticleNotInTrash
+	}
+	if err != nil {
+		return err
+	}
+	// 过期了但是还没来得及彻底删除的也不能恢复
+	if art.Status != domain.ArticleStatusDeleted ||
+		time.Since(art.Dtime) > ArticleTrashRetention {
+		return ErrArticleNotInTrash
+	}
+	err = a.repo.Restore(ctx, art.Author.Id, id)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return ErrArticleNotInTrash
+	}
+	return err
+}
+
+func (a *ArticleServiceImpl) PurgeTrash(ctx context.Context, batch int) (int, error) {
+	before := time.Now().Add(-ArticleTrashRetention)
+	cnt := 0
+	for {
+		arts, err := a.repo.ListTrashBefore(ctx, before, batch)
+		if err != nil {
+			return cnt, err
+		}
+		failed := false
+		for _, art := range arts {
+			er := a.purge(ctx, art)
+			if er != nil {
+				a.l.Error("彻底删除文章失败",
+					logger.Int64("aid", art.Id),
+					logger.Error(er))
+				failed = true
+				continue
+			}
+			cnt++
+		}
+		// 失败的文章还在回收站里面，下一批还会查出来，留到下一次任务再重试
+		if failed || len(arts) < batch {
+			return cnt, nil
+		}
+	}
+}
+
+func (a *ArticleServiceImpl) ReconcileReviews(ctx context.Context, batch int) (int, error) {
+	var (
+		id  int64
+		cnt int
+	)
+	for {
+		arts, err := a.repo.ScanPub(ctx, id, batch)
+		if err != nil {
+			return cnt, err
+		}
+		for _, art := range arts {
+			id = art.Id
+			if art.Status != domain.ArticleStatusPendingReview {
+				continue
+			}
+			_, err = a.reviewRepo.Get(ctx, art.Id)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, repository.ErrArticleReviewNotFound) {
+				return cnt, err
+			}
+			// Submit 是覆盖写，和发表的时候同时提交也没关系
+			err = a.reviewRepo.Submit(ctx, domain.ArticleReview{
+				ArticleId: art.Id,
+				Author:    art.Author,
+				Title:     art.Title,
+				Hits:      a.filter.Find(art.Title + "\n" + art.Content),
+			})
+			if err != nil {
+				return cnt, err
+			}
+			cnt++
+		}
+		if len(arts) < batch {
+			return cnt, nil
+		}
+	}
+}
+
+// purge 文章本身最后删，前面任何一步失败了，下一次任务还能扫描到这篇文章重试
+func (a *ArticleServiceImpl) purge(ctx context.Context, art domain.Article) error {
+	err := a.intrRepo.Delete(ctx, articleBiz, art.Id)
+	if err != nil {
+		return err
+	}
+	err = a.revRepo.DeleteByArticle(ctx, art.Id)
+	if err != nil {
+		return err
+	}
+	err = a.collabRepo.DeleteByArticle(ctx, art.Id)
+	if err != nil {
+		return err
+	}
+	err = a.reviewRepo.DeleteByArticle(ctx, art.Id)
+	if err != nil {
+		return err
+	}
+	err = a.seriesRepo.DeleteByArticle(ctx, art.Id)
+	if err != nil {
+		return err
+	}
+	return a.repo.Purge(ctx, art)
+}
+
+// abstractLen 摘要最多这么多个字符
+const abstractLen = 128
+
+// renderContent 按照格式把内容渲染成清理过的 HTML，摘要从渲染之后的纯文本里面截取，这样不会带上 markdown 的语法
+func renderContent(art domain.Article) domain.Article {
+	switch art.Format {
+	case domain.ArticleFormatMarkdown:
+		art.Html = markdown.ToHTML(art.Content)
+	default:
+		art.Html = markdown.PlainToHTML(art.Content)
+	}
+	text := []rune(markdown.Text(art.Html))
+	if len(text) > abstractLen {
+		text = text[:abstractLen]
+	}
+	art.Summary = string(text)
+	art.Stats = articleStats(art.Html)
+	return art
+}
+
+const (
+	// cjkCharsPerMinute 中文的阅读速度，一分钟多少个字
+	cjkCharsPerMinute = 300
+	// wordsPerMinute 英文的阅读速度，一分钟多少个单词
+	wordsPerMinute = 200
+	// secondsPerImage 看一张图片大概要多少秒
+	secondsPerImage = 12
+)
+
+// articleStats 中文和英文分开按照各自的速度算阅读时间，再加上看图片的时间，向上取整到分钟
+func articleStats(htmlStr string) domain.ArticleStats {
+	st := markdown.Analyze(htmlStr)
+	seconds := float64(st.CJKChars)*60/cjkCharsPerMinute +
+		float64(st.Words-st.CJKChars)*60/wordsPerMinute +
+		float64(st.Images*secondsPerImage)
+	return domain.ArticleStats{
+		WordCnt:      st.Words,
+		ReadMinutes:  int(math.Ceil(seconds / 60)),
+		ImageCnt:     st.Images,
+		CodeBlockCnt: st.CodeBlocks,
 	}
-	return a.repo.Create(ctx, art)
 }
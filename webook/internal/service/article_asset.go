@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/logger"
+	"mime"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrArticleAssetTooLarge    = errors.New("文件太大")
+	ErrArticleAssetTypeInvalid = errors.New("不支持的文件类型")
+	ErrArticleAssetNotFound    = errors.New("文件不存在")
+)
+
+// MaxArticleAssetSize 单个文件最大 5MB
+const MaxArticleAssetSize = 5 << 20
+
+// articleAssetExts 允许上传的类型，类型是根据内容判断的，不相信客户端给的文件名和 Content-Type
+// SVG 里面可以有脚本，不允许上传
+var articleAssetExts = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+type ArticleAssetService interface {
+	// Upload 上传文章的图片或者附件，至少是 editor 才可以上传
+	Upload(ctx context.Context, uid int64, aid int64, data []byte) (domain.ArticleAsset, error)
+	Get(ctx context.Context, key string) (domain.ArticleAsset, []byte, error)
+	// GC 删除 before 之前上传的、没有被文章任何一个历史版本引用的文件，返回删除的数量
+	GC(ctx context.Context, before time.Time, batch int) (int, error)
+}
+
+type ArticleAssetServiceImpl struct {
+	repo       repository.ArticleAssetRepository
+	artRepo    repository.ArticleRepository
+	collabRepo repository.ArticleCollaboratorRepository
+	l          logger.LoggerV1
+}
+
+func NewArticleAssetService(repo repository.ArticleAssetRepository,
+	artRepo repository.ArticleRepository,
+	collabRepo repository.ArticleCollaboratorRepository,
+	l logger.LoggerV1) ArticleAssetService {
+	return &ArticleAssetServiceImpl{
+		repo:       repo,
+		artRepo:    artRepo,
+		collabRepo: collabRepo,
+		l:          l,
+	}
+}
+
+func (s *ArticleAssetServiceImpl) Upload(ctx context.Context, uid int64, aid int64, data []byte) (domain.ArticleAsset, error) {
+	if len(data) > MaxArticleAssetSize {
+		return domain.ArticleAsset{}, ErrArticleAssetTooLarge
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	ext, ok := articleAssetExts[contentType]
+	if len(data) == 0 || !ok {
+		return domain.ArticleAsset{}, ErrArticleAssetTypeInvalid
+	}
+	_, err := checkArticleRole(ctx, s.artRepo, s.collabRepo, uid, aid, domain.ArticleRoleEditor)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return domain.ArticleAsset{}, ErrArticleAccessDenied
+	}
+	if err != nil {
+		return domain.ArticleAsset{}, err
+	}
+	// key 由内容决定，同一篇文章重复上传同一张图片得到的是同一个 URL
+	sum := sha256.Sum256(data)
+	return s.repo.Create(ctx, domain.ArticleAsset{
+		ArticleId: aid,
+		Author: domain.Author{
+			Id: uid,
+		},
+		Key:         fmt.Sprintf("%d/%x%s", aid, sum[:16], ext),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+	}, data)
+}
+
+func (s *ArticleAssetServiceImpl) Get(ctx context.Context, key string) (domain.ArticleAsset, []byte, error) {
+	asset, err := s.repo.GetByKey(ctx, key)
+	if errors.Is(err, repository.ErrArticleAssetNotFound) {
+		return domain.ArticleAsset{}, nil, ErrArticleAssetNotFound
+	}
+	if err != nil {
+		return domain.ArticleAsset{}, nil, err
+	}
+	data, err := s.repo.GetContent(ctx, key)
+	if errors.Is(err, repository.ErrArticleAssetNotFound) {
+		return domain.ArticleAsset{}, nil, ErrArticleAssetNotFound
+	}
+	return asset, data, err
+}
+
+func (s *ArticleAssetServiceImpl) GC(ctx context.Context, before time.Time, batch int) (int, error) {
+	var (
+		cnt     int
+		startId int64
+	)
+	for {
+		assets, err := s.repo.ListBefore(ctx, before, startId, batch)
+		if err != nil {
+			return cnt, err
+		}
+		for _, asset := range assets {
+			referenced, err := s.repo.IsReferenced(ctx, asset.ArticleId, asset.Key)
+			if err != nil {
+				return cnt, err
+			}
+			if referenced {
+				continue
+			}
+			err = s.repo.Delete(ctx, asset)
+			if err != nil {
+				// 单个文件删除失败，下一次回收的时候还会再试
+				s.l.Error("删除文章资源失败",
+					logger.Int64("id", asset.Id),
+					logger.String("key", asset.Key),
+					logger.Error(err))
+				continue
+			}
+			cnt++
+		}
+		if len(assets) < batch {
+			return cnt, nil
+		}
+		startId = assets[len(assets)-1].Id
+		if ctx.Err() != nil {
+			return cnt, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestArticleAssetServiceImpl_Upload(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n0000")
+	sum := sha256.Sum256(png)
+	pngKey := fmt.Sprintf("12/%x.png", sum[:16])
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleAssetRepository, repository.ArticleRepository, repository.ArticleCollaboratorRepository)
+		uid  int64
+		aid  int64
+		data []byte
+
+		wantAsset domain.ArticleAsset
+		wantErr   error
+	}{
+		{
+			name: "上传成功",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAssetRepository, repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(12)).
+					Return(domain.Article{Id: 12, Author: domain.Author{Id: 123}}, nil)
+				repo := repomocks.NewMockArticleAssetRepository(ctrl)
+				asset := domain.ArticleAsset{
+					ArticleId:   12,
+					Author:      domain.Author{Id: 123},
+					Key:         pngKey,
+					ContentType: "image/png",
+					Size:        int64(len(png)),
+				}
+				repo.EXPECT().Create(gomock.Any(), asset, png).Return(domain.ArticleAsset{
+					Id:          1,
+					ArticleId:   12,
+					Author:      domain.Author{Id: 123},
+					Key:         pngKey,
+					ContentType: "image/png",
+					Size:        int64(len(png)),
+				}, nil)
+				return repo, artRepo, repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			uid:  123,
+			aid:  12,
+			data: png,
+			wantAsset: domain.ArticleAsset{
+				Id:          1,
+				ArticleId:   12,
+				Author:      domain.Author{Id: 123},
+				Key:         pngKey,
+				ContentType: "image/png",
+				Size:        int64(len(png)),
+			},
+		},
+		{
+			name: "文件太大",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAssetRepository, repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				return repomocks.NewMockArticleAssetRepository(ctrl), repomocks.NewMockArticleRepository(ctrl),
+					repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			uid:     123,
+			aid:     12,
+			data:    append(png, make([]byte, MaxArticleAssetSize)...),
+			wantErr: ErrArticleAssetTooLarge,
+		},
+		{
+			name: "不支持的类型",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAssetRepository, repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				return repomocks.NewMockArticleAssetRepository(ctrl), repomocks.NewMockArticleRepository(ctrl),
+					repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			uid:     123,
+			aid:     12,
+			data:    []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+			wantErr: ErrArticleAssetTypeInvalid,
+		},
+		{
+			name: "不是作者也不是协作者",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAssetRepository, repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(12)).
+					Return(domain.Article{Id: 12, Author: domain.Author{Id: 456}}, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().Get(gomock.Any(), int64(12), int64(123)).
+					Return(domain.ArticleCollaborator{}, repository.ErrArticleCollaboratorNotFound)
+				return repomocks.NewMockArticleAssetRepository(ctrl), artRepo, collabRepo
+			},
+			uid:     123,
+			aid:     12,
+			data:    png,
+			wantErr: ErrArticleAccessDenied,
+		},
+		{
+			name: "viewer 不能上传",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAssetRepository, repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(12)).
+					Return(domain.Article{Id: 12, Author: domain.Author{Id: 456}}, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().Get(gomock.Any(), int64(12), int64(123)).
+					Return(domain.ArticleCollaborator{ArticleId: 12, Role: domain.ArticleRoleViewer}, nil)
+				return repomocks.NewMockArticleAssetRepository(ctrl), artRepo, collabRepo
+			},
+			uid:     123,
+			aid:     12,
+			data:    png,
+			wantErr: ErrArticleAccessDenied,
+		},
+		{
+			name: "文章不存在",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAssetRepository, repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(12)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				return repomocks.NewMockArticleAssetRepository(ctrl), artRepo, repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			uid:     123,
+			aid:     12,
+			data:    png,
+			wantErr: ErrArticleAccessDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo, collabRepo := tc.mock(ctrl)
+			svc := NewArticleAssetService(repo, artRepo, collabRepo, logger.NewNopLogger())
+			asset, err := svc.Upload(context.Background(), tc.uid, tc.aid, tc.data)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantAsset, asset)
+		})
+	}
+}
+
+func TestArticleAssetServiceImpl_GC(t *testing.T) {
+	before := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name  string
+		mock  func(ctrl *gomock.Controller) repository.ArticleAssetRepository
+		batch int
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "分批回收没有引用的资源",
+			mock: func(ctrl *gomock.Controller) repository.ArticleAssetRepository {
+				repo := repomocks.NewMockArticleAssetRepository(ctrl)
+				first := []domain.ArticleAsset{
+					{Id: 1, ArticleId: 11, Key: "11/a.png"},
+					{Id: 2, ArticleId: 11, Key: "11/b.png"},
+				}
+				repo.EXPECT().ListBefore(gomock.Any(), before, int64(0), 2).Return(first, nil)
+				repo.EXPECT().IsReferenced(gomock.Any(), int64(11), "11/a.png").Return(true, nil)
+				repo.EXPECT().IsReferenced(gomock.Any(), int64(11), "11/b.png").Return(false, nil)
+				repo.EXPECT().Delete(gomock.Any(), first[1]).Return(nil)
+				second := []domain.ArticleAsset{
+					{Id: 5, ArticleId: 12, Key: "12/c.png"},
+				}
+				repo.EXPECT().ListBefore(gomock.Any(), before, int64(2), 2).Return(second, nil)
+				repo.EXPECT().IsReferenced(gomock.Any(), int64(12), "12/c.png").Return(false, nil)
+				repo.EXPECT().Delete(gomock.Any(), second[0]).Return(nil)
+				return repo
+			},
+			batch:   2,
+			wantCnt: 2,
+		},
+		{
+			name: "删除失败继续处理下一个",
+			mock: func(ctrl *gomock.Controller) repository.ArticleAssetRepository {
+				repo := repomocks.NewMockArticleAssetRepository(ctrl)
+				assets := []domain.ArticleAsset{
+					{Id: 1, ArticleId: 11, Key: "11/a.png"},
+					{Id: 2, ArticleId: 11, Key: "11/b.png"},
+				}
+				repo.EXPECT().ListBefore(gomock.Any(), before, int64(0), 10).Return(assets, nil)
+				repo.EXPECT().IsReferenced(gomock.Any(), int64(11), gomock.Any()).Return(false, nil).Times(2)
+				repo.EXPECT().Delete(gomock.Any(), assets[0]).Return(errors.New("删除失败"))
+				repo.EXPECT().Delete(gomock.Any(), assets[1]).Return(nil)
+				return repo
+			},
+			batch:   10,
+			wantCnt: 1,
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) repository.ArticleAssetRepository {
+				repo := repomocks.NewMockArticleAssetRepository(ctrl)
+				repo.EXPECT().ListBefore(gomock.Any(), before, int64(0), 10).
+					Return(nil, errors.New("mock db error"))
+				return repo
+			},
+			batch:   10,
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleAssetService(tc.mock(ctrl), repomocks.NewMockArticleRepository(ctrl), nil, logger.NewNopLogger())
+			cnt, err := svc.GC(context.Background(), before, tc.batch)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
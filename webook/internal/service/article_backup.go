@@ -0,0 +1,401 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/frontmatter"
+	"geek-basic-go/webook/pkg/logger"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrArticleExportNotFound = errors.New("导出不存在")
+	ErrArticleExportNotReady = errors.New("导出还没有完成")
+	ErrArticleImportInvalid  = errors.New("导入的文件不是合法的 zip 文件")
+	ErrArticleImportTooLarge = errors.New("导入的文件太多或者太大")
+)
+
+const (
+	// ArticleImportMaxSize 上传的 zip 文件最大多少字节
+	ArticleImportMaxSize = 32 << 20
+	// ArticleImportMaxFiles zip 里面最多多少篇文章
+	ArticleImportMaxFiles = 1000
+	// ArticleImportMaxFileSize 一篇文章解压之后最大多少字节
+	ArticleImportMaxFileSize = 1 << 20
+)
+
+const (
+	// articleExportTimeout 导出被抢占之后超过这个时间没有更新进度，就认为抢占的实例挂了
+	articleExportTimeout = time.Minute
+	// articleExportBatch 每次查多少篇文章，每一批更新一次进度
+	articleExportBatch = 50
+	// articleExportListSize 只列出最近的几次导出
+	articleExportListSize = 10
+)
+
+// articleStatusNames 导出的 front matter 里面用名字，不用数字，方便人看
+var articleStatusNames = map[domain.ArticleStatus]string{
+	domain.ArticleStatusUnpublished:   "draft",
+	domain.ArticleStatusPublished:     "published",
+	domain.ArticleStatusPrivate:       "private",
+	domain.ArticleStatusScheduled:     "scheduled",
+	domain.ArticleStatusPendingReview: "pending_review",
+}
+
+var articleFormatNames = map[domain.ArticleFormat]string{
+	domain.ArticleFormatPlain:    "plain",
+	domain.ArticleFormatMarkdown: "markdown",
+}
+
+// ArticleBackupService 作者备份或者迁移自己的文章。
+// 导出的 zip 里面每篇文章一个目录：articles/<id>/index.md 是当前的内容，
+// articles/<id>/revisions/<revId>.md 是历史版本，都是带 YAML front matter 的 Markdown
+type ArticleBackupService interface {
+	// CreateExport 已经有还没完成的导出的时候，直接返回那一个
+	CreateExport(ctx context.Context, uid int64) (domain.ArticleExport, error)
+	// ListExports 最近的几次导出和进度
+	ListExports(ctx context.Context, uid int64) ([]domain.ArticleExport, error)
+	// Download 只能下载自己的、已经完成的导出
+	Download(ctx context.Context, uid int64, id int64) ([]byte, error)
+	// RunExports 执行等待中的导出，返回完成的数量，多个实例同时调用也只会执行一次
+	RunExports(ctx context.Context, limit int) (int, error)
+	// Import 把导出格式的 zip 导入成草稿，历史版本不导入。dryRun 的时候只检查，不创建草稿
+	Import(ctx context.Context, uid int64, data []byte, dryRun bool) (domain.ArticleImportReport, error)
+}
+
+type ArticleBackupServiceImpl struct {
+	repo    repository.ArticleExportRepository
+	artRepo repository.ArticleRepository
+	revRepo repository.ArticleRevisionRepository
+	// artSvc 导入的草稿和在编辑器里面保存的一样，要渲染、记录历史版本
+	artSvc ArticleService
+	l      logger.LoggerV1
+}
+
+func NewArticleBackupService(repo repository.ArticleExportRepository,
+	artRepo repository.ArticleRepository,
+	revRepo repository.ArticleRevisionRepository,
+	artSvc ArticleService,
+	l logger.LoggerV1) ArticleBackupService {
+	return &ArticleBackupServiceImpl{
+		repo:    repo,
+		artRepo: artRepo,
+		revRepo: revRepo,
+		artSvc:  artSvc,
+		l:       l,
+	}
+}
+
+func (s *ArticleBackupServiceImpl) CreateExport(ctx context.Context, uid int64) (domain.ArticleExport, error) {
+	e, err := s.repo.FindActive(ctx, uid)
+	if err == nil {
+		return e, nil
+	}
+	if !errors.Is(err, repository.ErrArticleExportNotFound) {
+		return domain.ArticleExport{}, err
+	}
+	// 并发创建的时候可能会有两个，只是多导出一次，不影响结果
+	id, err := s.repo.Create(ctx, uid)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	now := time.Now()
+	return domain.ArticleExport{
+		Id:     id,
+		Uid:    uid,
+		Status: domain.ArticleExportStatusPending,
+		Ctime:  now,
+		Utime:  now,
+	}, nil
+}
+
+func (s *ArticleBackupServiceImpl) ListExports(ctx context.Context, uid int64) ([]domain.ArticleExport, error) {
+	return s.repo.ListByUser(ctx, uid, articleExportListSize)
+}
+
+func (s *ArticleBackupServiceImpl) Download(ctx context.Context, uid int64, id int64) ([]byte, error) {
+	e, err := s.repo.GetById(ctx, id)
+	if errors.Is(err, repository.ErrArticleExportNotFound) {
+		return nil, ErrArticleExportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if e.Uid != uid {
+		// 别人的导出当成不存在
+		return nil, ErrArticleExportNotFound
+	}
+	if e.Status != domain.ArticleExportStatusDone {
+		return nil, ErrArticleExportNotReady
+	}
+	data, err := s.repo.GetFile(ctx, e)
+	if errors.Is(err, repository.ErrArticleExportNotFound) {
+		return nil, ErrArticleExportNotFound
+	}
+	return data, err
+}
+
+func (s *ArticleBackupServiceImpl) RunExports(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	es, err := s.repo.FindRunnable(ctx, now, articleExportTimeout, limit)
+	if err != nil {
+		return 0, err
+	}
+	cnt := 0
+	for _, e := range es {
+		// 和定时发表一样，先抢占，抢占成功的实例才导出
+		ok, er := s.repo.Claim(ctx, e.Id, now, articleExportTimeout)
+		if er != nil {
+			s.l.Error("抢占导出任务失败",
+				logger.Int64("id", e.Id),
+				logger.Error(er))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		er = s.export(ctx, e)
+		if er != nil {
+			s.l.Error("导出文章失败",
+				logger.Int64("id", e.Id),
+				logger.Int64("uid", e.Uid),
+				logger.Error(er))
+			// 失败了不自动重试，用户重新导出就可以
+			er = s.repo.Fail(ctx, e.Id, "系统错误，请重新导出")
+			if er != nil {
+				s.l.Error("标记导出失败失败",
+					logger.Int64("id", e.Id),
+					logger.Error(er))
+			}
+			continue
+		}
+		cnt++
+	}
+	return cnt, nil
+}
+
+// export 在内存里面打包，作者的文章一般不会太多
+func (s *ArticleBackupServiceImpl) export(ctx context.Context, e domain.ArticleExport) error {
+	total, err := s.artRepo.CountByAuthor(ctx, e.Uid)
+	if err != nil {
+		return err
+	}
+	err = s.repo.UpdateProgress(ctx, e.Id, int(total), 0)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	var cursor domain.ArticleCursor
+	done := 0
+	for {
+		// 导出的过程中修改的文章会排到前面去，可能漏掉，下一次导出就有了
+		arts, err := s.artRepo.ScanByAuthor(ctx, e.Uid, cursor, articleExportBatch)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			err = s.writeArticle(ctx, zw, art)
+			if err != nil {
+				return err
+			}
+		}
+		done += len(arts)
+		// 导出的过程中新建的文章也会导出，不能让进度超过 100%
+		err = s.repo.UpdateProgress(ctx, e.Id, max(int(total), done), done)
+		if err != nil {
+			return err
+		}
+		if len(arts) < articleExportBatch {
+			break
+		}
+		cursor = domain.CursorOf(arts[len(arts)-1])
+	}
+	err = zw.Close()
+	if err != nil {
+		return err
+	}
+	e.Key = fmt.Sprintf("exports/%d/%d.zip", e.Uid, e.Id)
+	return s.repo.Complete(ctx, e, buf.Bytes())
+}
+
+func (s *ArticleBackupServiceImpl) writeArticle(ctx context.Context, zw *zip.Writer, art domain.Article) error {
+	dir := fmt.Sprintf("articles/%d/", art.Id)
+	err := s.writeFile(zw, dir+"index.md", articleFrontMatter{
+		Title:  art.Title,
+		Status: articleStatusNames[art.Status],
+		Format: articleFormatNames[art.Format],
+		Tags:   art.Tags,
+		Ctime:  art.Ctime.Format(time.RFC3339),
+		Utime:  art.Utime.Format(time.RFC3339),
+	}, art.Content)
+	if err != nil {
+		return err
+	}
+	const batch = 100
+	for offset := 0; ; offset += batch {
+		revs, err := s.revRepo.ListByArticle(ctx, art.Id, offset, batch)
+		if err != nil {
+			return err
+		}
+		for _, rev := range revs {
+			err = s.writeFile(zw, fmt.Sprintf("%srevisions/%d.md", dir, rev.Id), articleFrontMatter{
+				Title:  rev.Title,
+				Status: articleStatusNames[rev.Status],
+				Format: articleFormatNames[rev.Format],
+				Ctime:  rev.Ctime.Format(time.RFC3339),
+			}, rev.Content)
+			if err != nil {
+				return err
+			}
+		}
+		if len(revs) < batch {
+			return nil
+		}
+	}
+}
+
+func (s *ArticleBackupServiceImpl) writeFile(zw *zip.Writer, name string, meta articleFrontMatter, body string) error {
+	data, err := frontmatter.Marshal(meta, body)
+	if err != nil {
+		return err
+	}
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (s *ArticleBackupServiceImpl) Import(ctx context.Context, uid int64, data []byte, dryRun bool) (domain.ArticleImportReport, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return domain.ArticleImportReport{}, ErrArticleImportInvalid
+	}
+	// 先数一遍，文件太多的时候一篇都不导入
+	files := make([]*zip.File, 0, len(zr.File))
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() ||
+			!strings.EqualFold(path.Ext(f.Name), ".md") ||
+			strings.Contains("/"+f.Name, "/revisions/") {
+			continue
+		}
+		files = append(files, f)
+	}
+	if len(files) > ArticleImportMaxFiles {
+		return domain.ArticleImportReport{}, ErrArticleImportTooLarge
+	}
+	report := domain.ArticleImportReport{
+		DryRun: dryRun,
+		Items:  make([]domain.ArticleImportItem, 0, len(files)),
+	}
+	for _, f := range files {
+		art, item := s.parseFile(f)
+		if item.Err == "" && !dryRun {
+			art.Author = domain.Author{Id: uid}
+			id, er := s.artSvc.Save(ctx, art)
+			if er != nil {
+				s.l.Error("导入文章失败",
+					logger.Int64("uid", uid),
+					logger.String("path", f.Name),
+					logger.Error(er))
+				item.Err = "保存失败"
+			}
+			item.Id = id
+		}
+		report.Items = append(report.Items, item)
+	}
+	return report, nil
+}
+
+// parseFile 文件本身有问题的时候记录在 item.Err 里面，不影响别的文件
+func (s *ArticleBackupServiceImpl) parseFile(f *zip.File) (domain.Article, domain.ArticleImportItem) {
+	item := domain.ArticleImportItem{Path: f.Name}
+	if f.UncompressedSize64 > ArticleImportMaxFileSize {
+		item.Err = "文件太大"
+		return domain.Article{}, item
+	}
+	rc, err := f.Open()
+	if err != nil {
+		item.Err = "文件损坏"
+		return domain.Article{}, item
+	}
+	defer rc.Close()
+	// 头部记录的大小是可以伪造的，读的时候还要限制
+	data, err := io.ReadAll(io.LimitReader(rc, ArticleImportMaxFileSize+1))
+	if err != nil {
+		item.Err = "文件损坏"
+		return domain.Article{}, item
+	}
+	if len(data) > ArticleImportMaxFileSize {
+		item.Err = "文件太大"
+		return domain.Article{}, item
+	}
+	if !utf8.Valid(data) {
+		item.Err = "不是 UTF-8 编码"
+		return domain.Article{}, item
+	}
+	var meta articleFrontMatter
+	body, err := frontmatter.Unmarshal(data, &meta)
+	if err != nil {
+		item.Err = "front matter 格式错误"
+		return domain.Article{}, item
+	}
+	item.Title = strings.TrimSpace(meta.Title)
+	if item.Title == "" {
+		base := path.Base(f.Name)
+		item.Title = strings.TrimSuffix(base, path.Ext(base))
+	}
+	// 导入的是 .md 文件，没写格式就当成 Markdown
+	format := domain.ArticleFormatMarkdown
+	if meta.Format != "" {
+		var ok bool
+		format, ok = parseArticleFormat(meta.Format)
+		if !ok {
+			item.Err = "不支持的格式 " + meta.Format
+			return domain.Article{}, item
+		}
+	}
+	item.Format = format
+	tags, ok := domain.NormalizeArticleTags(meta.Tags)
+	if !ok {
+		item.Err = "标签太多或者太长"
+		return domain.Article{}, item
+	}
+	item.Tags = tags
+	return domain.Article{
+		Title:   item.Title,
+		Content: body,
+		Format:  item.Format,
+		Tags:    tags,
+	}, item
+}
+
+func parseArticleFormat(name string) (domain.ArticleFormat, bool) {
+	for format, n := range articleFormatNames {
+		if strings.EqualFold(n, name) {
+			return format, true
+		}
+	}
+	return 0, false
+}
+
+// articleFrontMatter 导出的文件头，导入的时候只用 title、format 和 tags，状态和时间只是给人看的
+type articleFrontMatter struct {
+	Title  string   `yaml:"title"`
+	Status string   `yaml:"status,omitempty"`
+	Format string   `yaml:"format,omitempty"`
+	Tags   []string `yaml:"tags,omitempty"`
+	Ctime  string   `yaml:"ctime,omitempty"`
+	Utime  string   `yaml:"utime,omitempty"`
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	svcmocks "geek-basic-go/webook/internal/service/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArticleBackupServiceImpl_RunExports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctime := time.UnixMilli(1700000000000)
+	utime := time.UnixMilli(1700000100000)
+	repo := repomocks.NewMockArticleExportRepository(ctrl)
+	artRepo := repomocks.NewMockArticleRepository(ctrl)
+	revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+	repo.EXPECT().FindRunnable(gomock.Any(), gomock.Any(), articleExportTimeout, 10).
+		Return([]domain.ArticleExport{{Id: 1, Uid: 123}, {Id: 2, Uid: 456}, {Id: 3, Uid: 789}}, nil)
+	// 1 导出成功
+	repo.EXPECT().Claim(gomock.Any(), int64(1), gomock.Any(), articleExportTimeout).Return(true, nil)
+	artRepo.EXPECT().CountByAuthor(gomock.Any(), int64(123)).Return(int64(1), nil)
+	repo.EXPECT().UpdateProgress(gomock.Any(), int64(1), 1, 0).Return(nil)
+	artRepo.EXPECT().ScanByAuthor(gomock.Any(), int64(123), domain.ArticleCursor{}, articleExportBatch).
+		Return([]domain.Article{{
+			Id:      11,
+			Title:   "Go 入门",
+			Content: "# 第一章",
+			Format:  domain.ArticleFormatMarkdown,
+			Tags:    []string{"go"},
+			Status:  domain.ArticleStatusPublished,
+			Ctime:   ctime,
+			Utime:   utime,
+		}}, nil)
+	revRepo.EXPECT().ListByArticle(gomock.Any(), int64(11), 0, 100).
+		Return([]domain.ArticleRevision{{
+			Id:        101,
+			ArticleId: 11,
+			Title:     "Go",
+			Content:   "草稿",
+			Status:    domain.ArticleStatusUnpublished,
+			Ctime:     ctime,
+		}}, nil)
+	repo.EXPECT().UpdateProgress(gomock.Any(), int64(1), 1, 1).Return(nil)
+	var zipData []byte
+	repo.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, e domain.ArticleExport, data []byte) error {
+			assert.Equal(t, "exports/123/1.zip", e.Key)
+			zipData = data
+			return nil
+		})
+	// 2 被别的实例抢走了
+	repo.EXPECT().Claim(gomock.Any(), int64(2), gomock.Any(), articleExportTimeout).Return(false, nil)
+	// 3 导出失败
+	repo.EXPECT().Claim(gomock.Any(), int64(3), gomock.Any(), articleExportTimeout).Return(true, nil)
+	artRepo.EXPECT().CountByAuthor(gomock.Any(), int64(789)).Return(int64(0), errors.New("db 错误"))
+	repo.EXPECT().Fail(gomock.Any(), int64(3), gomock.Any()).Return(nil)
+
+	svc := NewArticleBackupService(repo, artRepo, revRepo, nil, logger.NewNopLogger())
+	cnt, err := svc.RunExports(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cnt)
+
+	files := readZip(t, zipData)
+	assert.Equal(t, `---
+title: Go 入门
+status: published
+format: markdown
+tags:
+    - go
+ctime: "`+ctime.Format(time.RFC3339)+`"
+utime: "`+utime.Format(time.RFC3339)+`"
+---
+
+# 第一章`, files["articles/11/index.md"])
+	assert.Equal(t, `---
+title: Go
+status: draft
+format: plain
+ctime: "`+ctime.Format(time.RFC3339)+`"
+---
+
+草稿`, files["articles/11/revisions/101.md"])
+}
+
+func TestArticleBackupServiceImpl_Import(t *testing.T) {
+	data := newZip(t, map[string]string{
+		"articles/11/index.md":            "---\ntitle: Go 入门\nstatus: published\nformat: markdown\ntags: [go, go, 入门]\n---\n\n# 第一章",
+		"articles/11/revisions/101.md":    "---\ntitle: 历史版本不导入\n---\n\n草稿",
+		"notes/没有标题.md":                   "只有正文",
+		"notes/坏掉的.md":                    "---\ntitle: [\n---\n\n内容",
+		"notes/未知格式.md":                   "---\ntitle: 未知格式\nformat: html\n---\n\n<p>内容</p>",
+		"notes/图片.png":                    "不是文章",
+		"articles/12/index.md":            "---\ntitle: 纯文本\nformat: plain\n---\n\n内容",
+		"articles/13/index.md":            "---\ntitle: 标签太多\ntags: [a, b, c, d, e, f]\n---\n\n内容",
+		"articles/14/index.md":            "---\ntitle: 太大\n---\n\n" + strings.Repeat("a", ArticleImportMaxFileSize),
+		"articles/15/revisions/README.md": "历史版本目录下面的都跳过",
+	})
+	wantItems := map[string]domain.ArticleImportItem{
+		"articles/11/index.md": {Path: "articles/11/index.md", Title: "Go 入门",
+			Format: domain.ArticleFormatMarkdown, Tags: []string{"go", "入门"}},
+		"notes/没有标题.md": {Path: "notes/没有标题.md", Title: "没有标题", Format: domain.ArticleFormatMarkdown},
+		"notes/坏掉的.md":  {Path: "notes/坏掉的.md", Err: "front matter 格式错误"},
+		"notes/未知格式.md": {Path: "notes/未知格式.md", Title: "未知格式", Err: "不支持的格式 html"},
+		"articles/12/index.md": {Path: "articles/12/index.md", Title: "纯文本",
+			Format: domain.ArticleFormatPlain},
+		"articles/13/index.md": {Path: "articles/13/index.md", Title: "标签太多",
+			Format: domain.ArticleFormatMarkdown, Err: "标签太多或者太长"},
+		"articles/14/index.md": {Path: "articles/14/index.md", Err: "文件太大"},
+	}
+
+	t.Run("试运行", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		// 试运行不会保存
+		artSvc := svcmocks.NewMockArticleService(ctrl)
+		svc := NewArticleBackupService(nil, nil, nil, artSvc, logger.NewNopLogger())
+		report, err := svc.Import(context.Background(), 123, data, true)
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, wantItems, itemsByPath(report))
+	})
+
+	t.Run("导入", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		artSvc := svcmocks.NewMockArticleService(ctrl)
+		artSvc.EXPECT().Save(gomock.Any(), domain.Article{
+			Title:   "Go 入门",
+			Content: "# 第一章",
+			Format:  domain.ArticleFormatMarkdown,
+			Tags:    []string{"go", "入门"},
+			Author:  domain.Author{Id: 123},
+		}).Return(int64(21), nil)
+		artSvc.EXPECT().Save(gomock.Any(), domain.Article{
+			Title:   "没有标题",
+			Content: "只有正文",
+			Format:  domain.ArticleFormatMarkdown,
+			Author:  domain.Author{Id: 123},
+		}).Return(int64(22), nil)
+		artSvc.EXPECT().Save(gomock.Any(), domain.Article{
+			Title:   "纯文本",
+			Content: "内容",
+			Format:  domain.ArticleFormatPlain,
+			Author:  domain.Author{Id: 123},
+		}).Return(int64(0), errors.New("db 错误"))
+		svc := NewArticleBackupService(nil, nil, nil, artSvc, logger.NewNopLogger())
+		report, err := svc.Import(context.Background(), 123, data, false)
+		require.NoError(t, err)
+		assert.False(t, report.DryRun)
+		items := itemsByPath(report)
+		assert.Equal(t, int64(21), items["articles/11/index.md"].Id)
+		assert.Equal(t, int64(22), items["notes/没有标题.md"].Id)
+		assert.Equal(t, "保存失败", items["articles/12/index.md"].Err)
+		assert.Equal(t, int64(0), items["notes/坏掉的.md"].Id)
+	})
+
+	t.Run("不是 zip", func(t *testing.T) {
+		svc := NewArticleBackupService(nil, nil, nil, nil, logger.NewNopLogger())
+		_, err := svc.Import(context.Background(), 123, []byte("abc"), true)
+		assert.Equal(t, ErrArticleImportInvalid, err)
+	})
+
+	t.Run("文件太多", func(t *testing.T) {
+		files := make(map[string]string, ArticleImportMaxFiles+1)
+		for i := 0; i <= ArticleImportMaxFiles; i++ {
+			files[fmt.Sprintf("notes/%d.md", i)] = "内容"
+		}
+		svc := NewArticleBackupService(nil, nil, nil, nil, logger.NewNopLogger())
+		_, err := svc.Import(context.Background(), 123, newZip(t, files), true)
+		assert.Equal(t, ErrArticleImportTooLarge, err)
+	})
+}
+
+func TestArticleBackupServiceImpl_Download(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.ArticleExportRepository
+		uid  int64
+
+		wantData []byte
+		wantErr  error
+	}{
+		{
+			name: "下载",
+			mock: func(ctrl *gomock.Controller) repository.ArticleExportRepository {
+				repo := repomocks.NewMockArticleExportRepository(ctrl)
+				e := domain.ArticleExport{Id: 1, Uid: 123, Status: domain.ArticleExportStatusDone, Key: "exports/123/1.zip"}
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(e, nil)
+				repo.EXPECT().GetFile(gomock.Any(), e).Return([]byte("zip"), nil)
+				return repo
+			},
+			uid:      123,
+			wantData: []byte("zip"),
+		},
+		{
+			name: "别人的导出",
+			mock: func(ctrl *gomock.Controller) repository.ArticleExportRepository {
+				repo := repomocks.NewMockArticleExportRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.ArticleExport{Id: 1, Uid: 456, Status: domain.ArticleExportStatusDone}, nil)
+				return repo
+			},
+			uid:     123,
+			wantErr: ErrArticleExportNotFound,
+		},
+		{
+			name: "还没有完成",
+			mock: func(ctrl *gomock.Controller) repository.ArticleExportRepository {
+				repo := repomocks.NewMockArticleExportRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.ArticleExport{Id: 1, Uid: 123, Status: domain.ArticleExportStatusRunning}, nil)
+				return repo
+			},
+			uid:     123,
+			wantErr: ErrArticleExportNotReady,
+		},
+		{
+			name: "不存在",
+			mock: func(ctrl *gomock.Controller) repository.ArticleExportRepository {
+				repo := repomocks.NewMockArticleExportRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.ArticleExport{}, repository.ErrArticleExportNotFound)
+				return repo
+			},
+			uid:     123,
+			wantErr: ErrArticleExportNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleBackupService(tc.mock(ctrl), nil, nil, nil, logger.NewNopLogger())
+			data, err := svc.Download(context.Background(), tc.uid, 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantData, data)
+		})
+	}
+}
+
+func TestArticleBackupServiceImpl_CreateExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockArticleExportRepository(ctrl)
+	// 还有没完成的导出，直接返回
+	active := domain.ArticleExport{Id: 1, Uid: 123, Status: domain.ArticleExportStatusRunning, Total: 10, Done: 3}
+	repo.EXPECT().FindActive(gomock.Any(), int64(123)).Return(active, nil)
+	repo.EXPECT().FindActive(gomock.Any(), int64(456)).
+		Return(domain.ArticleExport{}, repository.ErrArticleExportNotFound)
+	repo.EXPECT().Create(gomock.Any(), int64(456)).Return(int64(2), nil)
+
+	svc := NewArticleBackupService(repo, nil, nil, nil, logger.NewNopLogger())
+	e, err := svc.CreateExport(context.Background(), 123)
+	require.NoError(t, err)
+	assert.Equal(t, active, e)
+	e, err = svc.CreateExport(context.Background(), 456)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), e.Id)
+	assert.Equal(t, domain.ArticleExportStatusPending, e.Status)
+}
+
+func newZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func readZip(t *testing.T, data []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	res := make(map[string]string, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		res[f.Name] = buf.String()
+	}
+	return res
+}
+
+func itemsByPath(report domain.ArticleImportReport) map[string]domain.ArticleImportItem {
+	res := make(map[string]domain.ArticleImportItem, len(report.Items))
+	for _, item := range report.Items {
+		res[item.Path] = item
+	}
+	return res
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/logger"
+)
+
+var (
+	ErrArticleCollaboratorInvalid  = errors.New("用户不存在或者是文章的作者")
+	ErrArticleCollaboratorNotFound = errors.New("不是文章的协作者")
+)
+
+type ArticleCollaboratorService interface {
+	// Invite 只有 owner 可以邀请，已经是协作者的时候修改角色
+	Invite(ctx context.Context, uid int64, c domain.ArticleCollaborator) error
+	// Remove owner 可以移除任何协作者，协作者也可以自己退出
+	Remove(ctx context.Context, uid int64, aid int64, collaborator int64) error
+	// List 至少是 viewer 才能看，文章的作者排在第一个
+	List(ctx context.Context, uid int64, aid int64) ([]domain.ArticleCollaborator, error)
+	// ListAuthors 已发表的文章展示的作者，除了 art 的作者，editor 和 owner 也算
+	ListAuthors(ctx context.Context, art domain.Article) ([]domain.Author, error)
+}
+
+type ArticleCollaboratorServiceImpl struct {
+	repo     repository.ArticleCollaboratorRepository
+	artRepo  repository.ArticleRepository
+	userRepo repository.UserRepository
+	l        logger.LoggerV1
+}
+
+func NewArticleCollaboratorService(repo repository.ArticleCollaboratorRepository,
+	artRepo repository.ArticleRepository,
+	userRepo repository.UserRepository,
+	l logger.LoggerV1) ArticleCollaboratorService {
+	return &ArticleCollaboratorServiceImpl{
+		repo:     repo,
+		artRepo:  artRepo,
+		userRepo: userRepo,
+		l:        l,
+	}
+}
+
+func (s *ArticleCollaboratorServiceImpl) Invite(ctx context.Context, uid int64, c domain.ArticleCollaborator) error {
+	art, err := s.checkRole(ctx, uid, c.ArticleId, domain.ArticleRoleOwner)
+	if err != nil {
+		return err
+	}
+	if c.User.Id == art.Author.Id {
+		return ErrArticleCollaboratorInvalid
+	}
+	_, err = s.userRepo.FindById(ctx, c.User.Id)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ErrArticleCollaboratorInvalid
+	}
+	if err != nil {
+		return err
+	}
+	return s.repo.Upsert(ctx, c)
+}
+
+func (s *ArticleCollaboratorServiceImpl) Remove(ctx context.Context, uid int64, aid int64, collaborator int64) error {
+	// 自己退出不需要是 owner
+	if uid != collaborator {
+		_, err := s.checkRole(ctx, uid, aid, domain.ArticleRoleOwner)
+		if err != nil {
+			return err
+		}
+	}
+	err := s.repo.Delete(ctx, aid, collaborator)
+	if errors.Is(err, repository.ErrArticleCollaboratorNotFound) {
+		return ErrArticleCollaboratorNotFound
+	}
+	return err
+}
+
+func (s *ArticleCollaboratorServiceImpl) List(ctx context.Context, uid int64, aid int64) ([]domain.ArticleCollaborator, error) {
+	art, err := s.checkRole(ctx, uid, aid, domain.ArticleRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := s.repo.ListByArticle(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ArticleCollaborator, 0, len(cs)+1)
+	res = append(res, domain.ArticleCollaborator{
+		ArticleId: aid,
+		User:      art.Author,
+		Role:      domain.ArticleRoleOwner,
+		Ctime:     art.Ctime,
+		Utime:     art.Ctime,
+	})
+	res = append(res, cs...)
+	for i := range res {
+		res[i].User.Name = s.userName(ctx, res[i].User.Id)
+	}
+	return res, nil
+}
+
+func (s *ArticleCollaboratorServiceImpl) ListAuthors(ctx context.Context, art domain.Article) ([]domain.Author, error) {
+	cs, err := s.repo.ListByArticle(ctx, art.Id)
+	if err != nil {
+		return nil, err
+	}
+	res := []domain.Author{art.Author}
+	for _, c := range cs {
+		if !c.Role.Has(domain.ArticleRoleEditor) {
+			continue
+		}
+		res = append(res, domain.Author{
+			Id:   c.User.Id,
+			Name: s.userName(ctx, c.User.Id),
+		})
+	}
+	return res, nil
+}
+
+// userName 查不到名字只是展示不了，不影响协作
+func (s *ArticleCollaboratorServiceImpl) userName(ctx context.Context, uid int64) string {
+	u, err := s.userRepo.FindById(ctx, uid)
+	if err != nil {
+		s.l.Warn("查询协作者的名字失败",
+			logger.Int64("uid", uid),
+			logger.Error(err))
+		return ""
+	}
+	return u.NickName
+}
+
+func (s *ArticleCollaboratorServiceImpl) checkRole(ctx context.Context, uid int64, aid int64, need domain.ArticleRole) (domain.Article, error) {
+	return checkArticleRole(ctx, s.artRepo, s.repo, uid, aid, need)
+}
+
+// checkArticleRole uid 在文章上至少要有 need 这个角色，返回文章最新的草稿。
+// 文章的作者就是 owner，不在协作者里面
+func checkArticleRole(ctx context.Context,
+	artRepo repository.ArticleRepository,
+	collabRepo repository.ArticleCollaboratorRepository,
+	uid int64, aid int64, need domain.ArticleRole) (domain.Article, error) {
+	art, err := artRepo.GetById(ctx, aid)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if art.Author.Id == uid {
+		return art, nil
+	}
+	c, err := collabRepo.Get(ctx, aid, uid)
+	if errors.Is(err, repository.ErrArticleCollaboratorNotFound) {
+		return domain.Article{}, ErrArticleAccessDenied
+	}
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if !c.Role.Has(need) {
+		return domain.Article{}, ErrArticleAccessDenied
+	}
+	return art, nil
+}
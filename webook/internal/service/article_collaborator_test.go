@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestArticleCollaboratorServiceImpl_Invite(t *testing.T) {
+	editor := domain.ArticleCollaborator{
+		ArticleId: 11,
+		User:      domain.Author{Id: 456},
+		Role:      domain.ArticleRoleEditor,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository, repository.UserRepository)
+		uid  int64
+		c    domain.ArticleCollaborator
+
+		wantErr error
+	}{
+		{
+			name: "作者邀请",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository, repository.UserRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Article{Id: 11, Author: domain.Author{Id: 123}}, nil)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(456)).Return(domain.User{Id: 456}, nil)
+				repo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().Upsert(gomock.Any(), editor).Return(nil)
+				return repo, artRepo, userRepo
+			},
+			uid: 123,
+			c:   editor,
+		},
+		{
+			name: "协作者里面的 owner 也可以邀请",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository, repository.UserRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Article{Id: 11, Author: domain.Author{Id: 123}}, nil)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(456)).Return(domain.User{Id: 456}, nil)
+				repo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), int64(11), int64(789)).Return(domain.ArticleCollaborator{
+					ArticleId: 11,
+					User:      domain.Author{Id: 789},
+					Role:      domain.ArticleRoleOwner,
+				}, nil)
+				repo.EXPECT().Upsert(gomock.Any(), editor).Return(nil)
+				return repo, artRepo, userRepo
+			},
+			uid: 789,
+			c:   editor,
+		},
+		{
+			name: "editor 不能邀请",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository, repository.UserRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Article{Id: 11, Author: domain.Author{Id: 123}}, nil)
+				repo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), int64(11), int64(789)).Return(domain.ArticleCollaborator{
+					ArticleId: 11,
+					User:      domain.Author{Id: 789},
+					Role:      domain.ArticleRoleEditor,
+				}, nil)
+				return repo, artRepo, repomocks.NewMockUserRepository(ctrl)
+			},
+			uid:     789,
+			c:       editor,
+			wantErr: ErrArticleAccessDenied,
+		},
+		{
+			name: "不能邀请作者自己",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository, repository.UserRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Article{Id: 11, Author: domain.Author{Id: 123}}, nil)
+				return repomocks.NewMockArticleCollaboratorRepository(ctrl), artRepo, repomocks.NewMockUserRepository(ctrl)
+			},
+			uid: 123,
+			c: domain.ArticleCollaborator{
+				ArticleId: 11,
+				User:      domain.Author{Id: 123},
+				Role:      domain.ArticleRoleViewer,
+			},
+			wantErr: ErrArticleCollaboratorInvalid,
+		},
+		{
+			name: "用户不存在",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository, repository.UserRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Article{Id: 11, Author: domain.Author{Id: 123}}, nil)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(456)).Return(domain.User{}, repository.ErrUserNotFound)
+				return repomocks.NewMockArticleCollaboratorRepository(ctrl), artRepo, userRepo
+			},
+			uid:     123,
+			c:       editor,
+			wantErr: ErrArticleCollaboratorInvalid,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo, userRepo := tc.mock(ctrl)
+			svc := NewArticleCollaboratorService(repo, artRepo, userRepo, logger.NewNopLogger())
+			err := svc.Invite(context.Background(), tc.uid, tc.c)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestArticleCollaboratorServiceImpl_Remove(t *testing.T) {
+	testCases := []struct {
+		name         string
+		mock         func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository)
+		uid          int64
+		collaborator int64
+
+		wantErr error
+	}{
+		{
+			name: "作者移除协作者",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Article{Id: 11, Author: domain.Author{Id: 123}}, nil)
+				repo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().Delete(gomock.Any(), int64(11), int64(456)).Return(nil)
+				return repo, artRepo
+			},
+			uid:          123,
+			collaborator: 456,
+		},
+		{
+			name: "协作者自己退出",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().Delete(gomock.Any(), int64(11), int64(456)).Return(nil)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			uid:          456,
+			collaborator: 456,
+		},
+		{
+			name: "不是协作者",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().Delete(gomock.Any(), int64(11), int64(456)).Return(repository.ErrArticleCollaboratorNotFound)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			uid:          456,
+			collaborator: 456,
+			wantErr:      ErrArticleCollaboratorNotFound,
+		},
+		{
+			name: "viewer 不能移除别人",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleCollaboratorRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Article{Id: 11, Author: domain.Author{Id: 123}}, nil)
+				repo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), int64(11), int64(789)).Return(domain.ArticleCollaborator{
+					ArticleId: 11,
+					User:      domain.Author{Id: 789},
+					Role:      domain.ArticleRoleViewer,
+				}, nil)
+				return repo, artRepo
+			},
+			uid:          789,
+			collaborator: 456,
+			wantErr:      ErrArticleAccessDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewArticleCollaboratorService(repo, artRepo, repomocks.NewMockUserRepository(ctrl), logger.NewNopLogger())
+			err := svc.Remove(context.Background(), tc.uid, 11, tc.collaborator)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
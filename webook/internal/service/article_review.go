@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/logger"
+	"strings"
+	"unicode/utf8"
+)
+
+// ArticleReviewReasonMaxLength 拒绝原因最多多少个字符
+const ArticleReviewReasonMaxLength = 256
+
+var (
+	ErrArticleReviewNotFound = errors.New("审核不存在或者已经审核过了")
+	ErrArticleReviewInvalid  = errors.New("拒绝原因不能为空，也不能太长")
+)
+
+// ArticleReviewService 管理员审核命中敏感词的文章，调用方要保证是管理员
+type ArticleReviewService interface {
+	// ListPending 审核队列，先提交的排在前面
+	ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error)
+	// Approve 审核通过之后文章就发表了
+	Approve(ctx context.Context, reviewer int64, aid int64) error
+	// Reject 拒绝之后文章变回草稿，作者修改之后可以重新发表
+	Reject(ctx context.Context, reviewer int64, aid int64, reason string) error
+}
+
+type ArticleReviewServiceImpl struct {
+	repo    repository.ArticleReviewRepository
+	artRepo repository.ArticleRepository
+	l       logger.LoggerV1
+}
+
+func NewArticleReviewService(repo repository.ArticleReviewRepository,
+	artRepo repository.ArticleRepository,
+	l logger.LoggerV1) ArticleReviewService {
+	return &ArticleReviewServiceImpl{
+		repo:    repo,
+		artRepo: artRepo,
+		l:       l,
+	}
+}
+
+func (s *ArticleReviewServiceImpl) ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error) {
+	return s.repo.ListPending(ctx, offset, limit)
+}
+
+func (s *ArticleReviewServiceImpl) Approve(ctx context.Context, reviewer int64, aid int64) error {
+	return s.review(ctx, reviewer, aid, domain.ArticleReviewStatusApproved, "", domain.ArticleStatusPublished)
+}
+
+func (s *ArticleReviewServiceImpl) Reject(ctx context.Context, reviewer int64, aid int64, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > ArticleReviewReasonMaxLength {
+		return ErrArticleReviewInvalid
+	}
+	return s.review(ctx, reviewer, aid, domain.ArticleReviewStatusRejected, reason, domain.ArticleStatusUnpublished)
+}
+
+// review 先抢占审核记录，这样两个管理员同时审核只有一个能成功，然后再修改文章的状态
+func (s *ArticleReviewServiceImpl) review(ctx context.Context, reviewer int64, aid int64,
+	status domain.ArticleReviewStatus, reason string, artStatus domain.ArticleStatus) error {
+	r, err := s.repo.Get(ctx, aid)
+	if errors.Is(err, repository.ErrArticleReviewNotFound) {
+		return ErrArticleReviewNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if r.Status != domain.ArticleReviewStatusPending {
+		return ErrArticleReviewNotFound
+	}
+	art, err := s.artRepo.GetPubById(ctx, aid)
+	if err != nil && !errors.Is(err, repository.ErrArticleNotFound) {
+		return err
+	}
+	if err != nil || art.Status != domain.ArticleStatusPendingReview {
+		// 作者已经撤回或者删除了，不需要再审核
+		er := s.repo.DeleteByArticle(ctx, aid)
+		if er != nil {
+			s.l.Error("删除过时的审核记录失败",
+				logger.Int64("aid", aid),
+				logger.Error(er))
+		}
+		return ErrArticleReviewNotFound
+	}
+	err = s.repo.Review(ctx, domain.ArticleReview{
+		ArticleId: aid,
+		Status:    status,
+		Reason:    reason,
+		Reviewer:  reviewer,
+	})
+	if errors.Is(err, repository.ErrArticleReviewNotFound) {
+		return ErrArticleReviewNotFound
+	}
+	if err != nil {
+		return err
+	}
+	err = s.artRepo.SyncStatus(ctx, r.Author.Id, aid, artStatus)
+	if err != nil {
+		// 文章的状态没改成功，放回审核队列，不然文章会一直卡在等待审核
+		er := s.repo.Submit(ctx, r)
+		if er != nil {
+			s.l.Error("审核失败之后放回审核队列失败",
+				logger.Int64("aid", aid),
+				logger.Error(er))
+		}
+		return err
+	}
+	return nil
+}
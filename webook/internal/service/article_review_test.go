@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestArticleReviewServiceImpl_Review(t *testing.T) {
+	pending := domain.ArticleReview{
+		ArticleId: 11,
+		Author:    domain.Author{Id: 123},
+		Status:    domain.ArticleReviewStatusPending,
+		Hits:      []string{"代开发票"},
+	}
+	pendingArt := domain.Article{Id: 11, Author: domain.Author{Id: 123}, Status: domain.ArticleStatusPendingReview}
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) (repository.ArticleReviewRepository, repository.ArticleRepository)
+		reject bool
+		reason string
+
+		wantErr error
+	}{
+		{
+			name: "通过",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), int64(11)).Return(pending, nil)
+				repo.EXPECT().Review(gomock.Any(), domain.ArticleReview{
+					ArticleId: 11,
+					Status:    domain.ArticleReviewStatusApproved,
+					Reviewer:  1,
+				}).Return(nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(pendingArt, nil)
+				artRepo.EXPECT().SyncStatus(gomock.Any(), int64(123), int64(11), domain.ArticleStatus(domain.ArticleStatusPublished)).Return(nil)
+				return repo, artRepo
+			},
+		},
+		{
+			name: "拒绝",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), int64(11)).Return(pending, nil)
+				repo.EXPECT().Review(gomock.Any(), domain.ArticleReview{
+					ArticleId: 11,
+					Status:    domain.ArticleReviewStatusRejected,
+					Reason:    "包含广告",
+					Reviewer:  1,
+				}).Return(nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(pendingArt, nil)
+				artRepo.EXPECT().SyncStatus(gomock.Any(), int64(123), int64(11), domain.ArticleStatus(domain.ArticleStatusUnpublished)).Return(nil)
+				return repo, artRepo
+			},
+			reject: true,
+			reason: " 包含广告 ",
+		},
+		{
+			name: "拒绝没有写原因",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository, repository.ArticleRepository) {
+				return repomocks.NewMockArticleReviewRepository(ctrl), repomocks.NewMockArticleRepository(ctrl)
+			},
+			reject:  true,
+			reason:  "  ",
+			wantErr: ErrArticleReviewInvalid,
+		},
+		{
+			name: "已经审核过了",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				approved := pending
+				approved.Status = domain.ArticleReviewStatusApproved
+				repo.EXPECT().Get(gomock.Any(), int64(11)).Return(approved, nil)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			wantErr: ErrArticleReviewNotFound,
+		},
+		{
+			name: "同时被别人审核了",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), int64(11)).Return(pending, nil)
+				repo.EXPECT().Review(gomock.Any(), gomock.Any()).Return(repository.ErrArticleReviewNotFound)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(pendingArt, nil)
+				return repo, artRepo
+			},
+			wantErr: ErrArticleReviewNotFound,
+		},
+		{
+			name: "作者已经撤回了",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), int64(11)).Return(pending, nil)
+				repo.EXPECT().DeleteByArticle(gomock.Any(), int64(11)).Return(nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				return repo, artRepo
+			},
+			wantErr: ErrArticleReviewNotFound,
+		},
+		{
+			name: "修改文章状态失败，放回审核队列",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), int64(11)).Return(pending, nil)
+				repo.EXPECT().Review(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().Submit(gomock.Any(), pending).Return(nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(pendingArt, nil)
+				artRepo.EXPECT().SyncStatus(gomock.Any(), int64(123), int64(11), gomock.Any()).Return(errors.New("mock db error"))
+				return repo, artRepo
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewArticleReviewService(repo, artRepo, logger.NewNopLogger())
+			var err error
+			if tc.reject {
+				err = svc.Reject(context.Background(), 1, 11, tc.reason)
+			} else {
+				err = svc.Approve(context.Background(), 1, 11)
+			}
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/logger"
+)
+
+var (
+	ErrArticleSeriesInvalid  = errors.New("系列的标题或者简介不合法")
+	ErrArticleSeriesNotFound = errors.New("系列不存在")
+	ErrArticleInSeries       = errors.New("文章已经在系列里面了")
+	ErrArticleNotInSeries    = errors.New("文章不在这个系列里面")
+	ErrArticleSeriesMismatch = repository.ErrArticleSeriesMismatch
+)
+
+type ArticleSeriesService interface {
+	// Create s.Author 是创建系列的作者
+	Create(ctx context.Context, s domain.ArticleSeries) (int64, error)
+	// Update 只能修改自己的系列
+	Update(ctx context.Context, s domain.ArticleSeries) error
+	// Delete 系列里面的文章不会被删除
+	Delete(ctx context.Context, uid int64, id int64) error
+	ListByAuthor(ctx context.Context, uid int64) ([]domain.ArticleSeries, error)
+	// Detail 作者自己看系列，包括草稿和撤回的文章，Articles 只有 Id、Title 和 Status
+	Detail(ctx context.Context, uid int64, id int64) (domain.ArticleSeries, error)
+	// AddArticle 只能把自己的文章加到自己的系列里面，一篇文章只能在一个系列里面
+	AddArticle(ctx context.Context, uid int64, sid int64, aid int64) error
+	RemoveArticle(ctx context.Context, uid int64, sid int64, aid int64) error
+	// Reorder aids 是系列里面所有的文章的新顺序
+	Reorder(ctx context.Context, uid int64, sid int64, aids []int64) error
+	// GetPub 读者看到的系列，只有已发表的文章
+	GetPub(ctx context.Context, id int64) (domain.ArticleSeries, error)
+	// Nav 文章在系列里面的上一篇和下一篇，跳过读者看不到的文章
+	Nav(ctx context.Context, aid int64) (domain.ArticleSeriesNav, error)
+}
+
+type ArticleSeriesServiceImpl struct {
+	repo     repository.ArticleSeriesRepository
+	artRepo  repository.ArticleRepository
+	userRepo repository.UserRepository
+	l        logger.LoggerV1
+}
+
+func NewArticleSeriesService(repo repository.ArticleSeriesRepository,
+	artRepo repository.ArticleRepository,
+	userRepo repository.UserRepository,
+	l logger.LoggerV1) ArticleSeriesService {
+	return &ArticleSeriesServiceImpl{
+		repo:     repo,
+		artRepo:  artRepo,
+		userRepo: userRepo,
+		l:        l,
+	}
+}
+
+func (s *ArticleSeriesServiceImpl) Create(ctx context.Context, series domain.ArticleSeries) (int64, error) {
+	series, ok := series.Normalize()
+	if !ok {
+		return 0, ErrArticleSeriesInvalid
+	}
+	return s.repo.Create(ctx, series)
+}
+
+func (s *ArticleSeriesServiceImpl) Update(ctx context.Context, series domain.ArticleSeries) error {
+	series, ok := series.Normalize()
+	if !ok {
+		return ErrArticleSeriesInvalid
+	}
+	err := s.repo.Update(ctx, series)
+	if errors.Is(err, repository.ErrArticleSeriesNotFound) {
+		return ErrArticleSeriesNotFound
+	}
+	return err
+}
+
+func (s *ArticleSeriesServiceImpl) Delete(ctx context.Context, uid int64, id int64) error {
+	err := s.repo.Delete(ctx, id, uid)
+	if errors.Is(err, repository.ErrArticleSeriesNotFound) {
+		return ErrArticleSeriesNotFound
+	}
+	return err
+}
+
+func (s *ArticleSeriesServiceImpl) ListByAuthor(ctx context.Context, uid int64) ([]domain.ArticleSeries, error) {
+	return s.repo.ListByAuthor(ctx, uid)
+}
+
+func (s *ArticleSeriesServiceImpl) Detail(ctx context.Context, uid int64, id int64) (domain.ArticleSeries, error) {
+	series, err := s.checkOwner(ctx, uid, id)
+	if err != nil {
+		return domain.ArticleSeries{}, err
+	}
+	aids, err := s.repo.ListArticleIds(ctx, id)
+	if err != nil {
+		return domain.ArticleSeries{}, err
+	}
+	series.Articles = make([]domain.Article, 0, len(aids))
+	for _, aid := range aids {
+		art, err := s.artRepo.GetById(ctx, aid)
+		if errors.Is(err, repository.ErrArticleNotFound) {
+			// 彻底删除的时候会从系列里面移除，这里只是兜底
+			continue
+		}
+		if err != nil {
+			return domain.ArticleSeries{}, err
+		}
+		series.Articles = append(series.Articles, domain.Article{
+			Id:     art.Id,
+			Title:  art.Title,
+			Status: art.Status,
+			Utime:  art.Utime,
+		})
+	}
+	return series, nil
+}
+
+func (s *ArticleSeriesServiceImpl) AddArticle(ctx context.Context, uid int64, sid int64, aid int64) error {
+	_, err := s.checkOwner(ctx, uid, sid)
+	if err != nil {
+		return err
+	}
+	art, err := s.artRepo.GetById(ctx, aid)
+	if err != nil {
+		return err
+	}
+	if art.Author.Id != uid {
+		return ErrArticleAccessDenied
+	}
+	if art.Status == domain.ArticleStatusDeleted {
+		return ErrArticleNotFound
+	}
+	err = s.repo.AddArticle(ctx, sid, aid)
+	if errors.Is(err, repository.ErrArticleInSeries) {
+		return ErrArticleInSeries
+	}
+	return err
+}
+
+func (s *ArticleSeriesServiceImpl) RemoveArticle(ctx context.Context, uid int64, sid int64, aid int64) error {
+	_, err := s.checkOwner(ctx, uid, sid)
+	if err != nil {
+		return err
+	}
+	err = s.repo.RemoveArticle(ctx, sid, aid)
+	if errors.Is(err, repository.ErrArticleSeriesNotFound) {
+		return ErrArticleNotInSeries
+	}
+	return err
+}
+
+func (s *ArticleSeriesServiceImpl) Reorder(ctx context.Context, uid int64, sid int64, aids []int64) error {
+	_, err := s.checkOwner(ctx, uid, sid)
+	if err != nil {
+		return err
+	}
+	return s.repo.Reorder(ctx, sid, aids)
+}
+
+func (s *ArticleSeriesServiceImpl) GetPub(ctx context.Context, id int64) (domain.ArticleSeries, error) {
+	series, err := s.getById(ctx, id)
+	if err != nil {
+		return domain.ArticleSeries{}, err
+	}
+	aids, err := s.repo.ListArticleIds(ctx, id)
+	if err != nil {
+		return domain.ArticleSeries{}, err
+	}
+	series.Articles = make([]domain.Article, 0, len(aids))
+	for _, aid := range aids {
+		art, ok, err := s.pubArticle(ctx, aid)
+		if err != nil {
+			return domain.ArticleSeries{}, err
+		}
+		if ok {
+			series.Articles = append(series.Articles, art)
+		}
+	}
+	u, err := s.userRepo.FindById(ctx, series.Author.Id)
+	if err != nil {
+		// 查不到名字只是展示不了
+		s.l.Warn("查询系列作者的名字失败",
+			logger.Int64("uid", series.Author.Id),
+			logger.Error(err))
+	}
+	series.Author.Name = u.NickName
+	return series, nil
+}
+
+func (s *ArticleSeriesServiceImpl) Nav(ctx context.Context, aid int64) (domain.ArticleSeriesNav, error) {
+	sid, err := s.repo.FindByArticle(ctx, aid)
+	if errors.Is(err, repository.ErrArticleSeriesNotFound) {
+		return domain.ArticleSeriesNav{}, nil
+	}
+	if err != nil {
+		return domain.ArticleSeriesNav{}, err
+	}
+	series, err := s.getById(ctx, sid)
+	if errors.Is(err, ErrArticleSeriesNotFound) {
+		return domain.ArticleSeriesNav{}, nil
+	}
+	if err != nil {
+		return domain.ArticleSeriesNav{}, err
+	}
+	aids, err := s.repo.ListArticleIds(ctx, sid)
+	if err != nil {
+		return domain.ArticleSeriesNav{}, err
+	}
+	idx := -1
+	for i, id := range aids {
+		if id == aid {
+			idx = i
+			break
+		}
+	}
+	nav := domain.ArticleSeriesNav{Series: series}
+	if idx < 0 {
+		return nav, nil
+	}
+	for i := idx - 1; i >= 0; i-- {
+		art, ok, err := s.pubArticle(ctx, aids[i])
+		if err != nil {
+			return domain.ArticleSeriesNav{}, err
+		}
+		if ok {
+			nav.Prev = art
+			break
+		}
+	}
+	for i := idx + 1; i < len(aids); i++ {
+		art, ok, err := s.pubArticle(ctx, aids[i])
+		if err != nil {
+			return domain.ArticleSeriesNav{}, err
+		}
+		if ok {
+			nav.Next = art
+			break
+		}
+	}
+	return nav, nil
+}
+
+// pubArticle 读者能不能看到这篇文章，撤回、仅自己可见、待审核和回收站里面的文章都返回 false
+func (s *ArticleSeriesServiceImpl) pubArticle(ctx context.Context, aid int64) (domain.Article, bool, error) {
+	art, err := s.artRepo.GetPubById(ctx, aid)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		// 加到系列里面但是还没有发表过
+		return domain.Article{}, false, nil
+	}
+	if err != nil {
+		return domain.Article{}, false, err
+	}
+	if art.Status != domain.ArticleStatusPublished {
+		return domain.Article{}, false, nil
+	}
+	return domain.Article{
+		Id:      art.Id,
+		Title:   art.Title,
+		Summary: art.Abstract(),
+		Utime:   art.Utime,
+	}, true, nil
+}
+
+func (s *ArticleSeriesServiceImpl) getById(ctx context.Context, id int64) (domain.ArticleSeries, error) {
+	series, err := s.repo.GetById(ctx, id)
+	if errors.Is(err, repository.ErrArticleSeriesNotFound) {
+		return domain.ArticleSeries{}, ErrArticleSeriesNotFound
+	}
+	return series, err
+}
+
+// checkOwner 只有系列的作者可以管理系列，别人的系列当成不存在
+func (s *ArticleSeriesServiceImpl) checkOwner(ctx context.Context, uid int64, id int64) (domain.ArticleSeries, error) {
+	series, err := s.getById(ctx, id)
+	if err != nil {
+		return domain.ArticleSeries{}, err
+	}
+	if series.Author.Id != uid {
+		return domain.ArticleSeries{}, ErrArticleSeriesNotFound
+	}
+	return series, nil
+}
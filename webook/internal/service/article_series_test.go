@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestArticleSeriesServiceImpl_AddArticle(t *testing.T) {
+	series := domain.ArticleSeries{Id: 1, Author: domain.Author{Id: 123}, Title: "Go 入门"}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository)
+		uid  int64
+
+		wantErr error
+	}{
+		{
+			name: "加到系列里面",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(series, nil)
+				repo.EXPECT().AddArticle(gomock.Any(), int64(1), int64(11)).Return(nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusUnpublished,
+				}, nil)
+				return repo, artRepo
+			},
+			uid: 123,
+		},
+		{
+			name: "别人的系列",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(series, nil)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			uid:     456,
+			wantErr: ErrArticleSeriesNotFound,
+		},
+		{
+			name: "系列不存在",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.ArticleSeries{}, repository.ErrArticleSeriesNotFound)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			uid:     123,
+			wantErr: ErrArticleSeriesNotFound,
+		},
+		{
+			name: "别人的文章",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(series, nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 456},
+				}, nil)
+				return repo, artRepo
+			},
+			uid:     123,
+			wantErr: ErrArticleAccessDenied,
+		},
+		{
+			name: "回收站里面的文章",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(series, nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusDeleted,
+				}, nil)
+				return repo, artRepo
+			},
+			uid:     123,
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "已经在系列里面了",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(series, nil)
+				repo.EXPECT().AddArticle(gomock.Any(), int64(1), int64(11)).Return(repository.ErrArticleInSeries)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+				}, nil)
+				return repo, artRepo
+			},
+			uid:     123,
+			wantErr: ErrArticleInSeries,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewArticleSeriesService(repo, artRepo, nil, logger.NewNopLogger())
+			err := svc.AddArticle(context.Background(), tc.uid, 1, 11)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestArticleSeriesServiceImpl_Nav(t *testing.T) {
+	series := domain.ArticleSeries{Id: 1, Author: domain.Author{Id: 123}, Title: "Go 入门"}
+	pub := func(id int64, status domain.ArticleStatus) domain.Article {
+		return domain.Article{Id: id, Title: "第几篇", Summary: "摘要", Status: status}
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository)
+
+		wantNav domain.ArticleSeriesNav
+		wantErr error
+	}{
+		{
+			name: "不在系列里面",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().FindByArticle(gomock.Any(), int64(13)).Return(int64(0), repository.ErrArticleSeriesNotFound)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+		},
+		{
+			name: "跳过撤回和没发表的文章",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().FindByArticle(gomock.Any(), int64(13)).Return(int64(1), nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(series, nil)
+				repo.EXPECT().ListArticleIds(gomock.Any(), int64(1)).
+					Return([]int64{10, 11, 12, 13, 14, 15, 16}, nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(12)).Return(pub(12, domain.ArticleStatusPrivate), nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(pub(11, domain.ArticleStatusPublished), nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(14)).Return(domain.Article{}, repository.ErrArticleNotFound)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(15)).Return(pub(15, domain.ArticleStatusDeleted), nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(16)).Return(pub(16, domain.ArticleStatusPublished), nil)
+				return repo, artRepo
+			},
+			wantNav: domain.ArticleSeriesNav{
+				Series: series,
+				Prev:   domain.Article{Id: 11, Title: "第几篇", Summary: "摘要"},
+				Next:   domain.Article{Id: 16, Title: "第几篇", Summary: "摘要"},
+			},
+		},
+		{
+			name: "前面后面都没有能看的",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().FindByArticle(gomock.Any(), int64(13)).Return(int64(1), nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(series, nil)
+				repo.EXPECT().ListArticleIds(gomock.Any(), int64(1)).Return([]int64{12, 13, 14}, nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(12)).Return(pub(12, domain.ArticleStatusPendingReview), nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(14)).Return(pub(14, domain.ArticleStatusPrivate), nil)
+				return repo, artRepo
+			},
+			wantNav: domain.ArticleSeriesNav{Series: series},
+		},
+		{
+			name: "查询文章失败",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleSeriesRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+				repo.EXPECT().FindByArticle(gomock.Any(), int64(13)).Return(int64(1), nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(series, nil)
+				repo.EXPECT().ListArticleIds(gomock.Any(), int64(1)).Return([]int64{12, 13}, nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(12)).Return(domain.Article{}, errors.New("mock db error"))
+				return repo, artRepo
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewArticleSeriesService(repo, artRepo, nil, logger.NewNopLogger())
+			nav, err := svc.Nav(context.Background(), 13)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantNav, nav)
+		})
+	}
+}
+
+func TestArticleSeriesServiceImpl_GetPub(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockArticleSeriesRepository(ctrl)
+	repo.EXPECT().GetById(gomock.Any(), int64(1)).
+		Return(domain.ArticleSeries{Id: 1, Author: domain.Author{Id: 123}, Title: "Go 入门"}, nil)
+	repo.EXPECT().ListArticleIds(gomock.Any(), int64(1)).Return([]int64{11, 12, 13}, nil)
+	artRepo := repomocks.NewMockArticleRepository(ctrl)
+	artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).
+		Return(domain.Article{Id: 11, Title: "第一篇", Content: "内容", Status: domain.ArticleStatusPublished}, nil)
+	artRepo.EXPECT().GetPubById(gomock.Any(), int64(12)).
+		Return(domain.Article{Id: 12, Title: "撤回了", Status: domain.ArticleStatusPrivate}, nil)
+	artRepo.EXPECT().GetPubById(gomock.Any(), int64(13)).
+		Return(domain.Article{Id: 13, Title: "第三篇", Summary: "摘要", Status: domain.ArticleStatusPublished}, nil)
+	userRepo := repomocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().FindById(gomock.Any(), int64(123)).Return(domain.User{Id: 123, NickName: "大明"}, nil)
+
+	svc := NewArticleSeriesService(repo, artRepo, userRepo, logger.NewNopLogger())
+	s, err := svc.GetPub(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ArticleSeries{
+		Id:     1,
+		Author: domain.Author{Id: 123, Name: "大明"},
+		Title:  "Go 入门",
+		Articles: []domain.Article{
+			{Id: 11, Title: "第一篇", Summary: "内容"},
+			{Id: 13, Title: "第三篇", Summary: "摘要"},
+		},
+	}, s)
+}
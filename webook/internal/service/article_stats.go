@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/logger"
+)
+
+// ArticleStatsService 长度统计上线之前发表的文章没有统计，用一次性的命令回填。
+// 新发表的文章在 renderContent 的时候就算好了
+type ArticleStatsService interface {
+	// Backfill 按照 id 顺序扫描整个线上库，统计没有变化的跳过，返回更新了多少篇。
+	// 中途失败了重新跑一次就可以
+	Backfill(ctx context.Context, batchSize int) (int, error)
+}
+
+type ArticleStatsServiceImpl struct {
+	repo repository.ArticleRepository
+	l    logger.LoggerV1
+}
+
+func NewArticleStatsService(repo repository.ArticleRepository, l logger.LoggerV1) ArticleStatsService {
+	return &ArticleStatsServiceImpl{
+		repo: repo,
+		l:    l,
+	}
+}
+
+func (s *ArticleStatsServiceImpl) Backfill(ctx context.Context, batchSize int) (int, error) {
+	var (
+		id  int64
+		cnt int
+	)
+	for {
+		arts, err := s.repo.ScanPub(ctx, id, batchSize)
+		if err != nil {
+			return cnt, err
+		}
+		for _, art := range arts {
+			id = art.Id
+			// 和发表的时候一样从内容渲染，老文章可能连 Html 都没有
+			stats := renderContent(art).Stats
+			if stats == art.Stats {
+				continue
+			}
+			art.Stats = stats
+			err = s.repo.UpdatePubStats(ctx, art)
+			if err != nil {
+				return cnt, err
+			}
+			cnt++
+		}
+		s.l.Info("回填文章统计",
+			logger.Int64("id", id),
+			logger.Int("cnt", cnt))
+		if len(arts) < batchSize {
+			return cnt, nil
+		}
+	}
+}
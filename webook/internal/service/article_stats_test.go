@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestArticleStatsServiceImpl_Backfill(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.ArticleRepository
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "分批回填，统计没变的跳过",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().ScanPub(gomock.Any(), int64(0), 2).Return([]domain.Article{
+					{Id: 1, Content: "我的内容"},
+					{Id: 3, Content: "已经算过了", Stats: domain.ArticleStats{WordCnt: 5, ReadMinutes: 1}},
+				}, nil)
+				repo.EXPECT().ScanPub(gomock.Any(), int64(3), 2).Return([]domain.Article{
+					{Id: 4, Format: domain.ArticleFormatMarkdown, Content: "![图](/a.png)\n\n```\ncode\n```"},
+				}, nil)
+				repo.EXPECT().UpdatePubStats(gomock.Any(), gomock.Cond(func(x any) bool {
+					art := x.(domain.Article)
+					return art.Id == 1 && art.Stats == domain.ArticleStats{WordCnt: 4, ReadMinutes: 1}
+				})).Return(nil)
+				repo.EXPECT().UpdatePubStats(gomock.Any(), gomock.Cond(func(x any) bool {
+					art := x.(domain.Article)
+					return art.Id == 4 && art.Stats == domain.ArticleStats{
+						WordCnt: 1, ReadMinutes: 1, ImageCnt: 1, CodeBlockCnt: 1,
+					}
+				})).Return(nil)
+				return repo
+			},
+			wantCnt: 2,
+		},
+		{
+			name: "更新失败",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().ScanPub(gomock.Any(), int64(0), 2).Return([]domain.Article{
+					{Id: 1, Content: "我的内容"},
+					{Id: 2, Content: "我的内容"},
+				}, nil)
+				repo.EXPECT().UpdatePubStats(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().UpdatePubStats(gomock.Any(), gomock.Any()).Return(errors.New("db 错误"))
+				return repo
+			},
+			wantCnt: 1,
+			wantErr: errors.New("db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleStatsService(tc.mock(ctrl), logger.NewNopLogger())
+			cnt, err := svc.Backfill(context.Background(), 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"geek-basic-go/webook/internal/repository"
 	repomocks "geek-basic-go/webook/internal/repository/mocks"
 	"geek-basic-go/webook/pkg/logger"
+	"geek-basic-go/webook/pkg/sensitive"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestArticleServiceImpl_Publish(t *testing.T) {
@@ -202,3 +205,923 @@ func TestArticleServiceImpl_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleServiceImpl_DiffRevisions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		fromContent string
+		toContent   string
+
+		wantContent []domain.DiffLine
+		wantErr     error
+	}{
+		{
+			name:        "比较成功",
+			fromContent: "a\nb",
+			toContent:   "a\nc",
+			wantContent: []domain.DiffLine{
+				{Op: "equal", Text: "a"},
+				{Op: "delete", Text: "b"},
+				{Op: "insert", Text: "c"},
+			},
+		},
+		{
+			name:        "内容太长",
+			fromContent: strings.Repeat("a\n", articleDiffMaxLines/2),
+			toContent:   strings.Repeat("b\n", articleDiffMaxLines/2),
+			wantErr:     ErrArticleRevisionTooLarge,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repomocks.NewMockArticleRepository(ctrl)
+			repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+				Id:     11,
+				Author: domain.Author{Id: 123},
+			}, nil)
+			revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+			revRepo.EXPECT().GetById(gomock.Any(), int64(1)).Return(domain.ArticleRevision{
+				Id: 1, ArticleId: 11, Content: tc.fromContent,
+			}, nil)
+			revRepo.EXPECT().GetById(gomock.Any(), int64(2)).Return(domain.ArticleRevision{
+				Id: 2, ArticleId: 11, Content: tc.toContent,
+			}, nil)
+			svc := NewArticleService(repo, revRepo, nil, nil, repomocks.NewMockArticleCollaboratorRepository(ctrl),
+				nil, nil, nil, nil, logger.NewNopLogger())
+			diff, err := svc.DiffRevisions(context.Background(), 123, 11, 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantContent, diff.Content)
+		})
+	}
+}
+
+func TestArticleServiceImpl_RestoreRevision(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository, repository.ArticleCollaboratorRepository)
+		uid     int64
+		aid     int64
+		revId   int64
+		publish bool
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "恢复成草稿",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:      11,
+					Author:  domain.Author{Id: 123},
+					Version: 5,
+				}, nil).Times(2)
+				revRepo.EXPECT().GetById(gomock.Any(), int64(2)).Return(domain.ArticleRevision{
+					Id:        2,
+					ArticleId: 11,
+					Title:     "旧标题",
+					Content:   "**旧内容**",
+					Format:    domain.ArticleFormatMarkdown,
+				}, nil)
+				art := domain.Article{
+					Id:      11,
+					Title:   "旧标题",
+					Content: "**旧内容**",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusUnpublished,
+					Format:  domain.ArticleFormatMarkdown,
+					Html:    "<p><strong>旧内容</strong></p>\n",
+					Summary: "旧内容",
+					Stats:   domain.ArticleStats{WordCnt: 3, ReadMinutes: 1},
+					Version: 5,
+				}
+				repo.EXPECT().Update(gomock.Any(), art).Return(nil)
+				revRepo.EXPECT().Create(gomock.Any(), domain.ArticleRevision{
+					ArticleId: 11,
+					Title:     "旧标题",
+					Content:   "**旧内容**",
+					Format:    domain.ArticleFormatMarkdown,
+					Author:    domain.Author{Id: 123},
+					Status:    domain.ArticleStatusUnpublished,
+				}).Return(int64(3), nil)
+				return repo, revRepo, repomocks.NewMockArticleScheduleRepository(ctrl), repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			uid:    123,
+			aid:    11,
+			revId:  2,
+			wantId: 11,
+		},
+		{
+			name: "恢复并发表",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:      11,
+					Author:  domain.Author{Id: 123},
+					Version: 5,
+				}, nil).Times(2)
+				revRepo.EXPECT().GetById(gomock.Any(), int64(2)).Return(domain.ArticleRevision{
+					Id:        2,
+					ArticleId: 11,
+					Title:     "旧标题",
+					Content:   "旧内容",
+				}, nil)
+				repo.EXPECT().Sync(gomock.Any(), domain.Article{
+					Id:      11,
+					Title:   "旧标题",
+					Content: "旧内容",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPublished,
+					Html:    "<p>旧内容</p>\n",
+					Summary: "旧内容",
+					Stats:   domain.ArticleStats{WordCnt: 3, ReadMinutes: 1},
+					Version: 5,
+				}).Return(int64(11), nil)
+				// 历史版本记录失败不影响恢复
+				revRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("mock DB error"))
+				scheduleRepo := repomocks.NewMockArticleScheduleRepository(ctrl)
+				scheduleRepo.EXPECT().Cancel(gomock.Any(), int64(123), int64(11)).Return(repository.ErrArticleScheduleNotFound)
+				return repo, revRepo, scheduleRepo, repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			uid:     123,
+			aid:     11,
+			revId:   2,
+			publish: true,
+			wantId:  11,
+		},
+		{
+			name: "协作者恢复，作者不变",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:      11,
+					Author:  domain.Author{Id: 234},
+					Version: 5,
+				}, nil).Times(2)
+				collabRepo.EXPECT().Get(gomock.Any(), int64(11), int64(123)).Return(domain.ArticleCollaborator{
+					ArticleId: 11,
+					User:      domain.Author{Id: 123},
+					Role:      domain.ArticleRoleEditor,
+				}, nil).Times(2)
+				revRepo.EXPECT().GetById(gomock.Any(), int64(2)).Return(domain.ArticleRevision{
+					Id:        2,
+					ArticleId: 11,
+					Title:     "旧标题",
+					Content:   "旧内容",
+				}, nil)
+				repo.EXPECT().Update(gomock.Any(), domain.Article{
+					Id:      11,
+					Title:   "旧标题",
+					Content: "旧内容",
+					Author:  domain.Author{Id: 234},
+					Status:  domain.ArticleStatusUnpublished,
+					Html:    "<p>旧内容</p>\n",
+					Summary: "旧内容",
+					Stats:   domain.ArticleStats{WordCnt: 3, ReadMinutes: 1},
+					Version: 5,
+				}).Return(nil)
+				// 历史版本记录的是实际修改的人
+				revRepo.EXPECT().Create(gomock.Any(), domain.ArticleRevision{
+					ArticleId: 11,
+					Title:     "旧标题",
+					Content:   "旧内容",
+					Author:    domain.Author{Id: 123},
+					Status:    domain.ArticleStatusUnpublished,
+				}).Return(int64(3), nil)
+				return repo, revRepo, repomocks.NewMockArticleScheduleRepository(ctrl), collabRepo
+			},
+			uid:    123,
+			aid:    11,
+			revId:  2,
+			wantId: 11,
+		},
+		{
+			name: "viewer 不能恢复",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 234},
+				}, nil)
+				collabRepo.EXPECT().Get(gomock.Any(), int64(11), int64(123)).Return(domain.ArticleCollaborator{
+					ArticleId: 11,
+					User:      domain.Author{Id: 123},
+					Role:      domain.ArticleRoleViewer,
+				}, nil)
+				return repo, revRepo, repomocks.NewMockArticleScheduleRepository(ctrl), collabRepo
+			},
+			uid:     123,
+			aid:     11,
+			revId:   2,
+			wantErr: ErrArticleAccessDenied,
+		},
+		{
+			name: "不是作者也不是协作者",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 234},
+				}, nil)
+				collabRepo.EXPECT().Get(gomock.Any(), int64(11), int64(123)).
+					Return(domain.ArticleCollaborator{}, repository.ErrArticleCollaboratorNotFound)
+				return repo, revRepo, repomocks.NewMockArticleScheduleRepository(ctrl), collabRepo
+			},
+			uid:     123,
+			aid:     11,
+			revId:   2,
+			wantErr: ErrArticleAccessDenied,
+		},
+		{
+			name: "历史版本不属于这篇文章",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+				}, nil)
+				revRepo.EXPECT().GetById(gomock.Any(), int64(2)).Return(domain.ArticleRevision{
+					Id:        2,
+					ArticleId: 12,
+				}, nil)
+				return repo, revRepo, repomocks.NewMockArticleScheduleRepository(ctrl), repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			uid:     123,
+			aid:     11,
+			revId:   2,
+			wantErr: ErrArticleRevisionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, revRepo, scheduleRepo, collabRepo := tc.mock(ctrl)
+			// 没有命中敏感词，清掉上一次的审核结果
+			reviewRepo := repomocks.NewMockArticleReviewRepository(ctrl)
+			reviewRepo.EXPECT().DeleteByArticle(gomock.Any(), tc.aid).Return(nil).AnyTimes()
+			svc := NewArticleService(repo, revRepo, scheduleRepo, nil, collabRepo, reviewRepo, nil, sensitive.NewACFilter(nil), nil, logger.NewNopLogger())
+			id, err := svc.RestoreRevision(context.Background(), tc.uid, tc.aid, tc.revId, tc.publish)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestArticleServiceImpl_Save(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.ArticleScheduleRepository
+		// status 文章现在的状态
+		status domain.ArticleStatus
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name:   "保存草稿",
+			status: domain.ArticleStatusUnpublished,
+			mock: func(ctrl *gomock.Controller) repository.ArticleScheduleRepository {
+				return repomocks.NewMockArticleScheduleRepository(ctrl)
+			},
+			wantId: 11,
+		},
+		{
+			name:   "定时发表的文章保存草稿，取消定时发表",
+			status: domain.ArticleStatusScheduled,
+			mock: func(ctrl *gomock.Controller) repository.ArticleScheduleRepository {
+				scheduleRepo := repomocks.NewMockArticleScheduleRepository(ctrl)
+				scheduleRepo.EXPECT().Cancel(gomock.Any(), int64(123), int64(11)).Return(nil)
+				return scheduleRepo
+			},
+			wantId: 11,
+		},
+		{
+			name:   "定时发表已经被别人取消了",
+			status: domain.ArticleStatusScheduled,
+			mock: func(ctrl *gomock.Controller) repository.ArticleScheduleRepository {
+				scheduleRepo := repomocks.NewMockArticleScheduleRepository(ctrl)
+				scheduleRepo.EXPECT().Cancel(gomock.Any(), int64(123), int64(11)).
+					Return(repository.ErrArticleScheduleNotFound)
+				return scheduleRepo
+			},
+			wantId: 11,
+		},
+		{
+			name:   "取消定时发表失败",
+			status: domain.ArticleStatusScheduled,
+			mock: func(ctrl *gomock.Controller) repository.ArticleScheduleRepository {
+				scheduleRepo := repomocks.NewMockArticleScheduleRepository(ctrl)
+				scheduleRepo.EXPECT().Cancel(gomock.Any(), int64(123), int64(11)).
+					Return(errors.New("mock db error"))
+				return scheduleRepo
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repomocks.NewMockArticleRepository(ctrl)
+			repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+				Id:     11,
+				Author: domain.Author{Id: 123},
+				Status: tc.status,
+			}, nil)
+			repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, art domain.Article) error {
+				// 不管之前是什么状态，保存之后都是草稿
+				assert.Equal(t, domain.ArticleStatus(domain.ArticleStatusUnpublished), art.Status)
+				return nil
+			})
+			revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+			revRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+			svc := NewArticleService(repo, revRepo, tc.mock(ctrl), nil, repomocks.NewMockArticleCollaboratorRepository(ctrl),
+				nil, nil, nil, nil, logger.NewNopLogger())
+			id, err := svc.Save(context.Background(), domain.Article{
+				Id:      11,
+				Title:   "我的标题",
+				Content: "我的内容",
+				Author:  domain.Author{Id: 123},
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestArticleServiceImpl_PublishDue(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository)
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "抢占成功的发表，被别的实例抢走的跳过",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				scheduleRepo := repomocks.NewMockArticleScheduleRepository(ctrl)
+				scheduleRepo.EXPECT().FindDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+					Return([]domain.ArticleSchedule{
+						{Id: 1, ArticleId: 11, Author: domain.Author{Id: 123}},
+						{Id: 2, ArticleId: 12, Author: domain.Author{Id: 123}},
+					}, nil)
+				scheduleRepo.EXPECT().Claim(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).Return(true, nil)
+				scheduleRepo.EXPECT().Claim(gomock.Any(), int64(2), gomock.Any(), gomock.Any()).Return(false, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:      11,
+					Title:   "我的标题",
+					Content: "我的内容",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusScheduled,
+				}, nil).Times(2)
+				repo.EXPECT().Sync(gomock.Any(), domain.Article{
+					Id:      11,
+					Title:   "我的标题",
+					Content: "我的内容",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPublished,
+					Html:    "<p>我的内容</p>\n",
+					Summary: "我的内容",
+					Stats:   domain.ArticleStats{WordCnt: 4, ReadMinutes: 1},
+				}).Return(int64(11), nil)
+				revRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				scheduleRepo.EXPECT().Cancel(gomock.Any(), int64(123), int64(11)).Return(repository.ErrArticleScheduleNotFound)
+				scheduleRepo.EXPECT().Complete(gomock.Any(), int64(1)).Return(nil)
+				return repo, revRepo, scheduleRepo
+			},
+			wantCnt: 1,
+		},
+		{
+			name: "发表失败，释放任务",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.ArticleScheduleRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				scheduleRepo := repomocks.NewMockArticleScheduleRepository(ctrl)
+				scheduleRepo.EXPECT().FindDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+					Return([]domain.ArticleSchedule{
+						{Id: 1, ArticleId: 11, Author: domain.Author{Id: 123}},
+					}, nil)
+				scheduleRepo.EXPECT().Claim(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).Return(true, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+				}, nil).Times(2)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("mock DB error"))
+				scheduleRepo.EXPECT().Release(gomock.Any(), int64(1)).Return(nil)
+				return repo, revRepo, scheduleRepo
+			},
+			wantCnt: 0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, revRepo, scheduleRepo := tc.mock(ctrl)
+			reviewRepo := repomocks.NewMockArticleReviewRepository(ctrl)
+			reviewRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(11)).Return(nil).AnyTimes()
+			svc := NewArticleService(repo, revRepo, scheduleRepo, nil, nil, reviewRepo, nil, sensitive.NewACFilter(nil), nil, logger.NewNopLogger())
+			cnt, err := svc.PublishDue(context.Background(), 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func TestArticleServiceImpl_Restore(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository)
+
+		wantErr error
+	}{
+		{
+			name: "恢复成功",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusDeleted,
+					Dtime:  time.Now().Add(-time.Hour),
+				}, nil)
+				repo.EXPECT().Restore(gomock.Any(), int64(123), int64(11)).Return(nil)
+				return repo, repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+		},
+		{
+			name: "不是 owner",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 456},
+					Status: domain.ArticleStatusDeleted,
+					Dtime:  time.Now().Add(-time.Hour),
+				}, nil)
+				// editor 也不能恢复，要 owner 才行
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().Get(gomock.Any(), int64(11), int64(123)).Return(domain.ArticleCollaborator{
+					ArticleId: 11,
+					User:      domain.Author{Id: 123},
+					Role:      domain.ArticleRoleEditor,
+				}, nil)
+				return repo, collabRepo
+			},
+			wantErr: ErrArticleAccessDenied,
+		},
+		{
+			name: "不在回收站里面",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPublished,
+				}, nil)
+				return repo, repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			wantErr: ErrArticleNotInTrash,
+		},
+		{
+			name: "过了保留期限",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusDeleted,
+					Dtime:  time.Now().Add(-ArticleTrashRetention - time.Hour),
+				}, nil)
+				return repo, repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			wantErr: ErrArticleNotInTrash,
+		},
+		{
+			name: "已经被彻底删除",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{}, repository.ErrArticleNotFound)
+				return repo, repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			wantErr: ErrArticleNotInTrash,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, collabRepo := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, nil, nil, collabRepo, nil, nil, nil, nil, logger.NewNopLogger())
+			err := svc.Restore(context.Background(), 123, 11)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestArticleServiceImpl_EditTrashed(t *testing.T) {
+	testCases := []struct {
+		name string
+		edit func(svc ArticleService, art domain.Article) (int64, error)
+	}{
+		{
+			name: "保存草稿",
+			edit: func(svc ArticleService, art domain.Article) (int64, error) {
+				return svc.Save(context.Background(), art)
+			},
+		},
+		{
+			name: "发表",
+			edit: func(svc ArticleService, art domain.Article) (int64, error) {
+				return svc.Publish(context.Background(), art)
+			},
+		},
+		{
+			name: "定时发表",
+			edit: func(svc ArticleService, art domain.Article) (int64, error) {
+				return svc.SchedulePublish(context.Background(), art, time.Now().Add(time.Hour))
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// 回收站里面的文章只会查一下，不会有任何写操作
+			repo := repomocks.NewMockArticleRepository(ctrl)
+			repo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Article{
+				Id:     11,
+				Author: domain.Author{Id: 123},
+				Status: domain.ArticleStatusDeleted,
+				Dtime:  time.Now().Add(-time.Hour),
+			}, nil)
+			svc := NewArticleService(repo, nil, nil, nil, repomocks.NewMockArticleCollaboratorRepository(ctrl),
+				nil, nil, sensitive.NewACFilter(nil), nil, logger.NewNopLogger())
+			id, err := tc.edit(svc, domain.Article{
+				Id:      11,
+				Title:   "我的标题",
+				Content: "我的内容",
+				Author:  domain.Author{Id: 123},
+			})
+			assert.Equal(t, ErrArticleInTrash, err)
+			assert.Equal(t, int64(0), id)
+		})
+	}
+}
+
+func TestArticleServiceImpl_PurgeTrash(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.InteractiveRepository, repository.ArticleCollaboratorRepository)
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "分批彻底删除",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.InteractiveRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				intrRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				first := []domain.Article{{Id: 11}, {Id: 12}}
+				second := []domain.Article{{Id: 13}}
+				gomock.InOrder(
+					repo.EXPECT().ListTrashBefore(gomock.Any(), gomock.Any(), 2).Return(first, nil),
+					repo.EXPECT().ListTrashBefore(gomock.Any(), gomock.Any(), 2).Return(second, nil),
+				)
+				for _, art := range append(first, second...) {
+					intrRepo.EXPECT().Delete(gomock.Any(), "article", art.Id).Return(nil)
+					revRepo.EXPECT().DeleteByArticle(gomock.Any(), art.Id).Return(nil)
+					collabRepo.EXPECT().DeleteByArticle(gomock.Any(), art.Id).Return(nil)
+					repo.EXPECT().Purge(gomock.Any(), art).Return(nil)
+				}
+				return repo, revRepo, intrRepo, collabRepo
+			},
+			wantCnt: 3,
+		},
+		{
+			name: "删除失败的留到下一次",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.InteractiveRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				intrRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				repo.EXPECT().ListTrashBefore(gomock.Any(), gomock.Any(), 2).
+					Return([]domain.Article{{Id: 11}, {Id: 12}}, nil)
+				intrRepo.EXPECT().Delete(gomock.Any(), "article", int64(11)).Return(errors.New("mock db error"))
+				intrRepo.EXPECT().Delete(gomock.Any(), "article", int64(12)).Return(nil)
+				revRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(12)).Return(nil)
+				collabRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(12)).Return(nil)
+				repo.EXPECT().Purge(gomock.Any(), domain.Article{Id: 12}).Return(nil)
+				return repo, revRepo, intrRepo, collabRepo
+			},
+			wantCnt: 1,
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleRevisionRepository, repository.InteractiveRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().ListTrashBefore(gomock.Any(), gomock.Any(), 2).
+					Return(nil, errors.New("mock db error"))
+				return repo, repomocks.NewMockArticleRevisionRepository(ctrl), repomocks.NewMockInteractiveRepository(ctrl),
+					repomocks.NewMockArticleCollaboratorRepository(ctrl)
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, revRepo, intrRepo, collabRepo := tc.mock(ctrl)
+			// 审核记录、系列里面的位置和协作者一起删掉
+			reviewRepo := repomocks.NewMockArticleReviewRepository(ctrl)
+			reviewRepo.EXPECT().DeleteByArticle(gomock.Any(), gomock.Any()).Return(nil).Times(tc.wantCnt)
+			seriesRepo := repomocks.NewMockArticleSeriesRepository(ctrl)
+			seriesRepo.EXPECT().DeleteByArticle(gomock.Any(), gomock.Any()).Return(nil).Times(tc.wantCnt)
+			svc := NewArticleService(repo, revRepo, nil, intrRepo, collabRepo, reviewRepo, seriesRepo, nil, nil, logger.NewNopLogger())
+			cnt, err := svc.PurgeTrash(context.Background(), 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func TestArticleServiceImpl_GetPubById(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.ArticleRepository
+		uid  int64
+
+		wantErr error
+	}{
+		{
+			name: "撤回的文章，没有登录",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				return repo
+			},
+			uid:     0,
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "撤回的文章，别的读者",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				return repo
+			},
+			uid:     456,
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "在回收站里面，作者也看不到",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusDeleted,
+				}, nil)
+				return repo
+			},
+			uid:     123,
+			wantErr: ErrArticleNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleService(tc.mock(ctrl), nil, nil, nil, nil, nil, nil, nil, nil, logger.NewNopLogger())
+			_, err := svc.GetPubById(context.Background(), 11, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestArticleServiceImpl_PublishReview(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleReviewRepository)
+		art  domain.Article
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "命中敏感词，等待审核",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleReviewRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Sync(gomock.Any(), domain.Article{
+					Title:   "我的标题",
+					Content: "这里可以代开发票",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPendingReview,
+					Html:    "<p>这里可以代开发票</p>\n",
+					Summary: "这里可以代开发票",
+					Stats:   domain.ArticleStats{WordCnt: 8, ReadMinutes: 1},
+				}).Return(int64(1), nil)
+				reviewRepo := repomocks.NewMockArticleReviewRepository(ctrl)
+				reviewRepo.EXPECT().Submit(gomock.Any(), domain.ArticleReview{
+					ArticleId: 1,
+					Author:    domain.Author{Id: 123},
+					Title:     "我的标题",
+					Hits:      []string{"代开发票"},
+				}).Return(nil)
+				return repo, reviewRepo
+			},
+			art: domain.Article{
+				Title:   "我的标题",
+				Content: "这里可以代开发票",
+				Author:  domain.Author{Id: 123},
+			},
+			wantId: 1,
+		},
+		{
+			name: "没有命中，直接发表",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleReviewRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Sync(gomock.Any(), domain.Article{
+					Title:   "我的标题",
+					Content: "我的内容",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPublished,
+					Html:    "<p>我的内容</p>\n",
+					Summary: "我的内容",
+					Stats:   domain.ArticleStats{WordCnt: 4, ReadMinutes: 1},
+				}).Return(int64(1), nil)
+				reviewRepo := repomocks.NewMockArticleReviewRepository(ctrl)
+				reviewRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(1)).Return(nil)
+				return repo, reviewRepo
+			},
+			art: domain.Article{
+				Title:   "我的标题",
+				Content: "我的内容",
+				Author:  domain.Author{Id: 123},
+			},
+			wantId: 1,
+		},
+		{
+			// 文章已经保存成等待审核了，由 ReconcileReviews 补上审核记录
+			name: "提交审核失败，发表还是成功",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleReviewRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				reviewRepo := repomocks.NewMockArticleReviewRepository(ctrl)
+				reviewRepo.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(errors.New("mock db error"))
+				return repo, reviewRepo
+			},
+			art: domain.Article{
+				Title:   "代开发票",
+				Content: "我的内容",
+				Author:  domain.Author{Id: 123},
+			},
+			wantId: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, reviewRepo := tc.mock(ctrl)
+			revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+			revRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+			scheduleRepo := repomocks.NewMockArticleScheduleRepository(ctrl)
+			scheduleRepo.EXPECT().Cancel(gomock.Any(), int64(123), int64(1)).
+				Return(repository.ErrArticleScheduleNotFound).AnyTimes()
+			svc := NewArticleService(repo, revRepo, scheduleRepo, nil, nil, reviewRepo, nil,
+				sensitive.NewACFilter([]string{"代开发票"}), nil, logger.NewNopLogger())
+			id, err := svc.Publish(context.Background(), tc.art)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestArticleServiceImpl_ReconcileReviews(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleReviewRepository)
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "补上缺失的审核记录",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleReviewRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().ScanPub(gomock.Any(), int64(0), 2).Return([]domain.Article{
+					{Id: 1, Status: domain.ArticleStatusPublished},
+					{Id: 2, Title: "代开发票", Author: domain.Author{Id: 123}, Status: domain.ArticleStatusPendingReview},
+				}, nil)
+				repo.EXPECT().ScanPub(gomock.Any(), int64(2), 2).Return([]domain.Article{
+					{Id: 3, Status: domain.ArticleStatusPendingReview},
+				}, nil)
+				reviewRepo := repomocks.NewMockArticleReviewRepository(ctrl)
+				reviewRepo.EXPECT().Get(gomock.Any(), int64(2)).
+					Return(domain.ArticleReview{}, repository.ErrArticleReviewNotFound)
+				reviewRepo.EXPECT().Submit(gomock.Any(), domain.ArticleReview{
+					ArticleId: 2,
+					Author:    domain.Author{Id: 123},
+					Title:     "代开发票",
+					Hits:      []string{"代开发票"},
+				}).Return(nil)
+				reviewRepo.EXPECT().Get(gomock.Any(), int64(3)).Return(domain.ArticleReview{ArticleId: 3}, nil)
+				return repo, reviewRepo
+			},
+			wantCnt: 1,
+		},
+		{
+			name: "查询审核记录失败",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleReviewRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().ScanPub(gomock.Any(), int64(0), 2).Return([]domain.Article{
+					{Id: 2, Status: domain.ArticleStatusPendingReview},
+				}, nil)
+				reviewRepo := repomocks.NewMockArticleReviewRepository(ctrl)
+				reviewRepo.EXPECT().Get(gomock.Any(), int64(2)).
+					Return(domain.ArticleReview{}, errors.New("mock db error"))
+				return repo, reviewRepo
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, reviewRepo := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, nil, nil, nil, reviewRepo, nil,
+				sensitive.NewACFilter([]string{"代开发票"}), nil, logger.NewNopLogger())
+			cnt, err := svc.ReconcileReviews(context.Background(), 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func TestArticleStats(t *testing.T) {
+	testCases := []struct {
+		name string
+		html string
+
+		wantStats domain.ArticleStats
+	}{
+		{
+			name:      "空的文章",
+			wantStats: domain.ArticleStats{},
+		},
+		{
+			name:      "中文一分钟三百字",
+			html:      "<p>" + strings.Repeat("字", 600) + "</p>\n",
+			wantStats: domain.ArticleStats{WordCnt: 600, ReadMinutes: 2},
+		},
+		{
+			name: "中英文分开算，不满一分钟向上取整",
+			html: "<p>" + strings.Repeat("字", 300) + strings.Repeat(" word", 100) + "</p>\n",
+			// 60 秒加上 30 秒
+			wantStats: domain.ArticleStats{WordCnt: 400, ReadMinutes: 2},
+		},
+		{
+			name: "图片和代码块",
+			html: "<p><img src=\"/a.png\" alt=\"\"></p>\n<pre><code>x</code></pre>\n",
+			wantStats: domain.ArticleStats{
+				WordCnt:      1,
+				ReadMinutes:  1,
+				ImageCnt:     1,
+				CodeBlockCnt: 1,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantStats, articleStats(tc.html))
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/logger"
+)
+
+var (
+	ErrCollectionInvalid  = errors.New("收藏夹的名字不合法")
+	ErrCollectionNotFound = errors.New("收藏夹不存在")
+)
+
+// CollectionService 收藏夹的管理。收藏和取消收藏在 InteractiveService 里面，和点赞一样
+type CollectionService interface {
+	// Create c.Uid 是创建收藏夹的用户
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	// Rename 只能修改自己的收藏夹，默认收藏夹不能修改
+	Rename(ctx context.Context, c domain.Collection) error
+	SetPublic(ctx context.Context, uid int64, id int64, public bool) error
+	// Delete 连里面的收藏一起删掉，收藏数会减一
+	Delete(ctx context.Context, uid int64, id int64) error
+	// DeleteAndMove 里面的收藏移到自己的收藏夹 to，0 是默认收藏夹
+	DeleteAndMove(ctx context.Context, uid int64, id int64, to int64) error
+	// List uid 是看的人，owner 是收藏夹的主人，看别人的只有公开的收藏夹。不包括默认收藏夹
+	List(ctx context.Context, uid int64, owner int64) ([]domain.Collection, error)
+	// ListArticles 收藏夹里面的文章，按照收藏时间倒序。
+	// 自己的收藏夹和别人公开的收藏夹才能看，cid 是 0 代表自己的默认收藏夹。
+	// 撤回或者删除了的文章还在收藏夹里面，但是只有 Id 和 Status，方便用户取消收藏
+	ListArticles(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error)
+}
+
+type CollectionServiceImpl struct {
+	repo    repository.CollectionRepository
+	artRepo repository.ArticleRepository
+	l       logger.LoggerV1
+}
+
+func NewCollectionService(repo repository.CollectionRepository,
+	artRepo repository.ArticleRepository,
+	l logger.LoggerV1) CollectionService {
+	return &CollectionServiceImpl{
+		repo:    repo,
+		artRepo: artRepo,
+		l:       l,
+	}
+}
+
+func (s *CollectionServiceImpl) Create(ctx context.Context, c domain.Collection) (int64, error) {
+	c, ok := c.Normalize()
+	if !ok {
+		return 0, ErrCollectionInvalid
+	}
+	return s.repo.Create(ctx, c)
+}
+
+func (s *CollectionServiceImpl) Rename(ctx context.Context, c domain.Collection) error {
+	c, ok := c.Normalize()
+	if !ok {
+		return ErrCollectionInvalid
+	}
+	return s.wrapNotFound(s.repo.Rename(ctx, c))
+}
+
+func (s *CollectionServiceImpl) SetPublic(ctx context.Context, uid int64, id int64, public bool) error {
+	return s.wrapNotFound(s.repo.SetPublic(ctx, domain.Collection{
+		Id:     id,
+		Uid:    uid,
+		Public: public,
+	}))
+}
+
+func (s *CollectionServiceImpl) Delete(ctx context.Context, uid int64, id int64) error {
+	return s.wrapNotFound(s.repo.Delete(ctx, id, uid))
+}
+
+func (s *CollectionServiceImpl) DeleteAndMove(ctx context.Context, uid int64, id int64, to int64) error {
+	if to == id {
+		return ErrCollectionNotFound
+	}
+	if to > 0 {
+		_, err := s.checkVisible(ctx, uid, to, false)
+		if err != nil {
+			return err
+		}
+	}
+	return s.wrapNotFound(s.repo.DeleteAndMove(ctx, id, uid, to))
+}
+
+func (s *CollectionServiceImpl) List(ctx context.Context, uid int64, owner int64) ([]domain.Collection, error) {
+	return s.repo.ListByUser(ctx, owner, uid != owner)
+}
+
+func (s *CollectionServiceImpl) ListArticles(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error) {
+	owner := uid
+	if cid > 0 {
+		c, err := s.checkVisible(ctx, uid, cid, true)
+		if err != nil {
+			return nil, err
+		}
+		owner = c.Uid
+	}
+	items, err := s.repo.ListItems(ctx, owner, cid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		if item.Biz != articleBiz {
+			continue
+		}
+		items[i].Article, err = s.article(ctx, item.BizId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// article 和读者看到的一样，只有已发表的文章才有标题和摘要。
+// 这里直接查 repository，ArticleService.GetPubById 会记一次阅读
+func (s *CollectionServiceImpl) article(ctx context.Context, aid int64) (domain.Article, error) {
+	art, err := s.artRepo.GetPubById(ctx, aid)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		// 彻底删除了
+		return domain.Article{Id: aid, Status: domain.ArticleStatusDeleted}, nil
+	}
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if art.Status != domain.ArticleStatusPublished {
+		return domain.Article{Id: aid, Status: art.Status}, nil
+	}
+	return domain.Article{
+		Id:      art.Id,
+		Title:   art.Title,
+		Summary: art.Abstract(),
+		Author:  art.Author,
+		Status:  art.Status,
+		Utime:   art.Utime,
+	}, nil
+}
+
+// checkVisible 别人的收藏夹当成不存在，allowPublic 是 true 的时候别人公开的收藏夹也可以
+func (s *CollectionServiceImpl) checkVisible(ctx context.Context, uid int64, id int64, allowPublic bool) (domain.Collection, error) {
+	c, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return domain.Collection{}, s.wrapNotFound(err)
+	}
+	if c.Uid != uid && !(allowPublic && c.Public) {
+		return domain.Collection{}, ErrCollectionNotFound
+	}
+	return c, nil
+}
+
+func (s *CollectionServiceImpl) wrapNotFound(err error) error {
+	if errors.Is(err, repository.ErrCollectionNotFound) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
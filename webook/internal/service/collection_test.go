@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestCollectionServiceImpl_ListArticles(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CollectionRepository, repository.ArticleRepository)
+		uid  int64
+		cid  int64
+
+		wantItems []domain.CollectionItem
+		wantErr   error
+	}{
+		{
+			name: "默认收藏夹，撤回和删除了的文章只有状态",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().ListItems(gomock.Any(), int64(123), int64(0), 0, 10).Return([]domain.CollectionItem{
+					{Biz: "article", BizId: 1, Ctime: now},
+					{Biz: "article", BizId: 2, Ctime: now},
+					{Biz: "article", BizId: 3, Ctime: now},
+				}, nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id:      1,
+					Title:   "标题",
+					Content: "内容",
+					Author:  domain.Author{Id: 456, Name: "Tom"},
+					Status:  domain.ArticleStatusPublished,
+					Utime:   now,
+				}, nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(2)).Return(domain.Article{
+					Id:     2,
+					Title:  "撤回了",
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(3)).Return(domain.Article{}, repository.ErrArticleNotFound)
+				return repo, artRepo
+			},
+			uid: 123,
+			wantItems: []domain.CollectionItem{
+				{
+					Biz:   "article",
+					BizId: 1,
+					Ctime: now,
+					Article: domain.Article{
+						Id:      1,
+						Title:   "标题",
+						Summary: "内容",
+						Author:  domain.Author{Id: 456, Name: "Tom"},
+						Status:  domain.ArticleStatusPublished,
+						Utime:   now,
+					},
+				},
+				{
+					Biz:     "article",
+					BizId:   2,
+					Ctime:   now,
+					Article: domain.Article{Id: 2, Status: domain.ArticleStatusPrivate},
+				},
+				{
+					Biz:     "article",
+					BizId:   3,
+					Ctime:   now,
+					Article: domain.Article{Id: 3, Status: domain.ArticleStatusDeleted},
+				},
+			},
+		},
+		{
+			name: "别人公开的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Collection{Id: 11, Uid: 456, Public: true}, nil)
+				repo.EXPECT().ListItems(gomock.Any(), int64(456), int64(11), 0, 10).
+					Return([]domain.CollectionItem{}, nil)
+				return repo, artRepo
+			},
+			uid:       123,
+			cid:       11,
+			wantItems: []domain.CollectionItem{},
+		},
+		{
+			name: "别人私有的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Collection{Id: 11, Uid: 456}, nil)
+				return repo, artRepo
+			},
+			uid:     123,
+			cid:     11,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "收藏夹不存在",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(11)).
+					Return(domain.Collection{}, repository.ErrCollectionNotFound)
+				return repo, artRepo
+			},
+			uid:     123,
+			cid:     11,
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewCollectionService(repo, artRepo, logger.NewNopLogger())
+			items, err := svc.ListArticles(context.Background(), tc.uid, tc.cid, 0, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantItems, items)
+		})
+	}
+}
+
+func TestCollectionServiceImpl_DeleteAndMove(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.CollectionRepository
+		to   int64
+
+		wantErr error
+	}{
+		{
+			name: "移到默认收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().DeleteAndMove(gomock.Any(), int64(11), int64(123), int64(0)).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "移到自己的收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(12)).Return(domain.Collection{Id: 12, Uid: 123}, nil)
+				repo.EXPECT().DeleteAndMove(gomock.Any(), int64(11), int64(123), int64(12)).Return(nil)
+				return repo
+			},
+			to: 12,
+		},
+		{
+			name: "不能移到别人公开的收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(12)).Return(domain.Collection{Id: 12, Uid: 456, Public: true}, nil)
+				return repo
+			},
+			to:      12,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "不能移到要删除的收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				return repomocks.NewMockCollectionRepository(ctrl)
+			},
+			to:      11,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "要删除的收藏夹不是自己的",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().DeleteAndMove(gomock.Any(), int64(11), int64(123), int64(0)).
+					Return(repository.ErrCollectionNotFound)
+				return repo
+			},
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCollectionService(tc.mock(ctrl), nil, logger.NewNopLogger())
+			err := svc.DeleteAndMove(context.Background(), 123, 11, tc.to)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestInteractiveServiceImpl_Collect(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository)
+		cid  int64
+
+		wantErr error
+	}{
+		{
+			name: "收藏到默认收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(0), int64(123)).Return(nil)
+				return repo, repomocks.NewMockCollectionRepository(ctrl)
+			},
+		},
+		{
+			name: "收藏到自己的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				collectionRepo := repomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Collection{Id: 11, Uid: 123}, nil)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(11), int64(123)).Return(nil)
+				return repo, collectionRepo
+			},
+			cid: 11,
+		},
+		{
+			name: "别人的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				collectionRepo := repomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Collection{Id: 11, Uid: 456, Public: true}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), collectionRepo
+			},
+			cid:     11,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "收藏夹不存在",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				collectionRepo := repomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().GetById(gomock.Any(), int64(11)).Return(domain.Collection{}, repository.ErrCollectionNotFound)
+				return repomocks.NewMockInteractiveRepository(ctrl), collectionRepo
+			},
+			cid:     11,
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, collectionRepo := tc.mock(ctrl)
+			svc := NewInteractiveServiceImpl(repo, collectionRepo, existBizRegistry())
+			err := svc.Collect(context.Background(), "article", 1, tc.cid, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/logger"
+)
+
+var (
+	ErrCommentInvalid      = errors.New("评论内容不合法")
+	ErrCommentNotFound     = errors.New("评论不存在")
+	ErrCommentBizNotFound  = errors.New("评论的资源不存在")
+	ErrCommentAccessDenied = errors.New("没有权限删除这条评论")
+)
+
+type CommentService interface {
+	// Create ParentId 不是 0 的时候就是回复，返回新评论的 id
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	// Delete 评论的人和资源的作者都可以删除，删除根评论的时候回复也一起删掉
+	Delete(ctx context.Context, uid int64, id int64) error
+	// ListRoots 根评论带上回复数量，新的在前面
+	ListRoots(ctx context.Context, biz string, bizId int64, offset int, limit int) ([]domain.Comment, error)
+	ListReplies(ctx context.Context, rid int64, offset int, limit int) ([]domain.Comment, error)
+}
+
+type CommentServiceImpl struct {
+	repo     repository.CommentRepository
+	artRepo  repository.ArticleRepository
+	userRepo repository.UserRepository
+	l        logger.LoggerV1
+}
+
+func NewCommentService(repo repository.CommentRepository,
+	artRepo repository.ArticleRepository,
+	userRepo repository.UserRepository,
+	l logger.LoggerV1) CommentService {
+	return &CommentServiceImpl{
+		repo:     repo,
+		artRepo:  artRepo,
+		userRepo: userRepo,
+		l:        l,
+	}
+}
+
+func (s *CommentServiceImpl) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	content, ok := domain.NormalizeCommentContent(c.Content)
+	if !ok {
+		return 0, ErrCommentInvalid
+	}
+	c.Content = content
+	_, visible, err := s.bizOwner(ctx, c.Biz, c.BizId)
+	if err != nil {
+		return 0, err
+	}
+	if !visible {
+		return 0, ErrCommentBizNotFound
+	}
+	c.RootId = 0
+	if !c.IsRoot() {
+		parent, err := s.findById(ctx, c.ParentId)
+		if err != nil {
+			return 0, err
+		}
+		if parent.Biz != c.Biz || parent.BizId != c.BizId {
+			return 0, ErrCommentNotFound
+		}
+		// 回复的回复也挂在根评论下面
+		c.RootId = parent.RootId
+		if parent.IsRoot() {
+			c.RootId = parent.Id
+		}
+	}
+	id, err := s.repo.Create(ctx, c)
+	if errors.Is(err, repository.ErrCommentNotFound) {
+		// 根评论刚好被删掉了
+		return 0, ErrCommentNotFound
+	}
+	return id, err
+}
+
+func (s *CommentServiceImpl) Delete(ctx context.Context, uid int64, id int64) error {
+	c, err := s.findById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Commentator.Id != uid {
+		owner, _, err := s.bizOwner(ctx, c.Biz, c.BizId)
+		if err != nil {
+			return err
+		}
+		if owner != uid {
+			return ErrCommentAccessDenied
+		}
+	}
+	err = s.repo.Delete(ctx, c)
+	if errors.Is(err, repository.ErrCommentNotFound) {
+		return ErrCommentNotFound
+	}
+	return err
+}
+
+func (s *CommentServiceImpl) ListRoots(ctx context.Context, biz string, bizId int64, offset int, limit int) ([]domain.Comment, error) {
+	_, visible, err := s.bizOwner(ctx, biz, bizId)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrCommentBizNotFound
+	}
+	cs, err := s.repo.ListRoots(ctx, biz, bizId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	s.fillNames(ctx, cs)
+	return cs, nil
+}
+
+func (s *CommentServiceImpl) ListReplies(ctx context.Context, rid int64, offset int, limit int) ([]domain.Comment, error) {
+	root, err := s.findById(ctx, rid)
+	if err != nil {
+		return nil, err
+	}
+	if !root.IsRoot() {
+		return nil, ErrCommentNotFound
+	}
+	_, visible, err := s.bizOwner(ctx, root.Biz, root.BizId)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrCommentBizNotFound
+	}
+	cs, err := s.repo.ListReplies(ctx, rid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	s.fillNames(ctx, cs)
+	return cs, nil
+}
+
+func (s *CommentServiceImpl) findById(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := s.repo.FindById(ctx, id)
+	if errors.Is(err, repository.ErrCommentNotFound) {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	return c, err
+}
+
+// bizOwner 资源的作者，visible 代表读者能不能看到这个资源，看不到的资源不能评论。
+// 目前只有文章可以评论
+func (s *CommentServiceImpl) bizOwner(ctx context.Context, biz string, bizId int64) (int64, bool, error) {
+	switch biz {
+	case "article":
+		art, err := s.artRepo.GetPubById(ctx, bizId)
+		if errors.Is(err, repository.ErrArticleNotFound) {
+			return 0, false, ErrCommentBizNotFound
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		return art.Author.Id, art.Status == domain.ArticleStatusPublished, nil
+	default:
+		return 0, false, ErrCommentBizNotFound
+	}
+}
+
+// fillNames 查不到名字只是展示不了，不影响评论
+func (s *CommentServiceImpl) fillNames(ctx context.Context, cs []domain.Comment) {
+	// 同一个人的多条评论只查一次
+	names := make(map[int64]string, len(cs))
+	for i := range cs {
+		uid := cs[i].Commentator.Id
+		name, ok := names[uid]
+		if !ok {
+			u, err := s.userRepo.FindById(ctx, uid)
+			if err != nil {
+				s.l.Warn("查询评论人的名字失败",
+					logger.Int64("uid", uid),
+					logger.Error(err))
+			}
+			name = u.NickName
+			names[uid] = name
+		}
+		cs[i].Commentator.Name = name
+	}
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+)
+
+func TestCommentServiceImpl_Create(t *testing.T) {
+	pubArt := domain.Article{Id: 11, Author: domain.Author{Id: 123}, Status: domain.ArticleStatusPublished}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository)
+		c    domain.Comment
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "发表根评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(pubArt, nil)
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{
+					Biz:         "article",
+					BizId:       11,
+					Commentator: domain.Author{Id: 456},
+					Content:     "写得好",
+				}).Return(int64(1), nil)
+				return repo, artRepo
+			},
+			c: domain.Comment{
+				Biz:         "article",
+				BizId:       11,
+				Commentator: domain.Author{Id: 456},
+				Content:     "  写得好\n",
+			},
+			wantId: 1,
+		},
+		{
+			name: "回复的回复挂在根评论下面",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(pubArt, nil)
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.Comment{
+					Id:       2,
+					Biz:      "article",
+					BizId:    11,
+					ParentId: 1,
+					RootId:   1,
+				}, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{
+					Biz:         "article",
+					BizId:       11,
+					Commentator: domain.Author{Id: 456},
+					Content:     "同意",
+					ParentId:    2,
+					RootId:      1,
+				}).Return(int64(3), nil)
+				return repo, artRepo
+			},
+			c: domain.Comment{
+				Biz:         "article",
+				BizId:       11,
+				Commentator: domain.Author{Id: 456},
+				Content:     "同意",
+				ParentId:    2,
+			},
+			wantId: 3,
+		},
+		{
+			name: "回复别的文章的评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(pubArt, nil)
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.Comment{
+					Id:    2,
+					Biz:   "article",
+					BizId: 12,
+				}, nil)
+				return repo, artRepo
+			},
+			c: domain.Comment{
+				Biz:         "article",
+				BizId:       11,
+				Commentator: domain.Author{Id: 456},
+				Content:     "同意",
+				ParentId:    2,
+			},
+			wantErr: ErrCommentNotFound,
+		},
+		{
+			name: "评论太长",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				return repomocks.NewMockCommentRepository(ctrl), repomocks.NewMockArticleRepository(ctrl)
+			},
+			c: domain.Comment{
+				Biz:     "article",
+				BizId:   11,
+				Content: strings.Repeat("字", domain.CommentMaxLength+1),
+			},
+			wantErr: ErrCommentInvalid,
+		},
+		{
+			name: "文章已经撤回",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				return repomocks.NewMockCommentRepository(ctrl), artRepo
+			},
+			c: domain.Comment{
+				Biz:     "article",
+				BizId:   11,
+				Content: "写得好",
+			},
+			wantErr: ErrCommentBizNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewCommentService(repo, artRepo, repomocks.NewMockUserRepository(ctrl), logger.NewNopLogger())
+			id, err := svc.Create(context.Background(), tc.c)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestCommentServiceImpl_Delete(t *testing.T) {
+	comment := domain.Comment{
+		Id:          1,
+		Biz:         "article",
+		BizId:       11,
+		Commentator: domain.Author{Id: 456},
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository)
+		uid  int64
+
+		wantErr error
+	}{
+		{
+			name: "删除自己的评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(comment, nil)
+				repo.EXPECT().Delete(gomock.Any(), comment).Return(nil)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			uid: 456,
+		},
+		{
+			name: "文章作者删除评论，撤回了也可以删",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(comment, nil)
+				repo.EXPECT().Delete(gomock.Any(), comment).Return(nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				return repo, artRepo
+			},
+			uid: 123,
+		},
+		{
+			name: "别人的评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(comment, nil)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(domain.Article{
+					Id:     11,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPublished,
+				}, nil)
+				return repo, artRepo
+			},
+			uid:     789,
+			wantErr: ErrCommentAccessDenied,
+		},
+		{
+			name: "评论不存在",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.Comment{}, repository.ErrCommentNotFound)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			uid:     456,
+			wantErr: ErrCommentNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewCommentService(repo, artRepo, repomocks.NewMockUserRepository(ctrl), logger.NewNopLogger())
+			err := svc.Delete(context.Background(), tc.uid, 1)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCommentServiceImpl_ListRoots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	artRepo := repomocks.NewMockArticleRepository(ctrl)
+	artRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).Return(domain.Article{
+		Id:     11,
+		Author: domain.Author{Id: 123},
+		Status: domain.ArticleStatusPublished,
+	}, nil)
+	repo := repomocks.NewMockCommentRepository(ctrl)
+	repo.EXPECT().ListRoots(gomock.Any(), "article", int64(11), 0, 10).Return([]domain.Comment{
+		{Id: 3, Commentator: domain.Author{Id: 456}},
+		{Id: 2, Commentator: domain.Author{Id: 789}},
+		{Id: 1, Commentator: domain.Author{Id: 456}},
+	}, nil)
+	userRepo := repomocks.NewMockUserRepository(ctrl)
+	// 同一个人的评论只查一次名字
+	userRepo.EXPECT().FindById(gomock.Any(), int64(456)).Return(domain.User{NickName: "Tom"}, nil)
+	userRepo.EXPECT().FindById(gomock.Any(), int64(789)).Return(domain.User{}, errors.New("mock db error"))
+
+	svc := NewCommentService(repo, artRepo, userRepo, logger.NewNopLogger())
+	cs, err := svc.ListRoots(context.Background(), "article", 11, 0, 10)
+	require.NoError(t, err)
+	names := make([]string, 0, len(cs))
+	for _, c := range cs {
+		names = append(names, c.Commentator.Name)
+	}
+	assert.Equal(t, []string{"Tom", "", "Tom"}, names)
+}
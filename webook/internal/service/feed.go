@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/logger"
+)
+
+var ErrFeedAuthorNotFound = errors.New("订阅的作者不存在")
+
+// FeedService 给 RSS 和 Atom 阅读器用的订阅源，只有已发表的文章
+type FeedService interface {
+	AuthorFeed(ctx context.Context, uid int64) (domain.Feed, error)
+	// LatestFeed 全站最新发表的文章
+	LatestFeed(ctx context.Context) (domain.Feed, error)
+}
+
+type FeedServiceImpl struct {
+	repo     repository.ArticleRepository
+	userRepo repository.UserRepository
+	l        logger.LoggerV1
+}
+
+func NewFeedService(repo repository.ArticleRepository,
+	userRepo repository.UserRepository,
+	l logger.LoggerV1) FeedService {
+	return &FeedServiceImpl{
+		repo:     repo,
+		userRepo: userRepo,
+		l:        l,
+	}
+}
+
+func (s *FeedServiceImpl) AuthorFeed(ctx context.Context, uid int64) (domain.Feed, error) {
+	u, err := s.userRepo.FindById(ctx, uid)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return domain.Feed{}, ErrFeedAuthorNotFound
+	}
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	arts, err := s.repo.ListFeed(ctx, uid)
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	author := domain.Author{Id: u.Id, Name: u.NickName}
+	for i := range arts {
+		arts[i].Author = author
+	}
+	return domain.NewFeed(author, arts), nil
+}
+
+func (s *FeedServiceImpl) LatestFeed(ctx context.Context) (domain.Feed, error) {
+	arts, err := s.repo.ListFeed(ctx, 0)
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	s.fillNames(ctx, arts)
+	return domain.NewFeed(domain.Author{}, arts), nil
+}
+
+// fillNames 同一个作者只查一次，查不到名字只是展示不了，不影响订阅
+func (s *FeedServiceImpl) fillNames(ctx context.Context, arts []domain.Article) {
+	names := make(map[int64]string, len(arts))
+	for i := range arts {
+		uid := arts[i].Author.Id
+		name, ok := names[uid]
+		if !ok {
+			u, err := s.userRepo.FindById(ctx, uid)
+			if err != nil {
+				s.l.Warn("查询作者的名字失败",
+					logger.Int64("uid", uid),
+					logger.Error(err))
+			}
+			name = u.NickName
+			names[uid] = name
+		}
+		arts[i].Author.Name = name
+	}
+}
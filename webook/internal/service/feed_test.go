@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestFeedServiceImpl_AuthorFeed(t *testing.T) {
+	utime := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.UserRepository)
+		uid  int64
+
+		wantFeed domain.Feed
+		wantErr  error
+	}{
+		{
+			name: "作者的文章带上名字",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.UserRepository) {
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(123)).
+					Return(domain.User{Id: 123, NickName: "大明"}, nil)
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().ListFeed(gomock.Any(), int64(123)).Return([]domain.Article{
+					{Id: 2, Title: "新的", Author: domain.Author{Id: 123}, Utime: utime},
+					{Id: 1, Title: "旧的", Author: domain.Author{Id: 123}, Utime: utime.Add(-time.Hour)},
+				}, nil)
+				return repo, userRepo
+			},
+			uid: 123,
+			wantFeed: domain.Feed{
+				Author: domain.Author{Id: 123, Name: "大明"},
+				Articles: []domain.Article{
+					{Id: 2, Title: "新的", Author: domain.Author{Id: 123, Name: "大明"}, Utime: utime},
+					{Id: 1, Title: "旧的", Author: domain.Author{Id: 123, Name: "大明"}, Utime: utime.Add(-time.Hour)},
+				},
+				Utime: utime,
+			},
+		},
+		{
+			name: "作者不存在",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.UserRepository) {
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(123)).
+					Return(domain.User{}, repository.ErrUserNotFound)
+				return repomocks.NewMockArticleRepository(ctrl), userRepo
+			},
+			uid:     123,
+			wantErr: ErrFeedAuthorNotFound,
+		},
+		{
+			name: "查询文章失败",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.UserRepository) {
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(123)).
+					Return(domain.User{Id: 123, NickName: "大明"}, nil)
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().ListFeed(gomock.Any(), int64(123)).Return(nil, errors.New("db 错误"))
+				return repo, userRepo
+			},
+			uid:     123,
+			wantErr: errors.New("db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, userRepo := tc.mock(ctrl)
+			svc := NewFeedService(repo, userRepo, logger.NewNopLogger())
+			f, err := svc.AuthorFeed(context.Background(), tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantFeed, f)
+		})
+	}
+}
+
+func TestFeedServiceImpl_LatestFeed(t *testing.T) {
+	utime := time.UnixMilli(1700000000000)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockArticleRepository(ctrl)
+	repo.EXPECT().ListFeed(gomock.Any(), int64(0)).Return([]domain.Article{
+		{Id: 3, Author: domain.Author{Id: 123}, Utime: utime},
+		{Id: 2, Author: domain.Author{Id: 456}},
+		{Id: 1, Author: domain.Author{Id: 123}},
+	}, nil)
+	userRepo := repomocks.NewMockUserRepository(ctrl)
+	// 同一个作者只查一次，查不到名字也不影响
+	userRepo.EXPECT().FindById(gomock.Any(), int64(123)).Return(domain.User{Id: 123, NickName: "大明"}, nil)
+	userRepo.EXPECT().FindById(gomock.Any(), int64(456)).Return(domain.User{}, errors.New("redis 错误"))
+
+	svc := NewFeedService(repo, userRepo, logger.NewNopLogger())
+	f, err := svc.LatestFeed(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Feed{
+		Articles: []domain.Article{
+			{Id: 3, Author: domain.Author{Id: 123, Name: "大明"}, Utime: utime},
+			{Id: 2, Author: domain.Author{Id: 456}},
+			{Id: 1, Author: domain.Author{Id: 123, Name: "大明"}},
+		},
+		Utime: utime,
+	}, f)
+}
@@ -2,32 +2,63 @@ package service
 
 import (
 	"context"
+	"errors"
 	"geek-basic-go/webook/internal/domain"
 	"geek-basic-go/webook/internal/repository"
 	"golang.org/x/sync/errgroup"
 )
 
+var (
+	ErrCollectionItemNotFound = errors.New("没有收藏过")
+	ErrLikeSelf               = errors.New("不能给自己点赞")
+)
+
+// InteractiveService biz 必须在 BizRegistry 里面注册过，否则返回 ErrBizNotSupported。
+// 点赞和收藏还会校验资源存在，不存在返回 ErrBizNotFound；取消的时候资源可能已经删了，不校验
 type InteractiveService interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
+	// Like 不能给自己的资源点赞，返回 ErrLikeSelf
 	Like(ctx context.Context, biz string, id int64, uid int64) error
 	CancelLike(ctx context.Context, biz string, id int64, uid int64) error
+	// Collect cid 是 0 代表默认收藏夹，否则必须是自己的收藏夹。收藏自己的资源是允许的
 	Collect(ctx context.Context, biz string, id int64, cid int64, uid int64) error
+	// CancelCollect 不管在哪个收藏夹里面都会取消，没有收藏过返回 ErrCollectionItemNotFound
+	CancelCollect(ctx context.Context, biz string, id int64, uid int64) error
+	// Get uid 是 0 代表没有登录，Liked 和 Collected 都是 false
 	Get(ctx context.Context, biz string, id int64, uid int64) (domain.Interactive, error)
+	// GetByIds 列表页用的，批量查询计数、点赞和收藏，结果里面每个 id 都有
+	GetByIds(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]domain.Interactive, error)
 }
 
 type InteractiveServiceImpl struct {
-	repo repository.InteractiveRepository
+	repo           repository.InteractiveRepository
+	collectionRepo repository.CollectionRepository
+	bizs           *BizRegistry
 }
 
-func NewInteractiveServiceImpl(repo repository.InteractiveRepository) InteractiveService {
-	return &InteractiveServiceImpl{repo: repo}
+func NewInteractiveServiceImpl(repo repository.InteractiveRepository,
+	collectionRepo repository.CollectionRepository,
+	bizs *BizRegistry) InteractiveService {
+	return &InteractiveServiceImpl{
+		repo:           repo,
+		collectionRepo: collectionRepo,
+		bizs:           bizs,
+	}
 }
 
 func (i *InteractiveServiceImpl) Get(ctx context.Context, biz string, id int64, uid int64) (domain.Interactive, error) {
+	_, err := i.bizs.Get(biz)
+	if err != nil {
+		return domain.Interactive{}, err
+	}
 	intr, err := i.repo.Get(ctx, biz, id)
 	if err != nil {
 		return domain.Interactive{}, err
 	}
+	if uid <= 0 {
+		// 没有登录的读者，不需要查点赞和收藏
+		return intr, nil
+	}
 	var eg errgroup.Group
 	eg.Go(func() error {
 		var er error
@@ -43,18 +74,102 @@ func (i *InteractiveServiceImpl) Get(ctx context.Context, biz string, id int64,
 	return intr, eg.Wait()
 }
 
+func (i *InteractiveServiceImpl) GetByIds(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]domain.Interactive, error) {
+	_, err := i.bizs.Get(biz)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return map[int64]domain.Interactive{}, nil
+	}
+	var (
+		eg        errgroup.Group
+		intrs     map[int64]domain.Interactive
+		liked     map[int64]bool
+		collected map[int64]bool
+	)
+	eg.Go(func() error {
+		var er error
+		intrs, er = i.repo.GetByIds(ctx, biz, ids)
+		return er
+	})
+	if uid > 0 {
+		eg.Go(func() error {
+			var er error
+			liked, er = i.repo.LikedByIds(ctx, biz, ids, uid)
+			return er
+		})
+		eg.Go(func() error {
+			var er error
+			collected, er = i.repo.CollectedByIds(ctx, biz, ids, uid)
+			return er
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	for id, intr := range intrs {
+		intr.Liked = liked[id]
+		intr.Collected = collected[id]
+		intrs[id] = intr
+	}
+	return intrs, nil
+}
+
 func (i *InteractiveServiceImpl) Collect(ctx context.Context, biz string, id int64, cid int64, uid int64) error {
+	err := i.bizs.Exists(ctx, biz, id)
+	if err != nil {
+		return err
+	}
+	if cid > 0 {
+		c, err := i.collectionRepo.GetById(ctx, cid)
+		if errors.Is(err, repository.ErrCollectionNotFound) || (err == nil && c.Uid != uid) {
+			return ErrCollectionNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return i.repo.AddCollectionItem(ctx, biz, id, cid, uid)
 }
 
+func (i *InteractiveServiceImpl) CancelCollect(ctx context.Context, biz string, id int64, uid int64) error {
+	_, err := i.bizs.Get(biz)
+	if err != nil {
+		return err
+	}
+	err = i.repo.DeleteCollectionItem(ctx, biz, id, uid)
+	if errors.Is(err, repository.ErrCollectionItemNotFound) {
+		return ErrCollectionItemNotFound
+	}
+	return err
+}
+
 func (i *InteractiveServiceImpl) Like(ctx context.Context, biz string, id int64, uid int64) error {
+	owner, err := i.bizs.Owner(ctx, biz, id)
+	if err != nil {
+		return err
+	}
+	// 自己给自己点赞会刷高计数，影响热榜
+	if owner == uid {
+		return ErrLikeSelf
+	}
 	return i.repo.IncrLike(ctx, biz, id, uid)
 }
 
 func (i *InteractiveServiceImpl) CancelLike(ctx context.Context, biz string, id int64, uid int64) error {
+	_, err := i.bizs.Get(biz)
+	if err != nil {
+		return err
+	}
 	return i.repo.DecrLike(ctx, biz, id, uid)
 }
 
 func (i *InteractiveServiceImpl) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	_, err := i.bizs.Get(biz)
+	if err != nil {
+		return err
+	}
 	return i.repo.IncrReadCnt(ctx, biz, bizId)
 }
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+)
+
+var (
+	ErrBizNotSupported = errors.New("不支持点赞、收藏的业务")
+	ErrBizNotFound     = errors.New("点赞、收藏的资源不存在")
+)
+
+// InteractiveBiz 可以点赞、收藏的业务，注册到 BizRegistry 里面
+type InteractiveBiz interface {
+	// Owner 资源的主人，资源不存在或者不能公开访问的时候返回 ErrBizNotFound
+	Owner(ctx context.Context, id int64) (int64, error)
+}
+
+// InteractiveBizFunc 用一个方法实现 InteractiveBiz
+type InteractiveBizFunc func(ctx context.Context, id int64) (int64, error)
+
+func (f InteractiveBizFunc) Owner(ctx context.Context, id int64) (int64, error) {
+	return f(ctx, id)
+}
+
+// BizRegistry 所有可以点赞、收藏的业务，没有注册的 biz 不能点赞、收藏，
+// 避免前端传错了 biz 凭空多出来计数
+type BizRegistry struct {
+	bizs map[string]InteractiveBiz
+}
+
+func NewBizRegistry() *BizRegistry {
+	return &BizRegistry{
+		bizs: map[string]InteractiveBiz{},
+	}
+}
+
+// Register 启动的时候注册，不是并发安全的。重复注册说明代码写错了，直接 panic
+func (r *BizRegistry) Register(biz string, b InteractiveBiz) {
+	if _, ok := r.bizs[biz]; ok {
+		panic(fmt.Sprintf("重复注册的业务 %s", biz))
+	}
+	r.bizs[biz] = b
+}
+
+// Get 没有注册的业务返回 ErrBizNotSupported
+func (r *BizRegistry) Get(biz string) (InteractiveBiz, error) {
+	b, ok := r.bizs[biz]
+	if !ok {
+		return nil, ErrBizNotSupported
+	}
+	return b, nil
+}
+
+// Owner 校验业务和资源都存在，返回资源的主人
+func (r *BizRegistry) Owner(ctx context.Context, biz string, id int64) (int64, error) {
+	b, err := r.Get(biz)
+	if err != nil {
+		return 0, err
+	}
+	return b.Owner(ctx, id)
+}
+
+// Exists 只校验业务和资源都存在，不关心资源的主人
+func (r *BizRegistry) Exists(ctx context.Context, biz string, id int64) error {
+	_, err := r.Owner(ctx, biz, id)
+	return err
+}
+
+// NewArticleInteractiveBiz 只有已发表的文章可以点赞、收藏
+func NewArticleInteractiveBiz(repo repository.ArticleRepository) InteractiveBiz {
+	return InteractiveBizFunc(func(ctx context.Context, id int64) (int64, error) {
+		art, err := repo.GetPubById(ctx, id)
+		if errors.Is(err, repository.ErrArticleNotFound) ||
+			(err == nil && art.Status != domain.ArticleStatusPublished) {
+			return 0, ErrBizNotFound
+		}
+		return art.Author.Id, err
+	})
+}
+
+func NewCommentInteractiveBiz(repo repository.CommentRepository) InteractiveBiz {
+	return InteractiveBizFunc(func(ctx context.Context, id int64) (int64, error) {
+		c, err := repo.FindById(ctx, id)
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return 0, ErrBizNotFound
+		}
+		return c.Commentator.Id, err
+	})
+}
+
+func NewArticleSeriesInteractiveBiz(repo repository.ArticleSeriesRepository) InteractiveBiz {
+	return InteractiveBizFunc(func(ctx context.Context, id int64) (int64, error) {
+		s, err := repo.GetById(ctx, id)
+		if errors.Is(err, repository.ErrArticleSeriesNotFound) {
+			return 0, ErrBizNotFound
+		}
+		return s.Author.Id, err
+	})
+}
+
+// NewUserInteractiveBiz 用户主页，主人就是用户自己
+func NewUserInteractiveBiz(repo repository.UserRepository) InteractiveBiz {
+	return InteractiveBizFunc(func(ctx context.Context, id int64) (int64, error) {
+		u, err := repo.FindById(ctx, id)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return 0, ErrBizNotFound
+		}
+		return u.Id, err
+	})
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestInteractiveServiceImpl_GetByIds(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+		uid  int64
+
+		wantIntrs map[int64]domain.Interactive
+		wantErr   error
+	}{
+		{
+			name: "登录了",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{1: {ReadCnt: 1}, 2: {ReadCnt: 2}}, nil)
+				repo.EXPECT().LikedByIds(gomock.Any(), "article", []int64{1, 2}, int64(123)).
+					Return(map[int64]bool{2: true}, nil)
+				repo.EXPECT().CollectedByIds(gomock.Any(), "article", []int64{1, 2}, int64(123)).
+					Return(map[int64]bool{1: true, 2: true}, nil)
+				return repo
+			},
+			uid: 123,
+			wantIntrs: map[int64]domain.Interactive{
+				1: {ReadCnt: 1, Collected: true},
+				2: {ReadCnt: 2, Liked: true, Collected: true},
+			},
+		},
+		{
+			name: "没有登录不查点赞和收藏",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{1: {ReadCnt: 1}, 2: {ReadCnt: 2}}, nil)
+				return repo
+			},
+			wantIntrs: map[int64]domain.Interactive{1: {ReadCnt: 1}, 2: {ReadCnt: 2}},
+		},
+		{
+			name: "查点赞失败",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{1: {ReadCnt: 1}, 2: {ReadCnt: 2}}, nil)
+				repo.EXPECT().LikedByIds(gomock.Any(), "article", []int64{1, 2}, int64(123)).
+					Return(nil, errors.New("mock db 错误"))
+				repo.EXPECT().CollectedByIds(gomock.Any(), "article", []int64{1, 2}, int64(123)).
+					Return(map[int64]bool{}, nil)
+				return repo
+			},
+			uid:     123,
+			wantErr: errors.New("mock db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewInteractiveServiceImpl(tc.mock(ctrl), nil, existBizRegistry())
+			intrs, err := svc.GetByIds(context.Background(), "article", []int64{1, 2}, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantIntrs, intrs)
+		})
+	}
+}
+
+func TestInteractiveServiceImpl_Like(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.ArticleRepository)
+		biz  string
+
+		wantErr error
+	}{
+		{
+			name: "点赞已发表的文章",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id:     1,
+					Author: domain.Author{Id: 456},
+					Status: domain.ArticleStatusPublished,
+				}, nil)
+				repo.EXPECT().IncrLike(gomock.Any(), "article", int64(1), int64(123)).Return(nil)
+				return repo, artRepo
+			},
+			biz: "article",
+		},
+		{
+			name: "给自己的文章点赞",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id:     1,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPublished,
+				}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), artRepo
+			},
+			biz:     "article",
+			wantErr: ErrLikeSelf,
+		},
+		{
+			name: "文章撤回了",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id:     1,
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), artRepo
+			},
+			biz:     "article",
+			wantErr: ErrBizNotFound,
+		},
+		{
+			name: "文章不存在",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				return repomocks.NewMockInteractiveRepository(ctrl), artRepo
+			},
+			biz:     "article",
+			wantErr: ErrBizNotFound,
+		},
+		{
+			name: "没有注册的业务",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.ArticleRepository) {
+				return repomocks.NewMockInteractiveRepository(ctrl), repomocks.NewMockArticleRepository(ctrl)
+			},
+			biz:     "articel",
+			wantErr: ErrBizNotSupported,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			bizs := NewBizRegistry()
+			bizs.Register("article", NewArticleInteractiveBiz(artRepo))
+			svc := NewInteractiveServiceImpl(repo, nil, bizs)
+			err := svc.Like(context.Background(), tc.biz, 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+// existBizRegistry 只注册了 article，而且文章都存在
+func existBizRegistry() *BizRegistry {
+	bizs := NewBizRegistry()
+	bizs.Register("article", InteractiveBizFunc(func(ctx context.Context, id int64) (int64, error) {
+		return 0, nil
+	}))
+	return bizs
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/queue"
+	"math"
+	"time"
+)
+
+// 分数里面的权重，收藏比点赞难得，点赞又比阅读难得
+const (
+	rankingReadWeight    = 0.1
+	rankingLikeWeight    = 1.0
+	rankingCollectWeight = 2.0
+	// rankingGravity 越大，分数随着时间衰减得越快
+	rankingGravity = 1.5
+)
+
+type RankingService interface {
+	// TopN 重新计算热榜。多个实例同时调用会重复计算，要放在分布式锁里面调用
+	TopN(ctx context.Context) error
+	// GetTopN 热榜还没有计算出来的时候返回空的
+	GetTopN(ctx context.Context) ([]domain.HotArticle, error)
+}
+
+// BatchRankingService 分批扫描最近发表的文章，用小顶堆留下分数最高的 n 篇
+type BatchRankingService struct {
+	artRepo  repository.ArticleRepository
+	intrRepo repository.InteractiveRepository
+	repo     repository.RankingRepository
+	// batchSize 每次查多少篇文章
+	batchSize int
+	n         int
+	// window 只看这段时间里面第一次发表的文章，更早的文章分数已经衰减得差不多了
+	window    time.Duration
+	scoreFunc func(intr domain.Interactive, pubTime time.Time, now time.Time) float64
+	l         logger.LoggerV1
+}
+
+func NewBatchRankingService(artRepo repository.ArticleRepository,
+	intrRepo repository.InteractiveRepository,
+	repo repository.RankingRepository,
+	l logger.LoggerV1) RankingService {
+	return &BatchRankingService{
+		artRepo:   artRepo,
+		intrRepo:  intrRepo,
+		repo:      repo,
+		batchSize: 100,
+		n:         100,
+		window:    7 * 24 * time.Hour,
+		scoreFunc: rankingScore,
+		l:         l,
+	}
+}
+
+func (s *BatchRankingService) GetTopN(ctx context.Context) ([]domain.HotArticle, error) {
+	arts, err := s.repo.GetTopN(ctx)
+	if errors.Is(err, repository.ErrRankingNotFound) {
+		return []domain.HotArticle{}, nil
+	}
+	return arts, err
+}
+
+func (s *BatchRankingService) TopN(ctx context.Context) error {
+	arts, err := s.topN(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repo.ReplaceTopN(ctx, arts)
+}
+
+func (s *BatchRankingService) topN(ctx context.Context) ([]domain.HotArticle, error) {
+	now := time.Now()
+	// 小顶堆，堆顶是留下来的文章里面分数最低的
+	topN := queue.NewConcurrentPriorityQueue[domain.HotArticle](s.n, func(src domain.HotArticle, dst domain.HotArticle) int {
+		switch {
+		case src.Score > dst.Score:
+			return 1
+		case src.Score < dst.Score:
+			return -1
+		default:
+			return 0
+		}
+	})
+	// 按照 id 翻页，扫描期间有文章发表或者重新发表也不会跳过或者重复
+	var maxId int64
+	for {
+		arts, err := s.artRepo.ListPubSince(ctx, now.Add(-s.window), maxId, s.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int64, 0, len(arts))
+		for _, art := range arts {
+			ids = append(ids, art.Id)
+		}
+		intrs, err := s.intrRepo.GetByIds(ctx, articleBiz, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, art := range arts {
+			maxId = art.Id
+			intr := intrs[art.Id]
+			item := domain.HotArticle{
+				Article: art,
+				Intr:    intr,
+				// 从第一次发表开始衰减，重新发表不能让老文章变成新文章
+				Score: s.scoreFunc(intr, art.Ctime, now),
+			}
+			if topN.Len() >= s.n {
+				// 满了，比堆顶的分数高才换进去
+				low, _ := topN.Peek()
+				if low.Score >= item.Score {
+					continue
+				}
+				_, _ = topN.Dequeue()
+			}
+			err = topN.Enqueue(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(arts) < s.batchSize {
+			break
+		}
+	}
+	// 出队的顺序是分数从低到高，倒过来放
+	res := make([]domain.HotArticle, topN.Len())
+	for i := len(res) - 1; i >= 0; i-- {
+		item, _ := topN.Dequeue()
+		res[i] = item
+	}
+	return s.fill(ctx, res), nil
+}
+
+// fill 补上作者的名字和摘要，计算期间被撤回或者删除的文章直接去掉
+func (s *BatchRankingService) fill(ctx context.Context, items []domain.HotArticle) []domain.HotArticle {
+	res := make([]domain.HotArticle, 0, len(items))
+	for _, item := range items {
+		art, err := s.artRepo.GetPubById(ctx, item.Article.Id)
+		if err != nil {
+			if !errors.Is(err, repository.ErrArticleNotFound) {
+				s.l.Warn("查询热榜文章失败",
+					logger.Int64("aid", item.Article.Id),
+					logger.Error(err))
+			}
+			continue
+		}
+		if art.Status != domain.ArticleStatusPublished {
+			continue
+		}
+		// 热榜只展示摘要
+		item.Article = domain.Article{
+			Id:      art.Id,
+			Title:   art.Title,
+			Summary: art.Abstract(),
+			Author:  art.Author,
+			Tags:    art.Tags,
+			Status:  art.Status,
+			Ctime:   art.Ctime,
+			Utime:   art.Utime,
+			Stats:   art.Stats,
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+// rankingScore 和 Hacker News 的算法类似：互动越多分数越高，发表越久分数越低
+func rankingScore(intr domain.Interactive, pubTime time.Time, now time.Time) float64 {
+	points := float64(intr.ReadCnt)*rankingReadWeight +
+		float64(intr.LikeCnt)*rankingLikeWeight +
+		float64(intr.CollectCnt)*rankingCollectWeight
+	hours := math.Max(now.Sub(pubTime).Hours(), 0)
+	return (points + 1) / math.Pow(hours+2, rankingGravity)
+}
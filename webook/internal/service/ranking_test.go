@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/domain"
+	repomocks "geek-basic-go/webook/internal/repository/mocks"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestBatchRankingService_TopN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Now()
+	artRepo := repomocks.NewMockArticleRepository(ctrl)
+	intrRepo := repomocks.NewMockInteractiveRepository(ctrl)
+	repo := repomocks.NewMockRankingRepository(ctrl)
+	// 分两批查出来，第二批不满说明查完了
+	artRepo.EXPECT().ListPubSince(gomock.Any(), gomock.Any(), int64(0), 2).
+		Return([]domain.Article{{Id: 1, Ctime: now}, {Id: 2, Ctime: now}}, nil)
+	artRepo.EXPECT().ListPubSince(gomock.Any(), gomock.Any(), int64(2), 2).
+		Return([]domain.Article{{Id: 3, Ctime: now}}, nil)
+	intrRepo.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2}).
+		Return(map[int64]domain.Interactive{1: {LikeCnt: 1}, 2: {LikeCnt: 3}}, nil)
+	// 没有互动数据的当成 0
+	intrRepo.EXPECT().GetByIds(gomock.Any(), "article", []int64{3}).
+		Return(map[int64]domain.Interactive{3: {}}, nil)
+	artRepo.EXPECT().GetPubById(gomock.Any(), int64(2)).Return(domain.Article{
+		Id:      2,
+		Title:   "Go 入门",
+		Content: "很长的内容",
+		Author:  domain.Author{Id: 123, Name: "Tom"},
+		Status:  domain.ArticleStatusPublished,
+	}, nil)
+	// 计算期间被撤回了
+	artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+		Id:     1,
+		Status: domain.ArticleStatusPrivate,
+	}, nil)
+	repo.EXPECT().ReplaceTopN(gomock.Any(), []domain.HotArticle{{
+		Article: domain.Article{
+			Id:      2,
+			Title:   "Go 入门",
+			Summary: "很长的内容",
+			Author:  domain.Author{Id: 123, Name: "Tom"},
+			Status:  domain.ArticleStatusPublished,
+		},
+		Intr:  domain.Interactive{LikeCnt: 3},
+		Score: 4,
+	}}).Return(nil)
+
+	svc := &BatchRankingService{
+		artRepo:   artRepo,
+		intrRepo:  intrRepo,
+		repo:      repo,
+		batchSize: 2,
+		n:         2,
+		window:    time.Hour,
+		scoreFunc: func(intr domain.Interactive, pubTime time.Time, now time.Time) float64 {
+			return float64(intr.LikeCnt + 1)
+		},
+		l: logger.NewNopLogger(),
+	}
+	err := svc.TopN(context.Background())
+	require.NoError(t, err)
+}
+
+func TestRankingScore(t *testing.T) {
+	now := time.Now()
+	// 同样的互动，越新分数越高
+	assert.Greater(t,
+		rankingScore(domain.Interactive{LikeCnt: 10}, now.Add(-time.Hour), now),
+		rankingScore(domain.Interactive{LikeCnt: 10}, now.Add(-24*time.Hour), now))
+	// 同样的时间，收藏比点赞分数高，点赞比阅读分数高
+	assert.Greater(t,
+		rankingScore(domain.Interactive{CollectCnt: 1}, now, now),
+		rankingScore(domain.Interactive{LikeCnt: 1}, now, now))
+	assert.Greater(t,
+		rankingScore(domain.Interactive{LikeCnt: 1}, now, now),
+		rankingScore(domain.Interactive{ReadCnt: 1}, now, now))
+	// 一天前的大量点赞还是比刚发表没有互动的分数高
+	assert.Greater(t,
+		rankingScore(domain.Interactive{LikeCnt: 100}, now.Add(-24*time.Hour), now),
+		rankingScore(domain.Interactive{}, now, now))
+}
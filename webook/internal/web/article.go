@@ -1,7 +1,10 @@
 package web
 
 import (
+	"encoding/base64"
+	"errors"
 	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/errs"
 	"geek-basic-go/webook/internal/service"
 	"geek-basic-go/webook/internal/web/jwt"
 	"geek-basic-go/webook/pkg/ginx"
@@ -11,24 +14,35 @@ import (
 	"golang.org/x/sync/errgroup"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type ArticleHandler struct {
-	svc     service.ArticleService
-	intrSvc service.InteractiveService
-	l       logger.LoggerV1
-	biz     string
+	svc       service.ArticleService
+	intrSvc   service.InteractiveService
+	collabSvc service.ArticleCollaboratorService
+	userSvc   service.UserService
+	seriesSvc service.ArticleSeriesService
+	l         logger.LoggerV1
+	biz       string
 }
 
 func NewArticleHandler(svc service.ArticleService,
 	intrSvc service.InteractiveService,
+	collabSvc service.ArticleCollaboratorService,
+	userSvc service.UserService,
+	seriesSvc service.ArticleSeriesService,
 	l logger.LoggerV1) *ArticleHandler {
 	return &ArticleHandler{
-		svc:     svc,
-		intrSvc: intrSvc,
-		l:       l,
-		biz:     "article",
+		svc:       svc,
+		intrSvc:   intrSvc,
+		collabSvc: collabSvc,
+		userSvc:   userSvc,
+		seriesSvc: seriesSvc,
+		l:         l,
+		biz:       "article",
 	}
 }
 
@@ -37,15 +51,33 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	g.POST("/edit", h.Edit)
 	g.POST("/publish", h.Publish)
 	g.POST("/withdraw", h.Withdraw)
+	g.POST("/delete", h.Delete)
 
 	// 创作者接口
 	// List接口，一般是GET的，形如list?offset=?&limit=?, 这里定义成post，然后通过body接收参数
 	g.POST("/list", h.List)
 	g.GET("/detail/:id", h.Detail)
+	// 历史版本
+	rev := g.Group("/revisions")
+	rev.POST("/list", h.ListRevisions)
+	rev.GET("/diff", h.DiffRevisions)
+	rev.POST("/restore", h.RestoreRevision)
+	// 定时发表
+	schedule := g.Group("/schedules")
+	schedule.POST("/list", h.ListSchedules)
+	schedule.POST("/cancel", h.CancelSchedule)
+	// 回收站
+	trash := g.Group("/trash")
+	trash.POST("/list", h.ListTrash)
+	trash.POST("/restore", h.RestoreTrash)
 	pub := g.Group("/pub")
 	pub.GET("/:id", h.PubDetail)
+	pub.GET("/tags/:tag", h.ListPubByTag)
+	pub.GET("/search", h.SearchPub)
+	pub.GET("/authors/:id", h.AuthorHome)
 	pub.POST("/like", h.Like)
 	pub.POST("/collect", h.Collect)
+	pub.POST("/uncollect", h.CancelCollect)
 }
 
 // Edit 返回article id
@@ -54,6 +86,12 @@ func (h *ArticleHandler) Edit(ctx *gin.Context) {
 		Id      int64  `json:"id"`
 		Title   string `json:"title"`
 		Content string `json:"content"`
+		// Tags 不传代表不修改标签
+		Tags []string `json:"tags"`
+		// Format 0 是纯文本，1 是 markdown
+		Format uint8 `json:"format"`
+		// Version 修改的时候必须带上读到的版本号
+		Version int64 `json:"version"`
 	}
 
 	var req Req
@@ -61,25 +99,74 @@ func (h *ArticleHandler) Edit(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	version, ok := checkVersion(req.Id, req.Version)
+	if !ok {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "缺少版本号",
+		})
+		return
+	}
+	tags, ok := domain.NormalizeArticleTags(req.Tags)
+	if !ok {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "标签不合法",
+		})
+		return
+	}
+	format := domain.ArticleFormat(req.Format)
+	if !format.Valid() {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "内容格式不合法",
+		})
+		return
+	}
 	uc := ctx.MustGet("user").(jwt.UserClaims)
-	id, err := h.svc.Save(ctx, domain.Article{
+	art := domain.Article{
 		Id:      req.Id,
 		Title:   req.Title,
 		Content: req.Content,
 		Author: domain.Author{
 			Id: uc.Uid,
 		},
-	})
+		Tags:    tags,
+		Format:  format,
+		Version: version,
+	}
+	id, err := h.svc.Save(ctx, art)
+	if errors.Is(err, service.ErrArticleVersionConflict) {
+		h.versionConflict(ctx, uc.Uid, req.Id)
+		return
+	}
+	if errors.Is(err, service.ErrArticleAccessDenied) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "没有权限修改这篇文章",
+		})
+		return
+	}
+	if errors.Is(err, service.ErrArticleInTrash) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "文章在回收站里面，请先恢复",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
-			Code: 5,
+			Code: errs.ArticleInternalServerError,
 			Msg:  "系统错误",
 		})
 		h.l.Error("保存文章失败", logger.Int64("uid", uc.Uid), logger.Error(err))
 		return
 	}
 	ctx.JSON(http.StatusOK, ginx.Result{
-		Data: id,
+		Data: ArticleSavedVo{
+			Id:      id,
+			Version: art.NextVersion(),
+		},
 	})
 }
 
@@ -88,6 +175,14 @@ func (h *ArticleHandler) Publish(ctx *gin.Context) {
 		Id      int64  `json:"id"`
 		Title   string `json:"title"`
 		Content string `json:"content"`
+		// PublishAt 定时发表的时间，毫秒时间戳，不传或者已经过了就是立刻发表
+		PublishAt int64 `json:"publishAt"`
+		// Tags 不传代表不修改标签
+		Tags []string `json:"tags"`
+		// Format 0 是纯文本，1 是 markdown
+		Format uint8 `json:"format"`
+		// Version 修改的时候必须带上读到的版本号
+		Version int64 `json:"version"`
 	}
 
 	var req Req
@@ -95,25 +190,83 @@ func (h *ArticleHandler) Publish(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	version, ok := checkVersion(req.Id, req.Version)
+	if !ok {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "缺少版本号",
+		})
+		return
+	}
+	tags, ok := domain.NormalizeArticleTags(req.Tags)
+	if !ok {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "标签不合法",
+		})
+		return
+	}
+	format := domain.ArticleFormat(req.Format)
+	if !format.Valid() {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "内容格式不合法",
+		})
+		return
+	}
 	uc := ctx.MustGet("user").(jwt.UserClaims)
-	id, err := h.svc.Publish(ctx, domain.Article{
+	art := domain.Article{
 		Id:      req.Id,
 		Title:   req.Title,
 		Content: req.Content,
 		Author: domain.Author{
 			Id: uc.Uid,
 		},
-	})
+		Tags:    tags,
+		Format:  format,
+		Version: version,
+	}
+	var (
+		id  int64
+		err error
+	)
+	publishAt := time.UnixMilli(req.PublishAt)
+	if req.PublishAt > 0 && publishAt.After(time.Now()) {
+		id, err = h.svc.SchedulePublish(ctx, art, publishAt)
+	} else {
+		id, err = h.svc.Publish(ctx, art)
+	}
+	if errors.Is(err, service.ErrArticleVersionConflict) {
+		h.versionConflict(ctx, uc.Uid, req.Id)
+		return
+	}
+	if errors.Is(err, service.ErrArticleAccessDenied) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "没有权限修改这篇文章",
+		})
+		return
+	}
+	if errors.Is(err, service.ErrArticleInTrash) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "文章在回收站里面，请先恢复",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
-			Code: 5,
+			Code: errs.ArticleInternalServerError,
 			Msg:  "系统错误",
 		})
 		h.l.Error("发表文章失败", logger.Int64("uid", uc.Uid), logger.Error(err))
 		return
 	}
 	ctx.JSON(http.StatusOK, ginx.Result{
-		Data: id,
+		Data: ArticleSavedVo{
+			Id:      id,
+			Version: art.NextVersion(),
+		},
 	})
 }
 
@@ -128,6 +281,13 @@ func (h *ArticleHandler) Withdraw(ctx *gin.Context) {
 	}
 	uc := ctx.MustGet("user").(jwt.UserClaims)
 	err := h.svc.Withdraw(ctx, uc.Uid, req.Id)
+	if errors.Is(err, service.ErrArticleAccessDenied) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "没有权限撤回这篇文章",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -145,32 +305,179 @@ func (h *ArticleHandler) Withdraw(ctx *gin.Context) {
 	})
 }
 
-func (h *ArticleHandler) List(ctx *gin.Context) {
+// Delete 移到回收站，保留期限之内可以恢复
+func (h *ArticleHandler) Delete(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Trash(ctx, uc.Uid, req.Id)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Msg: "OK",
+		})
+	case errors.Is(err, service.ErrArticleAccessDenied):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在或者已经删除",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("删除文章失败",
+			logger.Error(err),
+			logger.Int64("uid", uc.Uid),
+			logger.Int64("aid", req.Id))
+	}
+}
+
+func (h *ArticleHandler) ListTrash(ctx *gin.Context) {
 	var page Page
 	if err := ctx.Bind(&page); err != nil {
 		return
 	}
+	if !checkPage(ctx, page) {
+		return
+	}
 	uc := ctx.MustGet("user").(jwt.UserClaims)
-	arts, err := h.svc.GetByAuthor(ctx, uc.Uid, page.Offset, page.Limit)
+	arts, err := h.svc.ListTrash(ctx, uc.Uid, page.Offset, page.Limit)
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
 			Msg:  "系统错误",
 		})
-		h.l.Error("查找文章列表失败",
+		h.l.Error("查找回收站失败",
 			logger.Error(err),
-			logger.Int("offset", page.Offset),
-			logger.Int("limit", page.Limit),
 			logger.Int64("uid", uc.Uid))
 		return
 	}
 	ctx.JSON(http.StatusOK, ginx.Result{
 		Data: slice.Map[domain.Article, ArticleVo](arts, func(idx int, src domain.Article) ArticleVo {
-			return toVo(src)
+			vo := toVo(src)
+			vo.Dtime = src.Dtime.Format(time.DateTime)
+			vo.PurgeTime = src.Dtime.Add(service.ArticleTrashRetention).Format(time.DateTime)
+			return vo
 		}),
 	})
 }
 
+// RestoreTrash 恢复成草稿，返回 article id
+func (h *ArticleHandler) RestoreTrash(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Restore(ctx, uc.Uid, req.Id)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Data: req.Id,
+		})
+	case errors.Is(err, service.ErrArticleAccessDenied),
+		errors.Is(err, service.ErrArticleNotInTrash):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不在回收站里面或者已经过期",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("恢复文章失败",
+			logger.Error(err),
+			logger.Int64("uid", uc.Uid),
+			logger.Int64("aid", req.Id))
+	}
+}
+
+func (h *ArticleHandler) List(ctx *gin.Context) {
+	type Req struct {
+		// Cursor 上一次返回的游标，不传代表第一页
+		Cursor string `json:"cursor"`
+		Limit  int    `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	cursor, err := decodeArticleCursor(req.Cursor)
+	if err != nil || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "分页参数错误",
+		})
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	arts, err := h.svc.GetByAuthor(ctx, uc.Uid, cursor, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查找文章列表失败",
+			logger.Error(err),
+			logger.String("cursor", req.Cursor),
+			logger.Int("limit", req.Limit),
+			logger.Int64("uid", uc.Uid))
+		return
+	}
+	res := ArticleListVo{
+		List: h.toVosWithIntr(ctx, arts, uc.Uid),
+	}
+	// 不满一页说明没有下一页了
+	if len(arts) == req.Limit {
+		res.Cursor = encodeArticleCursor(domain.CursorOf(arts[len(arts)-1]))
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: res,
+	})
+}
+
+// encodeArticleCursor 游标对前端是不透明的，前端原样传回来就可以
+func encodeArticleCursor(c domain.ArticleCursor) string {
+	raw := strconv.FormatInt(c.Utime.UnixMilli(), 10) + "_" + strconv.FormatInt(c.Id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeArticleCursor(s string) (domain.ArticleCursor, error) {
+	if s == "" {
+		return domain.ArticleCursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return domain.ArticleCursor{}, err
+	}
+	utimeStr, idStr, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return domain.ArticleCursor{}, errors.New("游标格式不对")
+	}
+	utime, err := strconv.ParseInt(utimeStr, 10, 64)
+	if err != nil {
+		return domain.ArticleCursor{}, err
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		return domain.ArticleCursor{}, errors.New("游标格式不对")
+	}
+	return domain.ArticleCursor{
+		Utime: time.UnixMilli(utime),
+		Id:    id,
+	}, nil
+}
+
 func toVo(art domain.Article) ArticleVo {
 	return ArticleVo{
 		Id:       art.Id,
@@ -180,11 +487,28 @@ func toVo(art domain.Article) ArticleVo {
 		AuthorId:   art.Author.Id,
 		AuthorName: art.Author.Name,
 		Status:     art.Status.ToUint8(),
+		Tags:       art.Tags,
+		Format:     art.Format.ToUint8(),
+		Version:    art.Version,
 		Ctime:      art.Ctime.Format(time.DateTime),
 		Utime:      art.Utime.Format(time.DateTime),
+
+		WordCnt:      art.Stats.WordCnt,
+		ReadMinutes:  art.Stats.ReadMinutes,
+		ImageCnt:     art.Stats.ImageCnt,
+		CodeBlockCnt: art.Stats.CodeBlockCnt,
 	}
 }
 
+func toAuthorVos(authors []domain.Author) []ArticleAuthorVo {
+	return slice.Map[domain.Author, ArticleAuthorVo](authors, func(idx int, src domain.Author) ArticleAuthorVo {
+		return ArticleAuthorVo{
+			Id:   src.Id,
+			Name: src.Name,
+		}
+	})
+}
+
 func (h *ArticleHandler) Detail(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -198,42 +522,98 @@ func (h *ArticleHandler) Detail(ctx *gin.Context) {
 			logger.String("id", idStr))
 		return
 	}
-	art, err := h.svc.GetById(ctx, id)
-	if err != nil {
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	// 作者和协作者都可以看草稿
+	art, err := h.svc.GetDraft(ctx, uc.Uid, id)
+	if errors.Is(err, service.ErrArticleAccessDenied) {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
 			Msg:  "系统错误",
 		})
-		h.l.Error("查找文章失败",
+		h.l.Error("非法查询文章",
 			logger.Error(err),
-			logger.Int64("id", id))
+			logger.Int64("id", id),
+			logger.Int64("uid", uc.Uid))
 		return
 	}
-	uc := ctx.MustGet("user").(jwt.UserClaims)
-	if art.Author.Id != uc.Uid {
+	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
 			Msg:  "系统错误",
 		})
-		h.l.Error("非法查询文章",
+		h.l.Error("查找文章失败",
 			logger.Error(err),
-			logger.Int64("id", id),
-			logger.Int64("uid", uc.Uid))
+			logger.Int64("id", id))
 		return
 	}
-	artVo := ArticleVo{
+	vo := toDraftVo(art)
+	// 审核结果查不到不影响看草稿
+	r, err := h.svc.GetReview(ctx, uc.Uid, id)
+	switch {
+	case err == nil:
+		vo.Review = &ArticleReviewVo{
+			Status: r.Status.ToUint8(),
+			Hits:   r.Hits,
+			Reason: r.Reason,
+			Utime:  r.Utime.Format(time.DateTime),
+		}
+	case !errors.Is(err, service.ErrArticleReviewNotFound):
+		h.l.Error("查找文章的审核结果失败",
+			logger.Error(err),
+			logger.Int64("id", id))
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: vo,
+	})
+}
+
+// toDraftVo 作者看到的草稿，带上完整的内容和版本号
+func toDraftVo(art domain.Article) ArticleVo {
+	return ArticleVo{
 		Id:    art.Id,
 		Title: art.Title,
 		//Abstract: art.Abstract(),
 		Content:  art.Content,
 		AuthorId: art.Author.Id,
 		Status:   art.Status.ToUint8(),
+		Tags:     art.Tags,
+		Format:   art.Format.ToUint8(),
+		Html:     art.Html,
+		Version:  art.Version,
 		Ctime:    art.Ctime.Format(time.DateTime),
 		Utime:    art.Utime.Format(time.DateTime),
+
+		WordCnt:      art.Stats.WordCnt,
+		ReadMinutes:  art.Stats.ReadMinutes,
+		ImageCnt:     art.Stats.ImageCnt,
+		CodeBlockCnt: art.Stats.CodeBlockCnt,
 	}
-	ctx.JSON(http.StatusOK, ginx.Result{
-		Data: artVo,
-	})
+}
+
+// checkVersion 修改已有的文章必须带版本号，新建的文章忽略版本号
+func checkVersion(id int64, version int64) (int64, bool) {
+	if id <= 0 {
+		return 0, true
+	}
+	return version, version > 0
+}
+
+// versionConflict 把服务端最新的草稿返回去，前端可以拿来和本地的修改合并
+func (h *ArticleHandler) versionConflict(ctx *gin.Context, uid int64, aid int64) {
+	res := ginx.Result{
+		Code: errs.ArticleVersionConflict,
+		Msg:  "文章已经被修改过了",
+	}
+	art, err := h.svc.GetDraft(ctx, uid, aid)
+	if err == nil {
+		res.Data = toDraftVo(art)
+	} else if !errors.Is(err, service.ErrArticleAccessDenied) {
+		h.l.Error("查找最新的文章失败",
+			logger.Int64("uid", uid),
+			logger.Int64("aid", aid),
+			logger.Error(err))
+	}
+	ctx.JSON(http.StatusOK, res)
 }
 
 func (h *ArticleHandler) PubDetail(ctx *gin.Context) {
@@ -255,21 +635,29 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context) {
 		intr domain.Interactive
 		art  domain.Article
 	)
-	uc := ctx.MustGet("user").(jwt.UserClaims)
+	// 没有登录也可以看，uid 是 0
+	uid := optionalUid(ctx)
 	eg.Go(func() error {
 		var er error
-		art, er = h.svc.GetPubById(ctx, id, uc.Uid)
+		art, er = h.svc.GetPubById(ctx, id, uid)
 		return er
 	})
 
 	eg.Go(func() error {
 		var er error
-		intr, er = h.intrSvc.Get(ctx, h.biz, id, uc.Uid)
+		intr, er = h.intrSvc.Get(ctx, h.biz, id, uid)
 		return er
 	})
 
 	// 等待结果
 	err = eg.Wait()
+	if errors.Is(err, service.ErrArticleNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -280,6 +668,21 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context) {
 			logger.Int64("id", id))
 		return
 	}
+	authors, err := h.collabSvc.ListAuthors(ctx, art)
+	if err != nil {
+		// 协作者查不到就只展示作者本人
+		h.l.Warn("查找文章的作者失败",
+			logger.Error(err),
+			logger.Int64("id", id))
+		authors = []domain.Author{art.Author}
+	}
+	nav, err := h.seriesSvc.Nav(ctx, art.Id)
+	if err != nil {
+		// 系列查不到就不展示上一篇和下一篇
+		h.l.Warn("查找文章所在的系列失败",
+			logger.Error(err),
+			logger.Int64("id", id))
+	}
 	// 在service通过kafka传递消息，这里不需要了
 	/*go func() {
 		// 1. 如果需要摆脱原本主链路的超时控制，创建一个新的
@@ -298,23 +701,233 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context) {
 		Data: ArticleVo{
 			Id:         art.Id,
 			Title:      art.Title,
-			Content:    art.Content,
 			AuthorId:   art.Author.Id,
 			AuthorName: art.Author.Name,
+			Authors:    toAuthorVos(authors),
+			// 读者看到的是渲染并且清理过的 HTML，不返回原始内容
+			Format: art.Format.ToUint8(),
+			Html:   art.Html,
 
 			ReadCnt:    intr.ReadCnt,
 			LikeCnt:    intr.LikeCnt,
 			CollectCnt: intr.CollectCnt,
+			CommentCnt: intr.CommentCnt,
 			Liked:      intr.Liked,
 			Collected:  intr.Collected,
+			Series:     toSeriesNavVo(nav),
+
+			WordCnt:      art.Stats.WordCnt,
+			ReadMinutes:  art.Stats.ReadMinutes,
+			ImageCnt:     art.Stats.ImageCnt,
+			CodeBlockCnt: art.Stats.CodeBlockCnt,
 
 			Status: art.Status.ToUint8(),
+			Tags:   art.Tags,
 			Ctime:  art.Ctime.Format(time.DateTime),
 			Utime:  art.Utime.Format(time.DateTime),
 		},
 	})
 }
 
+// ListPubByTag 形如 /articles/pub/tags/go?offset=0&limit=10，不需要登录
+func (h *ArticleHandler) ListPubByTag(ctx *gin.Context) {
+	tag := strings.TrimSpace(ctx.Param("tag"))
+	offset, limit, ok := queryPage(ctx)
+	if !ok {
+		return
+	}
+	if tag == "" {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "标签不能为空",
+		})
+		return
+	}
+	arts, err := h.svc.ListPubByTag(ctx, tag, offset, limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("按标签查找文章失败",
+			logger.Error(err),
+			logger.String("tag", tag),
+			logger.Int("offset", offset),
+			logger.Int("limit", limit))
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: h.toVosWithIntr(ctx, arts, optionalUid(ctx)),
+	})
+}
+
+// SearchPub 形如 /articles/pub/search?q=数据库&offset=0&limit=10，不需要登录
+func (h *ArticleHandler) SearchPub(ctx *gin.Context) {
+	q := strings.TrimSpace(ctx.Query("q"))
+	offset, limit, ok := queryPage(ctx)
+	if !ok {
+		return
+	}
+	if q == "" || utf8.RuneCountInString(q) > 64 {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "搜索关键字不合法",
+		})
+		return
+	}
+	arts, err := h.svc.SearchPub(ctx, q, offset, limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("搜索文章失败",
+			logger.Error(err),
+			logger.String("q", q),
+			logger.Int("offset", offset),
+			logger.Int("limit", limit))
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: h.toVosWithIntr(ctx, arts, optionalUid(ctx)),
+	})
+}
+
+// AuthorHome 作者主页，形如 /articles/pub/authors/123?offset=0&limit=10，不需要登录。
+// 只返回公开的个人信息和已经发表的文章
+func (h *ArticleHandler) AuthorHome(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "id参数错误",
+		})
+		return
+	}
+	offset, limit, ok := queryPage(ctx)
+	if !ok {
+		return
+	}
+	var (
+		eg   errgroup.Group
+		u    domain.User
+		arts []domain.Article
+	)
+	eg.Go(func() error {
+		var er error
+		u, er = h.userSvc.Profile(ctx, id)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		arts, er = h.svc.ListPubByAuthor(ctx, id, offset, limit)
+		return er
+	})
+	err = eg.Wait()
+	if errors.Is(err, service.ErrUserNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "作者不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查找作者主页失败",
+			logger.Error(err),
+			logger.Int64("uid", id),
+			logger.Int("offset", offset),
+			logger.Int("limit", limit))
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: AuthorHomeVo{
+			Id:              u.Id,
+			NickName:        u.NickName,
+			PersonalProfile: u.PersonalProfile,
+			Articles:        h.toVosWithIntr(ctx, arts, optionalUid(ctx)),
+		},
+	})
+}
+
+// toVosWithIntr 列表页一次查完所有文章的阅读、点赞和收藏，uid 是 0 的时候不查点赞和收藏。
+// 查不到就只展示文章，计数都是 0
+func (h *ArticleHandler) toVosWithIntr(ctx *gin.Context, arts []domain.Article, uid int64) []ArticleVo {
+	vos := slice.Map[domain.Article, ArticleVo](arts, func(idx int, src domain.Article) ArticleVo {
+		return toVo(src)
+	})
+	ids := slice.Map[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
+		return src.Id
+	})
+	intrs, err := h.intrSvc.GetByIds(ctx, h.biz, ids, uid)
+	if err != nil {
+		h.l.Warn("批量查找文章的互动数据失败",
+			logger.Error(err),
+			logger.Int("cnt", len(ids)))
+		return vos
+	}
+	for i := range vos {
+		intr := intrs[vos[i].Id]
+		vos[i].ReadCnt = intr.ReadCnt
+		vos[i].LikeCnt = intr.LikeCnt
+		vos[i].CollectCnt = intr.CollectCnt
+		vos[i].CommentCnt = intr.CommentCnt
+		vos[i].Liked = intr.Liked
+		vos[i].Collected = intr.Collected
+	}
+	return vos
+}
+
+// optionalUid 公开的接口登录是可选的，没有登录返回 0
+func optionalUid(ctx *gin.Context) int64 {
+	val, ok := ctx.Get("user")
+	if !ok {
+		return 0
+	}
+	uc, ok := val.(jwt.UserClaims)
+	if !ok {
+		return 0
+	}
+	return uc.Uid
+}
+
+// checkPage 校验请求体里面的 offset 和 limit，参数不对的时候已经写好了响应
+func checkPage(ctx *gin.Context, page Page) bool {
+	if page.Offset < 0 || page.Limit <= 0 || page.Limit > 100 {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "分页参数错误",
+		})
+		return false
+	}
+	return true
+}
+
+// queryPage 解析 query 里面的 offset 和 limit，参数不对的时候已经写好了响应
+func queryPage(ctx *gin.Context) (int, int, bool) {
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "offset参数错误",
+		})
+		return 0, 0, false
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > 100 {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "limit参数错误",
+		})
+		return 0, 0, false
+	}
+	return offset, limit, true
+}
+
 func (h *ArticleHandler) Like(ctx *gin.Context) {
 	type Req struct {
 		Id   int64 `json:"id"`
@@ -333,6 +946,20 @@ func (h *ArticleHandler) Like(ctx *gin.Context) {
 		//取消点赞
 		err = h.intrSvc.CancelLike(ctx, h.biz, req.Id, uc.Uid)
 	}
+	if errors.Is(err, service.ErrBizNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		})
+		return
+	}
+	if errors.Is(err, service.ErrLikeSelf) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "不能给自己的文章点赞",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -360,6 +987,20 @@ func (h *ArticleHandler) Collect(ctx *gin.Context) {
 	}
 	uc := ctx.MustGet("user").(jwt.UserClaims)
 	err := h.intrSvc.Collect(ctx, h.biz, req.Id, req.Cid, uc.Uid)
+	if errors.Is(err, service.ErrCollectionNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+		return
+	}
+	if errors.Is(err, service.ErrBizNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -375,3 +1016,226 @@ func (h *ArticleHandler) Collect(ctx *gin.Context) {
 		Msg: "OK",
 	})
 }
+
+// CancelCollect 不管在哪个收藏夹里面都会取消
+func (h *ArticleHandler) CancelCollect(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.intrSvc.CancelCollect(ctx, h.biz, req.Id, uc.Uid)
+	if errors.Is(err, service.ErrCollectionItemNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "没有收藏过",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		h.l.Error("取消收藏失败",
+			logger.Error(err),
+			logger.Int64("uid", uc.Uid),
+			logger.Int64("aid", req.Id))
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+func (h *ArticleHandler) ListRevisions(ctx *gin.Context) {
+	type Req struct {
+		Id     int64 `json:"id"`
+		Offset int   `json:"offset"`
+		Limit  int   `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !checkPage(ctx, Page{Offset: req.Offset, Limit: req.Limit}) {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	revs, err := h.svc.ListRevisions(ctx, uc.Uid, req.Id, req.Offset, req.Limit)
+	if err != nil {
+		h.revisionError(ctx, err, "查找文章历史版本失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: slice.Map[domain.ArticleRevision, ArticleRevisionVo](revs, func(idx int, src domain.ArticleRevision) ArticleRevisionVo {
+			return toRevisionVo(src)
+		}),
+	})
+}
+
+// DiffRevisions 形如 /articles/revisions/diff?id=1&from=2&to=3
+func (h *ArticleHandler) DiffRevisions(ctx *gin.Context) {
+	type Req struct {
+		Id   int64 `form:"id"`
+		From int64 `form:"from"`
+		To   int64 `form:"to"`
+	}
+	var req Req
+	if err := ctx.BindQuery(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	diff, err := h.svc.DiffRevisions(ctx, uc.Uid, req.Id, req.From, req.To)
+	if err != nil {
+		h.revisionError(ctx, err, "对比文章历史版本失败", uc.Uid, req.Id)
+		return
+	}
+	toLineVo := func(idx int, src domain.DiffLine) DiffLineVo {
+		return DiffLineVo{
+			Op:   src.Op,
+			Text: src.Text,
+		}
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: ArticleRevisionDiffVo{
+			From:    toRevisionVo(diff.From),
+			To:      toRevisionVo(diff.To),
+			Title:   slice.Map[domain.DiffLine, DiffLineVo](diff.Title, toLineVo),
+			Content: slice.Map[domain.DiffLine, DiffLineVo](diff.Content, toLineVo),
+		},
+	})
+}
+
+// RestoreRevision 返回 article id
+func (h *ArticleHandler) RestoreRevision(ctx *gin.Context) {
+	type Req struct {
+		Id         int64 `json:"id"`
+		RevisionId int64 `json:"revisionId"`
+		// Publish 恢复之后是否直接发表，默认只恢复成草稿
+		Publish bool `json:"publish"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	id, err := h.svc.RestoreRevision(ctx, uc.Uid, req.Id, req.RevisionId, req.Publish)
+	if err != nil {
+		h.revisionError(ctx, err, "恢复文章历史版本失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: id,
+	})
+}
+
+func (h *ArticleHandler) revisionError(ctx *gin.Context, err error, msg string, uid int64, aid int64) {
+	switch {
+	case errors.Is(err, service.ErrArticleAccessDenied),
+		errors.Is(err, service.ErrArticleRevisionNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章或者历史版本不存在",
+		})
+		h.l.Warn(msg,
+			logger.Error(err),
+			logger.Int64("uid", uid),
+			logger.Int64("aid", aid))
+	case errors.Is(err, service.ErrArticleInTrash):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章在回收站里面，请先恢复",
+		})
+	case errors.Is(err, service.ErrArticleRevisionTooLarge):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章太长，不能比较",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error(msg,
+			logger.Error(err),
+			logger.Int64("uid", uid),
+			logger.Int64("aid", aid))
+	}
+}
+
+func toRevisionVo(rev domain.ArticleRevision) ArticleRevisionVo {
+	return ArticleRevisionVo{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Status:    rev.Status.ToUint8(),
+		Ctime:     rev.Ctime.Format(time.DateTime),
+	}
+}
+
+func (h *ArticleHandler) ListSchedules(ctx *gin.Context) {
+	var page Page
+	if err := ctx.Bind(&page); err != nil {
+		return
+	}
+	if !checkPage(ctx, page) {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	schedules, err := h.svc.ListSchedules(ctx, uc.Uid, page.Offset, page.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查找定时发表任务失败",
+			logger.Error(err),
+			logger.Int64("uid", uc.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: slice.Map[domain.ArticleSchedule, ArticleScheduleVo](schedules, func(idx int, src domain.ArticleSchedule) ArticleScheduleVo {
+			return ArticleScheduleVo{
+				ArticleId: src.ArticleId,
+				PublishAt: src.PublishAt.Format(time.DateTime),
+				Ctime:     src.Ctime.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+func (h *ArticleHandler) CancelSchedule(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.CancelSchedule(ctx, uc.Uid, req.Id)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Msg: "OK",
+		})
+	case errors.Is(err, service.ErrArticleScheduleNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "定时发表任务不存在或者已经执行",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("取消定时发表失败",
+			logger.Error(err),
+			logger.Int64("uid", uc.Uid),
+			logger.Int64("aid", req.Id))
+	}
+}
@@ -0,0 +1,144 @@
+package web
+
+import (
+	"errors"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web/jwt"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// articleAssetPath 资源的 URL 前缀，URL 会被写进文章内容里面，不能修改
+const articleAssetPath = "/articles/assets/"
+
+type ArticleAssetHandler struct {
+	svc service.ArticleAssetService
+	l   logger.LoggerV1
+}
+
+func NewArticleAssetHandler(svc service.ArticleAssetService, l logger.LoggerV1) *ArticleAssetHandler {
+	return &ArticleAssetHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ArticleAssetHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group(articleAssetPath)
+	g.POST("/upload", h.Upload)
+	// 读者访问，不需要登录
+	g.GET("/:aid/:name", h.Get)
+}
+
+// Upload 表单上传，aid 是文章 id，file 是文件
+func (h *ArticleAssetHandler) Upload(ctx *gin.Context) {
+	// 留一点空间给表单的其它部分
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, service.MaxArticleAssetSize+1<<20)
+	aid, err := strconv.ParseInt(ctx.PostForm("aid"), 10, 64)
+	if err != nil {
+		h.badRequest(ctx, err)
+		return
+	}
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		h.badRequest(ctx, err)
+		return
+	}
+	if fh.Size > service.MaxArticleAssetSize {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文件太大",
+		})
+		return
+	}
+	f, err := fh.Open()
+	if err != nil {
+		h.badRequest(ctx, err)
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, service.MaxArticleAssetSize+1))
+	if err != nil {
+		h.badRequest(ctx, err)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	asset, err := h.svc.Upload(ctx, uc.Uid, aid, data)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Data: ArticleAssetVo{
+				Url:         articleAssetPath + asset.Key,
+				Key:         asset.Key,
+				ContentType: asset.ContentType,
+				Size:        asset.Size,
+			},
+		})
+	case errors.Is(err, service.ErrArticleAssetTooLarge):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文件太大",
+		})
+	case errors.Is(err, service.ErrArticleAssetTypeInvalid):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "不支持的文件类型",
+		})
+	case errors.Is(err, service.ErrArticleAccessDenied):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		})
+		h.l.Warn("上传文章资源失败，非法访问",
+			logger.Int64("uid", uc.Uid),
+			logger.Int64("aid", aid))
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("上传文章资源失败",
+			logger.Int64("uid", uc.Uid),
+			logger.Int64("aid", aid),
+			logger.Error(err))
+	}
+}
+
+func (h *ArticleAssetHandler) Get(ctx *gin.Context) {
+	key := ctx.Param("aid") + "/" + ctx.Param("name")
+	asset, data, err := h.svc.Get(ctx, key)
+	if errors.Is(err, service.ErrArticleAssetNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		ctx.Status(http.StatusInternalServerError)
+		h.l.Error("读取文章资源失败",
+			logger.String("key", key),
+			logger.Error(err))
+		return
+	}
+	// key 由内容决定，内容不会变，可以一直缓存
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Data(http.StatusOK, asset.ContentType, data)
+}
+
+func (h *ArticleAssetHandler) badRequest(ctx *gin.Context, err error) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文件太大",
+		})
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Code: 4,
+		Msg:  "参数错误",
+	})
+}
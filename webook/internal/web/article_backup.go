@@ -0,0 +1,215 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web/jwt"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ArticleBackupHandler 导出和导入自己的文章
+type ArticleBackupHandler struct {
+	svc service.ArticleBackupService
+	l   logger.LoggerV1
+}
+
+func NewArticleBackupHandler(svc service.ArticleBackupService, l logger.LoggerV1) *ArticleBackupHandler {
+	return &ArticleBackupHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ArticleBackupHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles")
+	g.POST("/exports/create", h.CreateExport)
+	g.POST("/exports/list", h.ListExports)
+	g.GET("/exports/download", h.Download)
+	g.POST("/import", h.Import)
+}
+
+// CreateExport 导出在后台执行，前端轮询 ListExports 看进度
+func (h *ArticleBackupHandler) CreateExport(ctx *gin.Context) {
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	e, err := h.svc.CreateExport(ctx, uc.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("创建导出失败",
+			logger.Int64("uid", uc.Uid),
+			logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: h.toExportVo(e),
+	})
+}
+
+func (h *ArticleBackupHandler) ListExports(ctx *gin.Context) {
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	es, err := h.svc.ListExports(ctx, uc.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查找导出失败",
+			logger.Int64("uid", uc.Uid),
+			logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: slice.Map[domain.ArticleExport, ArticleExportVo](es, func(idx int, src domain.ArticleExport) ArticleExportVo {
+			return h.toExportVo(src)
+		}),
+	})
+}
+
+// Download 成功的时候直接返回 zip 文件
+func (h *ArticleBackupHandler) Download(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Query("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "id参数错误",
+		})
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	data, err := h.svc.Download(ctx, uc.Uid, id)
+	switch {
+	case err == nil:
+		ctx.Header("Content-Disposition",
+			fmt.Sprintf(`attachment; filename="webook-articles-%d.zip"`, id))
+		ctx.Data(http.StatusOK, "application/zip", data)
+	case errors.Is(err, service.ErrArticleExportNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "导出不存在",
+		})
+	case errors.Is(err, service.ErrArticleExportNotReady):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "导出还没有完成",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("下载导出失败",
+			logger.Int64("uid", uc.Uid),
+			logger.Int64("id", id),
+			logger.Error(err))
+	}
+}
+
+// Import 表单上传，file 是导出的 zip 文件，dryRun 是 true 的时候只返回会创建哪些草稿
+func (h *ArticleBackupHandler) Import(ctx *gin.Context) {
+	// 留一点空间给表单的其它部分
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, service.ArticleImportMaxSize+1<<20)
+	dryRun, _ := strconv.ParseBool(ctx.PostForm("dryRun"))
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		h.badRequest(ctx, err)
+		return
+	}
+	if fh.Size > service.ArticleImportMaxSize {
+		h.tooLarge(ctx)
+		return
+	}
+	f, err := fh.Open()
+	if err != nil {
+		h.badRequest(ctx, err)
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, service.ArticleImportMaxSize+1))
+	if err != nil {
+		h.badRequest(ctx, err)
+		return
+	}
+	if len(data) > service.ArticleImportMaxSize {
+		h.tooLarge(ctx)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	report, err := h.svc.Import(ctx, uc.Uid, data, dryRun)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Data: ArticleImportReportVo{
+				DryRun: report.DryRun,
+				Items: slice.Map[domain.ArticleImportItem, ArticleImportItemVo](report.Items,
+					func(idx int, src domain.ArticleImportItem) ArticleImportItemVo {
+						return ArticleImportItemVo{
+							Path:   src.Path,
+							Title:  src.Title,
+							Format: src.Format.ToUint8(),
+							Tags:   src.Tags,
+							Id:     src.Id,
+							Err:    src.Err,
+						}
+					}),
+			},
+		})
+	case errors.Is(err, service.ErrArticleImportInvalid):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "不是合法的 zip 文件",
+		})
+	case errors.Is(err, service.ErrArticleImportTooLarge):
+		h.tooLarge(ctx)
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("导入文章失败",
+			logger.Int64("uid", uc.Uid),
+			logger.Error(err))
+	}
+}
+
+func (h *ArticleBackupHandler) toExportVo(e domain.ArticleExport) ArticleExportVo {
+	return ArticleExportVo{
+		Id:     e.Id,
+		Status: e.Status.ToUint8(),
+		Total:  e.Total,
+		Done:   e.Done,
+		Reason: e.Reason,
+		Ctime:  e.Ctime.Format(time.DateTime),
+		Utime:  e.Utime.Format(time.DateTime),
+	}
+}
+
+func (h *ArticleBackupHandler) tooLarge(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Code: 4,
+		Msg: fmt.Sprintf("文件不能超过 %dMB，最多 %d 篇文章，每篇不能超过 %dKB",
+			service.ArticleImportMaxSize>>20, service.ArticleImportMaxFiles, service.ArticleImportMaxFileSize>>10),
+	})
+}
+
+func (h *ArticleBackupHandler) badRequest(ctx *gin.Context, err error) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		h.tooLarge(ctx)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Code: 4,
+		Msg:  "参数错误",
+	})
+}
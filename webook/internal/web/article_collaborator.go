@@ -0,0 +1,146 @@
+package web
+
+import (
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web/jwt"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ArticleCollaboratorHandler struct {
+	svc service.ArticleCollaboratorService
+	l   logger.LoggerV1
+}
+
+func NewArticleCollaboratorHandler(svc service.ArticleCollaboratorService, l logger.LoggerV1) *ArticleCollaboratorHandler {
+	return &ArticleCollaboratorHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ArticleCollaboratorHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles/collaborators")
+	g.POST("/invite", h.Invite)
+	g.POST("/remove", h.Remove)
+	g.POST("/list", h.List)
+}
+
+// Invite 邀请协作者，已经是协作者的时候修改角色
+func (h *ArticleCollaboratorHandler) Invite(ctx *gin.Context) {
+	type Req struct {
+		Id  int64 `json:"id"`
+		Uid int64 `json:"uid"`
+		// Role 1 是 viewer，2 是 editor，3 是 owner
+		Role uint8 `json:"role"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	role := domain.ArticleRole(req.Role)
+	if !role.Valid() {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "角色不合法",
+		})
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Invite(ctx, uc.Uid, domain.ArticleCollaborator{
+		ArticleId: req.Id,
+		User:      domain.Author{Id: req.Uid},
+		Role:      role,
+	})
+	if err != nil {
+		h.handleErr(ctx, err, "邀请协作者失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// Remove 移除协作者，uid 是自己的时候就是退出协作
+func (h *ArticleCollaboratorHandler) Remove(ctx *gin.Context) {
+	type Req struct {
+		Id  int64 `json:"id"`
+		Uid int64 `json:"uid"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Remove(ctx, uc.Uid, req.Id, req.Uid)
+	if err != nil {
+		h.handleErr(ctx, err, "移除协作者失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+func (h *ArticleCollaboratorHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	cs, err := h.svc.List(ctx, uc.Uid, req.Id)
+	if err != nil {
+		h.handleErr(ctx, err, "查找协作者失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: slice.Map[domain.ArticleCollaborator, ArticleCollaboratorVo](cs,
+			func(idx int, src domain.ArticleCollaborator) ArticleCollaboratorVo {
+				return ArticleCollaboratorVo{
+					Uid:   src.User.Id,
+					Name:  src.User.Name,
+					Role:  src.Role.ToUint8(),
+					Ctime: src.Ctime.Format(time.DateTime),
+				}
+			}),
+	})
+}
+
+func (h *ArticleCollaboratorHandler) handleErr(ctx *gin.Context, err error, msg string, uid int64, aid int64) {
+	switch {
+	case errors.Is(err, service.ErrArticleAccessDenied),
+		errors.Is(err, service.ErrArticleNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在或者没有权限",
+		})
+	case errors.Is(err, service.ErrArticleCollaboratorInvalid):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "用户不存在或者是文章的作者",
+		})
+	case errors.Is(err, service.ErrArticleCollaboratorNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "不是文章的协作者",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error(msg,
+			logger.Error(err),
+			logger.Int64("uid", uid),
+			logger.Int64("aid", aid))
+	}
+}
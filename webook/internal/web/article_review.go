@@ -0,0 +1,148 @@
+package web
+
+import (
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web/jwt"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// ArticleReviewHandler 管理员审核命中敏感词的文章，只有配置里面的管理员可以访问
+type ArticleReviewHandler struct {
+	svc    service.ArticleReviewService
+	admins map[int64]struct{}
+	l      logger.LoggerV1
+}
+
+func NewArticleReviewHandler(svc service.ArticleReviewService, admins []int64, l logger.LoggerV1) *ArticleReviewHandler {
+	set := make(map[int64]struct{}, len(admins))
+	for _, uid := range admins {
+		set[uid] = struct{}{}
+	}
+	return &ArticleReviewHandler{
+		svc:    svc,
+		admins: set,
+		l:      l,
+	}
+}
+
+func (h *ArticleReviewHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/admin/articles/reviews", h.checkAdmin)
+	g.POST("/list", h.List)
+	g.POST("/approve", h.Approve)
+	g.POST("/reject", h.Reject)
+}
+
+// checkAdmin 登录校验之后才会走到这里，不是管理员就当作接口不存在
+func (h *ArticleReviewHandler) checkAdmin(ctx *gin.Context) {
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	if _, ok := h.admins[uc.Uid]; !ok {
+		h.l.Warn("非管理员访问审核接口", logger.Int64("uid", uc.Uid))
+		ctx.AbortWithStatus(http.StatusNotFound)
+	}
+}
+
+func (h *ArticleReviewHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !checkPage(ctx, Page{Offset: req.Offset, Limit: req.Limit}) {
+		return
+	}
+	rs, err := h.svc.ListPending(ctx, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查找审核队列失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: slice.Map[domain.ArticleReview, ArticleReviewVo](rs, func(idx int, src domain.ArticleReview) ArticleReviewVo {
+			return ArticleReviewVo{
+				ArticleId: src.ArticleId,
+				AuthorId:  src.Author.Id,
+				Title:     src.Title,
+				Status:    src.Status.ToUint8(),
+				Hits:      src.Hits,
+				Ctime:     src.Ctime.Format(time.DateTime),
+				Utime:     src.Utime.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+func (h *ArticleReviewHandler) Approve(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Approve(ctx, uc.Uid, req.Id)
+	if err != nil {
+		h.handleErr(ctx, err, "审核通过失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+func (h *ArticleReviewHandler) Reject(ctx *gin.Context) {
+	type Req struct {
+		Id     int64  `json:"id"`
+		Reason string `json:"reason"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Reject(ctx, uc.Uid, req.Id, req.Reason)
+	if err != nil {
+		h.handleErr(ctx, err, "审核拒绝失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+func (h *ArticleReviewHandler) handleErr(ctx *gin.Context, err error, msg string, uid int64, aid int64) {
+	switch {
+	case errors.Is(err, service.ErrArticleReviewInvalid):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "拒绝原因不能为空，也不能太长",
+		})
+	case errors.Is(err, service.ErrArticleReviewNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "已经审核过了，或者作者已经撤回",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error(msg,
+			logger.Error(err),
+			logger.Int64("uid", uid),
+			logger.Int64("aid", aid))
+	}
+}
@@ -0,0 +1,322 @@
+package web
+
+import (
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web/jwt"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ArticleSeriesHandler 作者管理自己的系列，读者看系列不需要登录
+type ArticleSeriesHandler struct {
+	svc service.ArticleSeriesService
+	l   logger.LoggerV1
+}
+
+func NewArticleSeriesHandler(svc service.ArticleSeriesService, l logger.LoggerV1) *ArticleSeriesHandler {
+	return &ArticleSeriesHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ArticleSeriesHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles/series")
+	g.POST("/create", h.Create)
+	g.POST("/update", h.Update)
+	g.POST("/delete", h.Delete)
+	g.POST("/list", h.List)
+	g.POST("/detail", h.Detail)
+	g.POST("/add", h.AddArticle)
+	g.POST("/remove", h.RemoveArticle)
+	g.POST("/reorder", h.Reorder)
+	server.GET("/articles/pub/series/:id", h.PubDetail)
+}
+
+func (h *ArticleSeriesHandler) Create(ctx *gin.Context) {
+	type Req struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	id, err := h.svc.Create(ctx, domain.ArticleSeries{
+		Author:      domain.Author{Id: uc.Uid},
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.handleErr(ctx, err, "创建系列失败", uc.Uid, 0)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: id,
+	})
+}
+
+func (h *ArticleSeriesHandler) Update(ctx *gin.Context) {
+	type Req struct {
+		Id          int64  `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Update(ctx, domain.ArticleSeries{
+		Id:          req.Id,
+		Author:      domain.Author{Id: uc.Uid},
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.handleErr(ctx, err, "修改系列失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// Delete 系列里面的文章不会被删除
+func (h *ArticleSeriesHandler) Delete(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Delete(ctx, uc.Uid, req.Id)
+	if err != nil {
+		h.handleErr(ctx, err, "删除系列失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// List 自己的所有系列
+func (h *ArticleSeriesHandler) List(ctx *gin.Context) {
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	ss, err := h.svc.ListByAuthor(ctx, uc.Uid)
+	if err != nil {
+		h.handleErr(ctx, err, "查找系列失败", uc.Uid, 0)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: slice.Map[domain.ArticleSeries, ArticleSeriesVo](ss, func(idx int, src domain.ArticleSeries) ArticleSeriesVo {
+			return h.toVo(src)
+		}),
+	})
+}
+
+// Detail 作者自己看系列，草稿和撤回的文章也在里面，方便调整顺序
+func (h *ArticleSeriesHandler) Detail(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	s, err := h.svc.Detail(ctx, uc.Uid, req.Id)
+	if err != nil {
+		h.handleErr(ctx, err, "查找系列失败", uc.Uid, req.Id)
+		return
+	}
+	vo := h.toVo(s)
+	vo.Articles = slice.Map[domain.Article, ArticleVo](s.Articles, func(idx int, src domain.Article) ArticleVo {
+		return ArticleVo{
+			Id:     src.Id,
+			Title:  src.Title,
+			Status: src.Status.ToUint8(),
+			Utime:  src.Utime.Format(time.DateTime),
+		}
+	})
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: vo,
+	})
+}
+
+// AddArticle 加到系列的最后面
+func (h *ArticleSeriesHandler) AddArticle(ctx *gin.Context) {
+	type Req struct {
+		Id        int64 `json:"id"`
+		ArticleId int64 `json:"articleId"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.AddArticle(ctx, uc.Uid, req.Id, req.ArticleId)
+	if err != nil {
+		h.handleErr(ctx, err, "把文章加到系列失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+func (h *ArticleSeriesHandler) RemoveArticle(ctx *gin.Context) {
+	type Req struct {
+		Id        int64 `json:"id"`
+		ArticleId int64 `json:"articleId"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.RemoveArticle(ctx, uc.Uid, req.Id, req.ArticleId)
+	if err != nil {
+		h.handleErr(ctx, err, "把文章移出系列失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// Reorder ArticleIds 是系列里面所有文章的新顺序，少了或者多了都不行
+func (h *ArticleSeriesHandler) Reorder(ctx *gin.Context) {
+	type Req struct {
+		Id         int64   `json:"id"`
+		ArticleIds []int64 `json:"articleIds"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Reorder(ctx, uc.Uid, req.Id, req.ArticleIds)
+	if err != nil {
+		h.handleErr(ctx, err, "调整系列的顺序失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// PubDetail 系列的公开页面，只有已发表的文章
+func (h *ArticleSeriesHandler) PubDetail(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "id参数错误",
+		})
+		return
+	}
+	s, err := h.svc.GetPub(ctx, id)
+	if err != nil {
+		h.handleErr(ctx, err, "查找系列失败", 0, id)
+		return
+	}
+	vo := h.toVo(s)
+	vo.Articles = slice.Map[domain.Article, ArticleVo](s.Articles, func(idx int, src domain.Article) ArticleVo {
+		return ArticleVo{
+			Id:       src.Id,
+			Title:    src.Title,
+			Abstract: src.Abstract(),
+			Utime:    src.Utime.Format(time.DateTime),
+		}
+	})
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: vo,
+	})
+}
+
+func (h *ArticleSeriesHandler) toVo(s domain.ArticleSeries) ArticleSeriesVo {
+	return ArticleSeriesVo{
+		Id:          s.Id,
+		AuthorId:    s.Author.Id,
+		AuthorName:  s.Author.Name,
+		Title:       s.Title,
+		Description: s.Description,
+		Ctime:       s.Ctime.Format(time.DateTime),
+		Utime:       s.Utime.Format(time.DateTime),
+	}
+}
+
+func (h *ArticleSeriesHandler) handleErr(ctx *gin.Context, err error, msg string, uid int64, sid int64) {
+	switch {
+	case errors.Is(err, service.ErrArticleSeriesInvalid):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg: "标题不能为空，也不能超过" + strconv.Itoa(domain.ArticleSeriesTitleMaxLength) +
+				"个字，简介不能超过" + strconv.Itoa(domain.ArticleSeriesDescriptionMaxLength) + "个字",
+		})
+	case errors.Is(err, service.ErrArticleSeriesNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "系列不存在",
+		})
+	case errors.Is(err, service.ErrArticleAccessDenied),
+		errors.Is(err, service.ErrArticleNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在或者没有权限",
+		})
+	case errors.Is(err, service.ErrArticleInSeries):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章已经在系列里面了",
+		})
+	case errors.Is(err, service.ErrArticleNotInSeries):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不在这个系列里面",
+		})
+	case errors.Is(err, service.ErrArticleSeriesMismatch):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "系列里面的文章已经变了，请刷新之后重试",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error(msg,
+			logger.Error(err),
+			logger.Int64("uid", uid),
+			logger.Int64("sid", sid))
+	}
+}
+
+// toSeriesNavVo 文章不在系列里面的时候返回 nil
+func toSeriesNavVo(nav domain.ArticleSeriesNav) *ArticleSeriesNavVo {
+	if nav.Series.Id == 0 {
+		return nil
+	}
+	vo := &ArticleSeriesNavVo{
+		Id:    nav.Series.Id,
+		Title: nav.Series.Title,
+	}
+	if nav.Prev.Id > 0 {
+		vo.Prev = &ArticleVo{Id: nav.Prev.Id, Title: nav.Prev.Title}
+	}
+	if nav.Next.Id > 0 {
+		vo.Next = &ArticleVo{Id: nav.Next.Id, Title: nav.Next.Title}
+	}
+	return vo
+}
@@ -5,18 +5,33 @@ import (
 	"encoding/json"
 	"errors"
 	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/errs"
 	"geek-basic-go/webook/internal/service"
 	svcmocks "geek-basic-go/webook/internal/service/mocks"
 	ijwt "geek-basic-go/webook/internal/web/jwt"
+	"geek-basic-go/webook/pkg/ginx"
 	"geek-basic-go/webook/pkg/logger"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 )
 
+func TestMain(m *testing.M) {
+	// ginx 的包装函数依赖 main 里面初始化的日志和统计
+	ginx.L = logger.NewNopLogger()
+	ginx.InitCounter(prometheus.CounterOpts{
+		Namespace: "geekbang_daming",
+		Subsystem: "webook_test",
+		Name:      "biz_code",
+	})
+	os.Exit(m.Run())
+}
+
 func TestArticleHandler_Publish(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -24,7 +39,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 		reqBody string
 
 		wantedCode int
-		wantedRes  Result
+		wantedRes  ginx.Result
 	}{
 		{
 			name: "新建发表成功",
@@ -41,8 +56,8 @@ func TestArticleHandler_Publish(t *testing.T) {
 			},
 			reqBody:    `{"title": "我的标题","content": "我的内容"}`,
 			wantedCode: 200,
-			wantedRes: Result{
-				Data: float64(1),
+			wantedRes: ginx.Result{
+				Data: map[string]any{"id": float64(1), "version": float64(1)},
 			},
 		},
 		{
@@ -56,13 +71,48 @@ func TestArticleHandler_Publish(t *testing.T) {
 					Author: domain.Author{
 						Id: 123,
 					},
+					Version: 2,
 				}).Return(int64(123), nil)
 				return svc
 			},
+			reqBody:    `{"id": 123, "title": "我的标题","content": "我的内容","version": 2}`,
+			wantedCode: 200,
+			wantedRes: ginx.Result{
+				Data: map[string]any{"id": float64(123), "version": float64(3)},
+			},
+		},
+		{
+			name: "修改的时候没有带版本号",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
 			reqBody:    `{"id": 123, "title": "我的标题","content": "我的内容"}`,
 			wantedCode: 200,
-			wantedRes: Result{
-				Data: float64(123),
+			wantedRes: ginx.Result{
+				Code: errs.ArticleInvalidInput,
+				Msg:  "缺少版本号",
+			},
+		},
+		{
+			name: "文章在回收站里面",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), domain.Article{
+					Id:      123,
+					Title:   "我的标题",
+					Content: "我的内容",
+					Author: domain.Author{
+						Id: 123,
+					},
+					Version: 2,
+				}).Return(int64(0), service.ErrArticleInTrash)
+				return svc
+			},
+			reqBody:    `{"id": 123, "title": "我的标题","content": "我的内容","version": 2}`,
+			wantedCode: 200,
+			wantedRes: ginx.Result{
+				Code: errs.ArticleInvalidInput,
+				Msg:  "文章在回收站里面，请先恢复",
 			},
 		},
 		{
@@ -80,8 +130,8 @@ func TestArticleHandler_Publish(t *testing.T) {
 			},
 			reqBody:    `{"title": "我的标题","content": "我的内容"}`,
 			wantedCode: 200,
-			wantedRes: Result{
-				Code: 5,
+			wantedRes: ginx.Result{
+				Code: errs.ArticleInternalServerError,
 				Msg:  "系统错误",
 			},
 		},
@@ -105,7 +155,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 			// mock UserService 和 CodService
 			svc := tc.mock(ctrl)
 			// 创建UserHandler
-			hdl := NewArticleHandler(svc, logger.NewNopLogger())
+			hdl := NewArticleHandler(svc, nil, nil, nil, nil, logger.NewNopLogger())
 			// 注册路由
 			server := gin.Default()
 			server.Use(func(ctx *gin.Context) {
@@ -126,7 +176,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 			if tc.wantedCode != http.StatusOK {
 				return
 			}
-			var res Result
+			var res ginx.Result
 			err = json.NewDecoder(recorder.Body).Decode(&res)
 			assert.NoError(t, err)
 			// Check the response code
@@ -135,3 +185,67 @@ func TestArticleHandler_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleHandler_ListPage(t *testing.T) {
+	testCases := []struct {
+		name    string
+		path    string
+		reqBody string
+	}{
+		{
+			name:    "历史版本 limit 是 0",
+			path:    "/articles/revisions/list",
+			reqBody: `{"id": 1, "offset": 0, "limit": 0}`,
+		},
+		{
+			name:    "历史版本 limit 太大",
+			path:    "/articles/revisions/list",
+			reqBody: `{"id": 1, "offset": 0, "limit": 101}`,
+		},
+		{
+			name:    "定时发表 limit 是负数",
+			path:    "/articles/schedules/list",
+			reqBody: `{"offset": 0, "limit": -1}`,
+		},
+		{
+			name:    "定时发表 limit 太大",
+			path:    "/articles/schedules/list",
+			reqBody: `{"offset": 0, "limit": 1000000}`,
+		},
+		{
+			name:    "回收站 offset 是负数",
+			path:    "/articles/trash/list",
+			reqBody: `{"offset": -1, "limit": 10}`,
+		},
+		{
+			name:    "回收站没有传 limit",
+			path:    "/articles/trash/list",
+			reqBody: `{"offset": 0}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// 参数不对的时候不会调用 service
+			hdl := NewArticleHandler(svcmocks.NewMockArticleService(ctrl), nil, nil, nil, nil, logger.NewNopLogger())
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", ijwt.UserClaims{
+					Uid: 123,
+				})
+			})
+			hdl.RegisterRoutes(server)
+			req, err := http.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(tc.reqBody))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, req)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			var res ginx.Result
+			err = json.NewDecoder(recorder.Body).Decode(&res)
+			assert.NoError(t, err)
+			assert.Equal(t, ginx.Result{Code: 4, Msg: "分页参数错误"}, res)
+		})
+	}
+}
@@ -10,10 +10,162 @@ type ArticleVo struct {
 	Status     uint8  `json:"status,omitempty"`
 	Ctime      string `json:"ctime,omitempty"`
 	Utime      string `json:"utime,omitempty"`
+	// Authors 已发表的文章的所有作者，第一个是 AuthorId 对应的作者
+	Authors []ArticleAuthorVo `json:"authors,omitempty"`
+
+	Tags []string `json:"tags,omitempty"`
+	// Format 0 是纯文本，1 是 markdown
+	Format uint8 `json:"format"`
+	// Html 渲染之后的内容，已经清理过，可以直接展示
+	Html string `json:"html,omitempty"`
+	// Version 修改和发表的时候要带上
+	Version int64 `json:"version,omitempty"`
+	// Dtime 移到回收站的时间，PurgeTime 之后会被彻底删除，只有回收站列表里面有
+	Dtime     string `json:"dtime,omitempty"`
+	PurgeTime string `json:"purgeTime,omitempty"`
+	// Review 最近一次审核的结果，只有作者看草稿的时候有
+	Review *ArticleReviewVo `json:"review,omitempty"`
+	// Series 文章在系列里面的上一篇和下一篇，不在系列里面就没有
+	Series *ArticleSeriesNavVo `json:"series,omitempty"`
 
 	ReadCnt    int64 `json:"readCnt"`
 	LikeCnt    int64 `json:"likeCnt"`
 	CollectCnt int64 `json:"collectCnt"`
-	Liked      bool  `json:"liked"`
-	Collected  bool  `json:"collected"`
+
+	// 长度统计，保存或者发表的时候算出来的，ReadMinutes 是估算的阅读时间
+	WordCnt      int   `json:"wordCnt"`
+	ReadMinutes  int   `json:"readMinutes"`
+	ImageCnt     int   `json:"imageCnt"`
+	CodeBlockCnt int   `json:"codeBlockCnt"`
+	CommentCnt   int64 `json:"commentCnt"`
+	Liked        bool  `json:"liked"`
+	Collected    bool  `json:"collected"`
+}
+
+type ArticleAuthorVo struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// AuthorHomeVo 作者主页，不包含邮箱、手机号这些隐私信息
+type AuthorHomeVo struct {
+	Id              int64       `json:"id"`
+	NickName        string      `json:"nickName"`
+	PersonalProfile string      `json:"personalProfile"`
+	Articles        []ArticleVo `json:"articles"`
+}
+
+// ArticleReviewVo Status 1 是等待审核，2 是通过，3 是拒绝
+type ArticleReviewVo struct {
+	ArticleId int64    `json:"articleId,omitempty"`
+	AuthorId  int64    `json:"authorId,omitempty"`
+	Title     string   `json:"title,omitempty"`
+	Status    uint8    `json:"status"`
+	Hits      []string `json:"hits"`
+	Reason    string   `json:"reason,omitempty"`
+	Ctime     string   `json:"ctime,omitempty"`
+	Utime     string   `json:"utime"`
+}
+
+// ArticleSavedVo 保存或者发表成功之后返回，Version 是下一次修改要带上的版本号
+type ArticleSavedVo struct {
+	Id      int64 `json:"id"`
+	Version int64 `json:"version"`
+}
+
+type ArticleListVo struct {
+	List []ArticleVo `json:"list"`
+	// Cursor 下一页的游标，为空说明没有下一页了
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type ArticleRevisionVo struct {
+	Id        int64  `json:"id"`
+	ArticleId int64  `json:"articleId"`
+	Title     string `json:"title"`
+	Status    uint8  `json:"status"`
+	Ctime     string `json:"ctime"`
+}
+
+type ArticleRevisionDiffVo struct {
+	From    ArticleRevisionVo `json:"from"`
+	To      ArticleRevisionVo `json:"to"`
+	Title   []DiffLineVo      `json:"title"`
+	Content []DiffLineVo      `json:"content"`
+}
+
+type DiffLineVo struct {
+	// Op equal, insert 或者 delete
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type ArticleScheduleVo struct {
+	ArticleId int64  `json:"articleId"`
+	PublishAt string `json:"publishAt"`
+	Ctime     string `json:"ctime"`
+}
+
+type ArticleAssetVo struct {
+	// Url 可以直接写到文章内容里面，不会变
+	Url         string `json:"url"`
+	Key         string `json:"key"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+type ArticleCollaboratorVo struct {
+	Uid  int64  `json:"uid"`
+	Name string `json:"name"`
+	// Role 1 是 viewer，2 是 editor，3 是 owner
+	Role  uint8  `json:"role"`
+	Ctime string `json:"ctime"`
+}
+
+type ArticleSeriesVo struct {
+	Id          int64  `json:"id"`
+	AuthorId    int64  `json:"authorId"`
+	AuthorName  string `json:"authorName,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Articles 按照系列里面的顺序，列表里面没有
+	Articles []ArticleVo `json:"articles,omitempty"`
+	Ctime    string      `json:"ctime,omitempty"`
+	Utime    string      `json:"utime,omitempty"`
+}
+
+// ArticleSeriesNavVo Prev 和 Next 是空的说明已经是第一篇或者最后一篇了
+type ArticleSeriesNavVo struct {
+	Id    int64      `json:"id"`
+	Title string     `json:"title"`
+	Prev  *ArticleVo `json:"prev,omitempty"`
+	Next  *ArticleVo `json:"next,omitempty"`
+}
+
+// ArticleExportVo Done 和 Total 是进度，Total 在开始导出之前是 0
+type ArticleExportVo struct {
+	Id int64 `json:"id"`
+	// Status 1 是等待，2 是正在导出，3 是完成，4 是失败
+	Status uint8  `json:"status"`
+	Total  int    `json:"total"`
+	Done   int    `json:"done"`
+	Reason string `json:"reason,omitempty"`
+	Ctime  string `json:"ctime"`
+	Utime  string `json:"utime"`
+}
+
+type ArticleImportReportVo struct {
+	DryRun bool                  `json:"dryRun"`
+	Items  []ArticleImportItemVo `json:"items"`
+}
+
+type ArticleImportItemVo struct {
+	Path   string   `json:"path"`
+	Title  string   `json:"title"`
+	Format uint8    `json:"format"`
+	Tags   []string `json:"tags,omitempty"`
+	// Id 创建出来的草稿，试运行的时候是 0
+	Id int64 `json:"id,omitempty"`
+	// Err 不是空的说明这个文件不能导入
+	Err string `json:"err,omitempty"`
 }
@@ -0,0 +1,239 @@
+package web
+
+import (
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web/jwt"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CollectionHandler 收藏夹的管理，收藏和取消收藏在 ArticleHandler 和 InteractiveHandler 里面
+type CollectionHandler struct {
+	svc service.CollectionService
+	l   logger.LoggerV1
+}
+
+func NewCollectionHandler(svc service.CollectionService, l logger.LoggerV1) *CollectionHandler {
+	return &CollectionHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *CollectionHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/collections")
+	g.POST("/create", h.Create)
+	g.POST("/rename", h.Rename)
+	g.POST("/public", h.SetPublic)
+	g.POST("/delete", h.Delete)
+	g.POST("/list", h.List)
+	g.POST("/articles", h.ListArticles)
+}
+
+func (h *CollectionHandler) Create(ctx *gin.Context) {
+	type Req struct {
+		Name   string `json:"name"`
+		Public bool   `json:"public"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	id, err := h.svc.Create(ctx, domain.Collection{
+		Uid:    uc.Uid,
+		Name:   req.Name,
+		Public: req.Public,
+	})
+	if err != nil {
+		h.handleErr(ctx, err, "创建收藏夹失败", uc.Uid, 0)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: id,
+	})
+}
+
+func (h *CollectionHandler) Rename(ctx *gin.Context) {
+	type Req struct {
+		Id   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Rename(ctx, domain.Collection{
+		Id:   req.Id,
+		Uid:  uc.Uid,
+		Name: req.Name,
+	})
+	if err != nil {
+		h.handleErr(ctx, err, "修改收藏夹失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// SetPublic 公开的收藏夹别人也能看到里面的文章
+func (h *CollectionHandler) SetPublic(ctx *gin.Context) {
+	type Req struct {
+		Id     int64 `json:"id"`
+		Public bool  `json:"public"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.SetPublic(ctx, uc.Uid, req.Id, req.Public)
+	if err != nil {
+		h.handleErr(ctx, err, "修改收藏夹失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// Delete move 是 true 的时候里面的收藏移到收藏夹 to，0 是默认收藏夹，否则连收藏一起删掉
+func (h *CollectionHandler) Delete(ctx *gin.Context) {
+	type Req struct {
+		Id   int64 `json:"id"`
+		Move bool  `json:"move"`
+		To   int64 `json:"to"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	var err error
+	if req.Move {
+		err = h.svc.DeleteAndMove(ctx, uc.Uid, req.Id, req.To)
+	} else {
+		err = h.svc.Delete(ctx, uc.Uid, req.Id)
+	}
+	if err != nil {
+		h.handleErr(ctx, err, "删除收藏夹失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// List uid 不传是自己的收藏夹，否则是这个用户公开的收藏夹
+func (h *CollectionHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Uid int64 `json:"uid"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	owner := req.Uid
+	if owner <= 0 {
+		owner = uc.Uid
+	}
+	cs, err := h.svc.List(ctx, uc.Uid, owner)
+	if err != nil {
+		h.handleErr(ctx, err, "查找收藏夹失败", uc.Uid, 0)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: slice.Map[domain.Collection, CollectionVo](cs, func(idx int, src domain.Collection) CollectionVo {
+			return CollectionVo{
+				Id:     src.Id,
+				Uid:    src.Uid,
+				Name:   src.Name,
+				Public: src.Public,
+				Ctime:  src.Ctime.Format(time.DateTime),
+				Utime:  src.Utime.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+// ListArticles id 是 0 代表自己的默认收藏夹
+func (h *CollectionHandler) ListArticles(ctx *gin.Context) {
+	type Req struct {
+		Id     int64 `json:"id"`
+		Offset int   `json:"offset"`
+		Limit  int   `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !checkPage(ctx, Page{Offset: req.Offset, Limit: req.Limit}) {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	items, err := h.svc.ListArticles(ctx, uc.Uid, req.Id, req.Offset, req.Limit)
+	if err != nil {
+		h.handleErr(ctx, err, "查找收藏夹里面的文章失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: slice.Map[domain.CollectionItem, CollectionItemVo](items, func(idx int, src domain.CollectionItem) CollectionItemVo {
+			res := CollectionItemVo{
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Ctime: src.Ctime.Format(time.DateTime),
+			}
+			if src.Biz != "article" {
+				return res
+			}
+			art := src.Article
+			vo := ArticleVo{
+				Id:     src.BizId,
+				Status: art.Status.ToUint8(),
+			}
+			if art.Status == domain.ArticleStatusPublished {
+				vo.Title = art.Title
+				vo.Abstract = art.Abstract()
+				vo.AuthorId = art.Author.Id
+				vo.AuthorName = art.Author.Name
+				vo.Utime = art.Utime.Format(time.DateTime)
+			}
+			res.Article = &vo
+			return res
+		}),
+	})
+}
+
+func (h *CollectionHandler) handleErr(ctx *gin.Context, err error, msg string, uid int64, cid int64) {
+	switch {
+	case errors.Is(err, service.ErrCollectionInvalid):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "名字不能为空，也不能超过" + strconv.Itoa(domain.CollectionNameMaxLength) + "个字",
+		})
+	case errors.Is(err, service.ErrCollectionNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error(msg,
+			logger.Error(err),
+			logger.Int64("uid", uid),
+			logger.Int64("cid", cid))
+	}
+}
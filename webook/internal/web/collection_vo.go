@@ -0,0 +1,20 @@
+package web
+
+type CollectionVo struct {
+	Id     int64  `json:"id"`
+	Uid    int64  `json:"uid"`
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+	Ctime  string `json:"ctime"`
+	Utime  string `json:"utime"`
+}
+
+type CollectionItemVo struct {
+	// Biz 和 BizId 收藏的是什么，除了文章还可以收藏评论、系列这些
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// Article 只有收藏的是文章的时候才有，撤回或者删除了的文章只有 id 和 status
+	Article *ArticleVo `json:"article,omitempty"`
+	// Ctime 收藏的时间
+	Ctime string `json:"ctime"`
+}
@@ -0,0 +1,191 @@
+package web
+
+import (
+	"errors"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web/jwt"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CommentHandler 文章的评论，看评论不需要登录
+type CommentHandler struct {
+	svc service.CommentService
+	l   logger.LoggerV1
+	biz string
+}
+
+func NewCommentHandler(svc service.CommentService, l logger.LoggerV1) *CommentHandler {
+	return &CommentHandler{
+		svc: svc,
+		l:   l,
+		biz: "article",
+	}
+}
+
+func (h *CommentHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles/pub/comments")
+	g.GET("", h.ListRoots)
+	g.GET("/replies", h.ListReplies)
+	g.POST("/create", h.Create)
+	g.POST("/delete", h.Delete)
+}
+
+func (h *CommentHandler) Create(ctx *gin.Context) {
+	type Req struct {
+		// Id 文章的 id
+		Id int64 `json:"id"`
+		// ParentId 回复的评论，发表根评论的时候不传
+		ParentId int64  `json:"parentId"`
+		Content  string `json:"content"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	content, ok := domain.NormalizeCommentContent(req.Content)
+	if !ok {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "评论不能为空，也不能超过" + strconv.Itoa(domain.CommentMaxLength) + "个字",
+		})
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	id, err := h.svc.Create(ctx, domain.Comment{
+		Biz:         h.biz,
+		BizId:       req.Id,
+		Commentator: domain.Author{Id: uc.Uid},
+		Content:     content,
+		ParentId:    req.ParentId,
+	})
+	if err != nil {
+		h.handleErr(ctx, err, "发表评论失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: id,
+	})
+}
+
+func (h *CommentHandler) Delete(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Delete(ctx, uc.Uid, req.Id)
+	if err != nil {
+		h.handleErr(ctx, err, "删除评论失败", uc.Uid, req.Id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// ListRoots 形如 /articles/pub/comments?id=1&offset=0&limit=10
+func (h *CommentHandler) ListRoots(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Query("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "id参数错误",
+		})
+		return
+	}
+	offset, limit, ok := queryPage(ctx)
+	if !ok {
+		return
+	}
+	cs, err := h.svc.ListRoots(ctx, h.biz, id, offset, limit)
+	if err != nil {
+		h.handleErr(ctx, err, "查找评论失败", 0, id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: h.toVos(cs),
+	})
+}
+
+// ListReplies 形如 /articles/pub/comments/replies?id=1&offset=0&limit=10，id 是根评论
+func (h *CommentHandler) ListReplies(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Query("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "id参数错误",
+		})
+		return
+	}
+	offset, limit, ok := queryPage(ctx)
+	if !ok {
+		return
+	}
+	cs, err := h.svc.ListReplies(ctx, id, offset, limit)
+	if err != nil {
+		h.handleErr(ctx, err, "查找回复失败", 0, id)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: h.toVos(cs),
+	})
+}
+
+func (h *CommentHandler) toVos(cs []domain.Comment) []CommentVo {
+	return slice.Map[domain.Comment, CommentVo](cs, func(idx int, src domain.Comment) CommentVo {
+		return CommentVo{
+			Id:       src.Id,
+			Uid:      src.Commentator.Id,
+			Name:     src.Commentator.Name,
+			Content:  src.Content,
+			ParentId: src.ParentId,
+			RootId:   src.RootId,
+			ReplyCnt: src.ReplyCnt,
+			Ctime:    src.Ctime.Format(time.DateTime),
+		}
+	})
+}
+
+func (h *CommentHandler) handleErr(ctx *gin.Context, err error, msg string, uid int64, id int64) {
+	switch {
+	case errors.Is(err, service.ErrCommentInvalid):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "评论不能为空，也不能超过" + strconv.Itoa(domain.CommentMaxLength) + "个字",
+		})
+	case errors.Is(err, service.ErrCommentBizNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		})
+	case errors.Is(err, service.ErrCommentNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "评论不存在",
+		})
+	case errors.Is(err, service.ErrCommentAccessDenied):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "没有权限删除这条评论",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error(msg,
+			logger.Error(err),
+			logger.Int64("uid", uid),
+			logger.Int64("id", id))
+	}
+}
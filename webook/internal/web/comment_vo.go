@@ -0,0 +1,15 @@
+package web
+
+type CommentVo struct {
+	Id      int64  `json:"id"`
+	Uid     int64  `json:"uid"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	// ParentId 回复的是哪条评论，根评论是 0
+	ParentId int64 `json:"parentId"`
+	// RootId 所在的根评论，根评论是 0
+	RootId int64 `json:"rootId"`
+	// ReplyCnt 只有根评论有
+	ReplyCnt int64  `json:"replyCnt"`
+	Ctime    string `json:"ctime"`
+}
@@ -0,0 +1,170 @@
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/pkg/feedx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeedHandler RSS 和 Atom 订阅源，阅读器不会登录。
+// 地址是 /feeds/authors/123.rss、/feeds/authors/123.atom 和 /feeds/latest.rss、/feeds/latest.atom
+type FeedHandler struct {
+	svc service.FeedService
+	// baseURL 网站的地址，订阅源里面的链接都要是绝对地址
+	baseURL string
+	l       logger.LoggerV1
+}
+
+func NewFeedHandler(svc service.FeedService, baseURL string, l logger.LoggerV1) *FeedHandler {
+	return &FeedHandler{
+		svc:     svc,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		l:       l,
+	}
+}
+
+func (h *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/feeds")
+	// gin 的路径参数不能带后缀，只能自己拆
+	g.GET("/authors/:file", h.AuthorFeed)
+	g.GET("/:file", h.LatestFeed)
+}
+
+func (h *FeedHandler) AuthorFeed(ctx *gin.Context) {
+	name, format, ok := splitFeedFile(ctx.Param("file"))
+	if !ok {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	uid, err := strconv.ParseInt(name, 10, 64)
+	if err != nil || uid <= 0 {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	f, err := h.svc.AuthorFeed(ctx, uid)
+	if errors.Is(err, service.ErrFeedAuthorNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		ctx.Status(http.StatusInternalServerError)
+		h.l.Error("查询作者的订阅源失败",
+			logger.Int64("uid", uid),
+			logger.Error(err))
+		return
+	}
+	h.render(ctx, format, feedx.Feed{
+		Title:       f.Author.Name + " 的文章",
+		Description: f.Author.Name + " 最新发表的文章",
+		Link:        fmt.Sprintf("%s/articles/pub/authors/%d", h.baseURL, uid),
+	}, f)
+}
+
+func (h *FeedHandler) LatestFeed(ctx *gin.Context) {
+	name, format, ok := splitFeedFile(ctx.Param("file"))
+	if !ok || name != "latest" {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	f, err := h.svc.LatestFeed(ctx)
+	if err != nil {
+		ctx.Status(http.StatusInternalServerError)
+		h.l.Error("查询全站的订阅源失败", logger.Error(err))
+		return
+	}
+	h.render(ctx, format, feedx.Feed{
+		Title:       "webook",
+		Description: "webook 最新发表的文章",
+		Link:        h.baseURL,
+	}, f)
+}
+
+// render 内容没变的时候返回 304，阅读器定时拉取的时候不用每次都下载一遍
+func (h *FeedHandler) render(ctx *gin.Context, format string, feed feedx.Feed, f domain.Feed) {
+	feed.Self = h.baseURL + ctx.Request.URL.Path
+	feed.Updated = f.Utime
+	feed.Items = make([]feedx.Item, 0, len(f.Articles))
+	for _, art := range f.Articles {
+		feed.Items = append(feed.Items, feedx.Item{
+			Title:     art.Title,
+			Link:      fmt.Sprintf("%s/articles/pub/%d", h.baseURL, art.Id),
+			Author:    art.Author.Name,
+			Summary:   art.Abstract(),
+			Content:   art.Html,
+			Published: art.Ctime,
+			Updated:   art.Utime,
+		})
+	}
+	var (
+		data        []byte
+		err         error
+		contentType string
+	)
+	if format == "atom" {
+		data, err = feed.Atom()
+		contentType = feedx.ContentTypeAtom
+	} else {
+		data, err = feed.RSS()
+		contentType = feedx.ContentTypeRSS
+	}
+	if err != nil {
+		ctx.Status(http.StatusInternalServerError)
+		h.l.Error("生成订阅源失败",
+			logger.String("path", ctx.Request.URL.Path),
+			logger.Error(err))
+		return
+	}
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	ctx.Header("ETag", etag)
+	if !f.Utime.IsZero() {
+		ctx.Header("Last-Modified", f.Utime.UTC().Format(http.TimeFormat))
+	}
+	if notModified(ctx.Request, etag, f.Utime) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+// splitFeedFile 把 123.rss 拆成 123 和 rss，只支持 rss 和 atom
+func splitFeedFile(file string) (string, string, bool) {
+	name, format, ok := strings.Cut(file, ".")
+	if !ok || name == "" || (format != "rss" && format != "atom") {
+		return "", "", false
+	}
+	return name, format, true
+}
+
+// notModified 和 net/http 一样，有 If-None-Match 的时候就不看 If-Modified-Since 了
+func notModified(req *http.Request, etag string, utime time.Time) bool {
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	ims := req.Header.Get("If-Modified-Since")
+	if ims == "" || utime.IsZero() {
+		return false
+	}
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// HTTP 的时间只精确到秒
+	return !utime.Truncate(time.Second).After(t)
+}
@@ -0,0 +1,185 @@
+package web
+
+import (
+	"errors"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web/jwt"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// InteractiveHandler 通用的点赞、收藏接口，形如 /interactive/comment/123/like。
+// 支持哪些 biz 看 ioc.InitBizRegistry
+type InteractiveHandler struct {
+	svc service.InteractiveService
+	l   logger.LoggerV1
+}
+
+func NewInteractiveHandler(svc service.InteractiveService, l logger.LoggerV1) *InteractiveHandler {
+	return &InteractiveHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *InteractiveHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/interactive/:biz/:id")
+	// 不需要登录，登录了的话带上有没有点赞、收藏
+	g.GET("", h.Get)
+	g.POST("/like", h.Like)
+	g.POST("/collect", h.Collect)
+	g.POST("/uncollect", h.CancelCollect)
+}
+
+func (h *InteractiveHandler) Get(ctx *gin.Context) {
+	biz, id, ok := h.bizParams(ctx)
+	if !ok {
+		return
+	}
+	uid := optionalUid(ctx)
+	intr, err := h.svc.Get(ctx, biz, id, uid)
+	if err != nil {
+		h.handleErr(ctx, err, "查找互动数据失败", biz, id, uid)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: InteractiveVo{
+			Biz:        biz,
+			BizId:      id,
+			ReadCnt:    intr.ReadCnt,
+			LikeCnt:    intr.LikeCnt,
+			CollectCnt: intr.CollectCnt,
+			CommentCnt: intr.CommentCnt,
+			Liked:      intr.Liked,
+			Collected:  intr.Collected,
+		},
+	})
+}
+
+func (h *InteractiveHandler) Like(ctx *gin.Context) {
+	type Req struct {
+		Like bool `json:"like"` // true 点赞，false 取消点赞
+	}
+	biz, id, ok := h.bizParams(ctx)
+	if !ok {
+		return
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	var err error
+	if req.Like {
+		err = h.svc.Like(ctx, biz, id, uc.Uid)
+	} else {
+		err = h.svc.CancelLike(ctx, biz, id, uc.Uid)
+	}
+	if err != nil {
+		h.handleErr(ctx, err, "点赞/取消点赞失败", biz, id, uc.Uid)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+func (h *InteractiveHandler) Collect(ctx *gin.Context) {
+	type Req struct {
+		Cid int64 `json:"cid"` // 收藏夹 id，0 是默认收藏夹
+	}
+	biz, id, ok := h.bizParams(ctx)
+	if !ok {
+		return
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.Collect(ctx, biz, id, req.Cid, uc.Uid)
+	if errors.Is(err, service.ErrCollectionNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+		return
+	}
+	if err != nil {
+		h.handleErr(ctx, err, "收藏失败", biz, id, uc.Uid)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// CancelCollect 不管在哪个收藏夹里面都会取消
+func (h *InteractiveHandler) CancelCollect(ctx *gin.Context) {
+	biz, id, ok := h.bizParams(ctx)
+	if !ok {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := h.svc.CancelCollect(ctx, biz, id, uc.Uid)
+	if errors.Is(err, service.ErrCollectionItemNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "没有收藏过",
+		})
+		return
+	}
+	if err != nil {
+		h.handleErr(ctx, err, "取消收藏失败", biz, id, uc.Uid)
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Msg: "OK",
+	})
+}
+
+// bizParams 解析路径里面的 biz 和 id，参数不对的时候已经写好了响应
+func (h *InteractiveHandler) bizParams(ctx *gin.Context) (string, int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "id参数错误",
+		})
+		return "", 0, false
+	}
+	return ctx.Param("biz"), id, true
+}
+
+func (h *InteractiveHandler) handleErr(ctx *gin.Context, err error, msg string, biz string, id int64, uid int64) {
+	switch {
+	case errors.Is(err, service.ErrBizNotSupported):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "不支持的业务",
+		})
+	case errors.Is(err, service.ErrBizNotFound):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "资源不存在",
+		})
+	case errors.Is(err, service.ErrLikeSelf):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "不能给自己点赞",
+		})
+	default:
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error(msg,
+			logger.Error(err),
+			logger.String("biz", biz),
+			logger.Int64("bizId", id),
+			logger.Int64("uid", uid))
+	}
+}
@@ -0,0 +1,13 @@
+package web
+
+type InteractiveVo struct {
+	Biz        string `json:"biz"`
+	BizId      int64  `json:"bizId"`
+	ReadCnt    int64  `json:"readCnt"`
+	LikeCnt    int64  `json:"likeCnt"`
+	CollectCnt int64  `json:"collectCnt"`
+	CommentCnt int64  `json:"commentCnt"`
+	// Liked 和 Collected 没有登录的时候都是 false
+	Liked     bool `json:"liked"`
+	Collected bool `json:"collected"`
+}
@@ -0,0 +1,63 @@
+package web
+
+import (
+	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// RankingHandler 热榜，不需要登录
+type RankingHandler struct {
+	svc service.RankingService
+	l   logger.LoggerV1
+}
+
+func NewRankingHandler(svc service.RankingService, l logger.LoggerV1) *RankingHandler {
+	return &RankingHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *RankingHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/articles/pub/hot", h.Hot)
+}
+
+// Hot 按照分数从高到低，互动数据是计算热榜时候的，不是实时的
+func (h *RankingHandler) Hot(ctx *gin.Context) {
+	arts, err := h.svc.GetTopN(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查找热榜失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: slice.Map[domain.HotArticle, ArticleVo](arts, func(idx int, src domain.HotArticle) ArticleVo {
+			return ArticleVo{
+				Id:         src.Article.Id,
+				Title:      src.Article.Title,
+				Abstract:   src.Article.Abstract(),
+				AuthorId:   src.Article.Author.Id,
+				AuthorName: src.Article.Author.Name,
+				Tags:       src.Article.Tags,
+				Utime:      src.Article.Utime.Format(time.DateTime),
+				ReadCnt:    src.Intr.ReadCnt,
+				LikeCnt:    src.Intr.LikeCnt,
+				CollectCnt: src.Intr.CollectCnt,
+
+				WordCnt:      src.Article.Stats.WordCnt,
+				ReadMinutes:  src.Article.Stats.ReadMinutes,
+				ImageCnt:     src.Article.Stats.ImageCnt,
+				CodeBlockCnt: src.Article.Stats.CodeBlockCnt,
+			}
+		}),
+	})
+}
@@ -179,7 +179,6 @@ func (h *UserHandler) SignUp(ctx *gin.Context, req SignUpReq) (ginx.Result, erro
 
 	switch {
 	case err == nil:
-		ctx.String(http.StatusOK, "Hello, 恭喜注册成功")
 		return ginx.Result{
 			Msg: "Hello, 恭喜注册成功",
 		}, nil
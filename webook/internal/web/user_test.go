@@ -3,10 +3,14 @@ package web
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"geek-basic-go/webook/internal/domain"
+	"geek-basic-go/webook/internal/errs"
 	"geek-basic-go/webook/internal/service"
 	svcmocks "geek-basic-go/webook/internal/service/mocks"
+	"geek-basic-go/webook/pkg/ginx"
+	"geek-basic-go/webook/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,7 +31,7 @@ func TestUserHandler_SignUp(t *testing.T) {
 		// 预期response code
 		wantedCode int
 		// 预期response body
-		wantedBody string
+		wantedRes ginx.Result
 	}{
 		{
 			name: "注册成功",
@@ -51,7 +55,9 @@ func TestUserHandler_SignUp(t *testing.T) {
 				return req
 			},
 			wantedCode: http.StatusOK,
-			wantedBody: "Hello, 恭喜注册成功",
+			wantedRes: ginx.Result{
+				Msg: "Hello, 恭喜注册成功",
+			},
 		},
 		{
 			name: "Bind出错",
@@ -71,7 +77,6 @@ func TestUserHandler_SignUp(t *testing.T) {
 				return req
 			},
 			wantedCode: http.StatusBadRequest,
-			wantedBody: "",
 		},
 		{
 			name: "邮箱格式不对",
@@ -92,7 +97,10 @@ func TestUserHandler_SignUp(t *testing.T) {
 				return req
 			},
 			wantedCode: http.StatusOK,
-			wantedBody: "邮箱格式不正确",
+			wantedRes: ginx.Result{
+				Code: errs.UserInvalidInput,
+				Msg:  "邮箱格式不正确",
+			},
 		},
 		{
 			name: "两次输入密码不一致",
@@ -113,7 +121,10 @@ func TestUserHandler_SignUp(t *testing.T) {
 				return req
 			},
 			wantedCode: http.StatusOK,
-			wantedBody: "两次输入密码不一致",
+			wantedRes: ginx.Result{
+				Code: errs.UserInvalidInput,
+				Msg:  "两次输入密码不一致",
+			},
 		},
 		{
 			name: "密码格式不对",
@@ -134,7 +145,10 @@ func TestUserHandler_SignUp(t *testing.T) {
 				return req
 			},
 			wantedCode: http.StatusOK,
-			wantedBody: "密码必须包含数字、特殊字符，并且长度不能小于8位",
+			wantedRes: ginx.Result{
+				Code: errs.UserInvalidInput,
+				Msg:  "密码必须包含数字、特殊字符，并且长度不能小于8位",
+			},
 		},
 		{
 			name: "系统错误",
@@ -158,7 +172,10 @@ func TestUserHandler_SignUp(t *testing.T) {
 				return req
 			},
 			wantedCode: http.StatusOK,
-			wantedBody: "系统错误！",
+			wantedRes: ginx.Result{
+				Code: errs.UserInternalServerError,
+				Msg:  "系统错误！",
+			},
 		},
 		{
 			name: "邮箱冲突",
@@ -182,7 +199,10 @@ func TestUserHandler_SignUp(t *testing.T) {
 				return req
 			},
 			wantedCode: http.StatusOK,
-			wantedBody: "注册用户失败:邮箱已被注册，请换个邮箱重新申请",
+			wantedRes: ginx.Result{
+				Code: errs.UserDuplicateEmail,
+				Msg:  "注册用户失败:邮箱已被注册，请换个邮箱重新申请",
+			},
 		},
 	}
 
@@ -194,7 +214,7 @@ func TestUserHandler_SignUp(t *testing.T) {
 			// mock UserService 和 CodService
 			userSvc, codeSvc := tc.mock(ctrl)
 			// 创建UserHandler
-			hdl := NewUserHandler(userSvc, codeSvc, nil)
+			hdl := NewUserHandler(userSvc, codeSvc, nil, logger.NewNopLogger())
 			// 注册路由
 			server := gin.Default()
 			hdl.RegisterRoutes(server)
@@ -206,8 +226,14 @@ func TestUserHandler_SignUp(t *testing.T) {
 			server.ServeHTTP(recorder, req)
 			// Check the response code
 			assert.Equal(t, tc.wantedCode, recorder.Code)
+			if tc.wantedCode != http.StatusOK {
+				return
+			}
 			// Check the response body
-			assert.Equal(t, tc.wantedBody, recorder.Body.String())
+			var res ginx.Result
+			err := json.NewDecoder(recorder.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantedRes, res)
 		})
 	}
 }
@@ -236,7 +262,7 @@ func TestUserEmailPattern(t *testing.T) {
 			match: true,
 		},
 	}
-	h := NewUserHandler(nil, nil, nil)
+	h := NewUserHandler(nil, nil, nil, logger.NewNopLogger())
 	// 执行测试用例
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,40 @@
+package ioc
+
+import (
+	"fmt"
+	"geek-basic-go/webook/internal/repository/dao"
+	"geek-basic-go/webook/pkg/blobx"
+	"github.com/bwmarrin/snowflake"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const (
+	ArticleStorageGORM    = "gorm"
+	ArticleStorageMongoDB = "mongodb"
+	ArticleStorageBlob    = "blob"
+	// ArticleStorageS3 之前的配置名字，和 blob 一样
+	ArticleStorageS3 = "s3"
+)
+
+// InitArticleDao 根据 article.storage 选择文章的存储，默认用 GORM
+// 只有选中的存储才会建立连接，用 GORM 的时候不需要配置 MongoDB 和对象存储
+func InitArticleDao(db *gorm.DB, store blobx.BlobStore) dao.ArticleDao {
+	viper.SetDefault("article.storage", ArticleStorageGORM)
+	storage := viper.GetString("article.storage")
+	switch storage {
+	case ArticleStorageGORM:
+		return dao.NewGormDBArticleDao(db)
+	case ArticleStorageMongoDB:
+		viper.SetDefault("mongodb.node", 1)
+		node, err := snowflake.NewNode(viper.GetInt64("mongodb.node"))
+		if err != nil {
+			panic(err)
+		}
+		return dao.NewMongoDBArticleDao(InitMongoDB(), node)
+	case ArticleStorageBlob, ArticleStorageS3:
+		return dao.NewArticleBlobDao(db, store)
+	default:
+		panic(fmt.Sprintf("未知的文章存储 %s", storage))
+	}
+}
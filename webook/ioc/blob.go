@@ -0,0 +1,33 @@
+package ioc
+
+import (
+	"fmt"
+	"geek-basic-go/webook/pkg/blobx"
+	"github.com/spf13/viper"
+)
+
+// InitBlobStore 根据 blob.type 选择对象存储，默认存在本地磁盘上
+func InitBlobStore() blobx.BlobStore {
+	type Config struct {
+		Type   string `yaml:"type"`
+		Dir    string `yaml:"dir"`
+		Bucket string `yaml:"bucket"`
+		Prefix string `yaml:"prefix"`
+	}
+	cfg := Config{
+		Type: "local",
+		Dir:  "./data/blob",
+	}
+	err := viper.UnmarshalKey("blob", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	switch cfg.Type {
+	case "local":
+		return blobx.NewLocalBlobStore(cfg.Dir, cfg.Prefix)
+	case "s3":
+		return blobx.NewS3BlobStore(InitOSS(), cfg.Bucket, cfg.Prefix)
+	default:
+		panic(fmt.Sprintf("未知的对象存储 %s", cfg.Type))
+	}
+}
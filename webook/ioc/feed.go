@@ -0,0 +1,17 @@
+package ioc
+
+import (
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/spf13/viper"
+)
+
+// InitFeedHandler feed.baseURL 是网站的地址，订阅源里面的链接都是基于它拼出来的
+func InitFeedHandler(svc service.FeedService, l logger.LoggerV1) *web.FeedHandler {
+	baseURL := viper.GetString("feed.baseURL")
+	if baseURL == "" {
+		l.Warn("没有配置订阅源的网站地址，订阅源里面的链接都是相对地址")
+	}
+	return web.NewFeedHandler(svc, baseURL, l)
+}
@@ -0,0 +1,19 @@
+package ioc
+
+import (
+	"geek-basic-go/webook/internal/repository"
+	"geek-basic-go/webook/internal/service"
+)
+
+// InitBizRegistry 新的业务要支持点赞、收藏，在这里注册
+func InitBizRegistry(artRepo repository.ArticleRepository,
+	commentRepo repository.CommentRepository,
+	seriesRepo repository.ArticleSeriesRepository,
+	userRepo repository.UserRepository) *service.BizRegistry {
+	r := service.NewBizRegistry()
+	r.Register("article", service.NewArticleInteractiveBiz(artRepo))
+	r.Register("comment", service.NewCommentInteractiveBiz(commentRepo))
+	r.Register("series", service.NewArticleSeriesInteractiveBiz(seriesRepo))
+	r.Register("user", service.NewUserInteractiveBiz(userRepo))
+	return r
+}
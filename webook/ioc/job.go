@@ -0,0 +1,30 @@
+package ioc
+
+import (
+	"geek-basic-go/webook/internal/job"
+	"geek-basic-go/webook/pkg/logger"
+	"time"
+)
+
+func InitJobs(l logger.LoggerV1,
+	articleScheduleJob *job.ArticleScheduleJob,
+	articleAssetGCJob *job.ArticleAssetGCJob,
+	articleTrashPurgeJob *job.ArticleTrashPurgeJob,
+	articleExportJob *job.ArticleExportJob,
+	articleSearchRefreshJob *job.ArticleSearchRefreshJob,
+	articleReviewReconcileJob *job.ArticleReviewReconcileJob,
+	rankingJob *job.RankingJob) []*job.IntervalRunner {
+	return []*job.IntervalRunner{
+		// 定时发表的精度是 10 秒
+		job.NewIntervalRunner(articleScheduleJob, time.Second*10, time.Second*30, l),
+		job.NewIntervalRunner(articleAssetGCJob, time.Hour, time.Minute*10, l),
+		job.NewIntervalRunner(articleTrashPurgeJob, time.Hour, time.Minute*10, l),
+		// 导出一般几秒钟就能开始，超时和 articleExportTimeout 无关，每一批都会更新进度
+		job.NewIntervalRunner(articleExportJob, time.Second*10, time.Minute*10, l),
+		// 其他实例上发表或者撤回的文章最多几分钟之后就能在当前实例上搜到或者搜不到
+		job.NewIntervalRunner(articleSearchRefreshJob, time.Minute*5, time.Minute*2, l),
+		job.NewIntervalRunner(articleReviewReconcileJob, time.Minute*10, time.Minute*10, l),
+		// 热榜几分钟更新一次就够了，超时时间要比 RankingJob 里面锁的过期时间短
+		job.NewIntervalRunner(rankingJob, time.Minute*3, time.Minute, l),
+	}
+}
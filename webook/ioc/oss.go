@@ -0,0 +1,35 @@
+package ioc
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/ecodeclub/ekit"
+	"github.com/spf13/viper"
+	"os"
+)
+
+// InitOSS 腾讯云的 COS 兼容 S3 的协议，密钥从环境变量里面读
+func InitOSS() *s3.S3 {
+	type Config struct {
+		Endpoint string `yaml:"endpoint"`
+		Region   string `yaml:"region"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("oss", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials(os.Getenv("COS_APP_ID"), os.Getenv("COS_APP_SECRET"), ""),
+		Region:      ekit.ToPtr[string](cfg.Region),
+		Endpoint:    ekit.ToPtr[string](cfg.Endpoint),
+		// 强制使用 /bucket/key 的形态
+		S3ForcePathStyle: ekit.ToPtr[bool](true),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return s3.New(sess)
+}
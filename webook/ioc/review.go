@@ -0,0 +1,36 @@
+package ioc
+
+import (
+	"geek-basic-go/webook/internal/service"
+	"geek-basic-go/webook/internal/web"
+	"geek-basic-go/webook/pkg/logger"
+	"geek-basic-go/webook/pkg/sensitive"
+	"github.com/spf13/viper"
+)
+
+// InitSensitiveFilter 没有配置 review.dict 的时候不审核，所有文章直接发表
+func InitSensitiveFilter(l logger.LoggerV1) sensitive.Filter {
+	path := viper.GetString("review.dict")
+	if path == "" {
+		l.Info("没有配置敏感词词典，发表文章不审核")
+		return sensitive.NewACFilter(nil)
+	}
+	f, err := sensitive.LoadACFilter(path)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// InitArticleReviewHandler review.admins 是可以审核文章的用户 id
+func InitArticleReviewHandler(svc service.ArticleReviewService, l logger.LoggerV1) *web.ArticleReviewHandler {
+	var admins []int64
+	err := viper.UnmarshalKey("review.admins", &admins)
+	if err != nil {
+		panic(err)
+	}
+	if len(admins) == 0 {
+		l.Warn("没有配置审核文章的管理员")
+	}
+	return web.NewArticleReviewHandler(svc, admins, l)
+}
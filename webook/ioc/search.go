@@ -0,0 +1,22 @@
+package ioc
+
+import (
+	"context"
+	"geek-basic-go/webook/internal/repository/dao"
+	"geek-basic-go/webook/pkg/logger"
+	"time"
+)
+
+// InitArticleSearchDao 进程内的索引，启动的时候通过 ArticleDao 从线上库重建，
+// 之后由 ArticleSearchRefreshJob 定时重建
+func InitArticleSearchDao(artDao dao.ArticleDao, l logger.LoggerV1) *dao.MemoryArticleSearchDao {
+	searchDao := dao.NewMemoryArticleSearchDao()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	err := searchDao.Load(ctx, artDao)
+	if err != nil {
+		// 索引不完整只影响搜索，不影响启动
+		l.Error("加载文章搜索索引失败", logger.Error(err))
+	}
+	return searchDao
+}
@@ -19,12 +19,32 @@ import (
 func InitWebServer(mdls []gin.HandlerFunc,
 	userHdl *web.UserHandler,
 	wechatHdl *web.OAuth2WechatHandler,
-	articleHdl *web.ArticleHandler) *gin.Engine {
+	articleHdl *web.ArticleHandler,
+	assetHdl *web.ArticleAssetHandler,
+	collabHdl *web.ArticleCollaboratorHandler,
+	commentHdl *web.CommentHandler,
+	reviewHdl *web.ArticleReviewHandler,
+	feedHdl *web.FeedHandler,
+	seriesHdl *web.ArticleSeriesHandler,
+	backupHdl *web.ArticleBackupHandler,
+	rankingHdl *web.RankingHandler,
+	collectionHdl *web.CollectionHandler,
+	intrHdl *web.InteractiveHandler) *gin.Engine {
 	server := gin.Default()
 	server.Use(mdls...)
 	userHdl.RegisterRoutes(server)
 	wechatHdl.RegisterRoutes(server)
 	articleHdl.RegisterRoutes(server)
+	assetHdl.RegisterRoutes(server)
+	collabHdl.RegisterRoutes(server)
+	commentHdl.RegisterRoutes(server)
+	reviewHdl.RegisterRoutes(server)
+	feedHdl.RegisterRoutes(server)
+	seriesHdl.RegisterRoutes(server)
+	backupHdl.RegisterRoutes(server)
+	rankingHdl.RegisterRoutes(server)
+	collectionHdl.RegisterRoutes(server)
+	intrHdl.RegisterRoutes(server)
 	return server
 }
 
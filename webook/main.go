@@ -37,6 +37,12 @@ func main() {
 			panic(err)
 		}
 	}
+	for _, j := range app.jobs {
+		err := j.Start()
+		if err != nil {
+			panic(err)
+		}
+	}
 	server := app.server
 	server.GET("/hello", func(context *gin.Context) {
 		// context核心职责：处理请求，返回响应
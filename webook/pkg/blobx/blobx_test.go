@@ -0,0 +1,108 @@
+package blobx
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/ecodeclub/ekit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestBlobStore(t *testing.T) {
+	testCases := []struct {
+		name     string
+		newStore func(t *testing.T) BlobStore
+	}{
+		{
+			name: "本地磁盘",
+			newStore: func(t *testing.T) BlobStore {
+				return NewLocalBlobStore(t.TempDir(), "articles/")
+			},
+		},
+		{
+			name: "S3",
+			newStore: func(t *testing.T) BlobStore {
+				server := httptest.NewServer(&fakeS3{objects: map[string][]byte{}})
+				t.Cleanup(server.Close)
+				sess, err := session.NewSession(&aws.Config{
+					Credentials:      credentials.NewStaticCredentials("id", "secret", ""),
+					Region:           ekit.ToPtr[string]("ap-nanjing"),
+					Endpoint:         ekit.ToPtr[string](server.URL),
+					S3ForcePathStyle: ekit.ToPtr[bool](true),
+				})
+				require.NoError(t, err)
+				return NewS3BlobStore(s3.New(sess), "webook", "articles/")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := tc.newStore(t)
+			ctx := context.Background()
+			_, err := store.Get(ctx, "1")
+			assert.ErrorIs(t, err, ErrBlobNotFound)
+
+			require.NoError(t, store.Put(ctx, "1", []byte("内容"), "text/plain;charset=utf-8"))
+			require.NoError(t, store.Put(ctx, "1", []byte("新内容"), "text/plain;charset=utf-8"))
+			data, err := store.Get(ctx, "1")
+			require.NoError(t, err)
+			assert.Equal(t, "新内容", string(data))
+
+			require.NoError(t, store.Delete(ctx, "1"))
+			_, err = store.Get(ctx, "1")
+			assert.ErrorIs(t, err, ErrBlobNotFound)
+			// 重复删除不是错误
+			assert.NoError(t, store.Delete(ctx, "1"))
+		})
+	}
+}
+
+func TestLocalBlobStore_Path(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalBlobStore(filepath.Join(dir, "blob"), "articles/")
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "2024/1", []byte("内容"), ""))
+	_, err := os.Stat(filepath.Join(dir, "blob", "articles", "2024", "1"))
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "../../secret", "a/"} {
+		assert.Error(t, store.Put(ctx, key, []byte("x"), ""), key)
+	}
+}
+
+// fakeS3 只实现了 PUT、GET 和 DELETE
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`))
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,82 @@
+package blobx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStore 存在本地磁盘上，开发和单机部署的时候不需要云服务
+type LocalBlobStore struct {
+	dir    string
+	prefix string
+}
+
+func NewLocalBlobStore(dir string, prefix string) *LocalBlobStore {
+	return &LocalBlobStore{
+		dir:    dir,
+		prefix: prefix,
+	}
+}
+
+func (l *LocalBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+	// 先写临时文件再改名，读的人不会读到写了一半的内容
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if er := tmp.Close(); err == nil {
+		err = er
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (l *LocalBlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return data, err
+}
+
+func (l *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path 不允许 key 跳出 dir
+func (l *LocalBlobStore) path(key string) (string, error) {
+	name := l.prefix + key
+	if name == "" || !filepath.IsLocal(filepath.FromSlash(name)) || strings.HasSuffix(name, "/") {
+		return "", fmt.Errorf("非法的 key %s", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(name)), nil
+}
@@ -0,0 +1,17 @@
+package blobx
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrBlobNotFound = errors.New("blob 不存在")
+
+// BlobStore 对象存储，key 是相对于实现里面配置的前缀的
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get key 不存在的时候返回 ErrBlobNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete key 不存在的时候不返回错误
+	Delete(ctx context.Context, key string) error
+}
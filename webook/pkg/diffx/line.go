@@ -0,0 +1,139 @@
+package diffx
+
+import "strings"
+
+type Op uint8
+
+const (
+	// OpEqual 两边都有的行
+	OpEqual Op = iota
+	// OpInsert 新版本增加的行
+	OpInsert
+	// OpDelete 旧版本删掉的行
+	OpDelete
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpInsert:
+		return "insert"
+	case OpDelete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines 按行比较 from 和 to，返回从 from 变成 to 的编辑脚本
+// 使用 Myers 算法，时间复杂度 O((N+M)D)，D 是差异的行数
+func Lines(from, to string) []Line {
+	return diff(splitLines(from), splitLines(to))
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+func diff(a, b []string) []Line {
+	// 先去掉公共的前缀和后缀，文章的修改一般都比较集中
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	res := make([]Line, 0, len(a)+len(b))
+	for _, l := range a[:prefix] {
+		res = append(res, Line{Op: OpEqual, Text: l})
+	}
+	res = append(res, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, l := range a[len(a)-suffix:] {
+		res = append(res, Line{Op: OpEqual, Text: l})
+	}
+	return res
+}
+
+func myers(a, b []string) []Line {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	maxD := n + m
+	offset := maxD
+	v := make([]int, 2*maxD+2)
+	// trace 记录每一步开始之前的 v，用于回溯出编辑路径。
+	// 第 d 步只会读到 [-d, d+1] 范围里面的 k，所以只保存这一段，总共占用 O(D^2) 而不是 O(D(N+M))
+	var trace [][]int
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, 2*d+2)
+		copy(snapshot, v[offset-d:offset+d+2])
+		trace = append(trace, snapshot)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack trace[d] 的下标 0 对应 k = -d
+func backtrack(trace [][]int, a, b []string) []Line {
+	x, y := len(a), len(b)
+	var res []Line
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[d+k-1] < v[d+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[d+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			res = append(res, Line{Op: OpEqual, Text: a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				res = append(res, Line{Op: OpInsert, Text: b[y]})
+			} else {
+				x--
+				res = append(res, Line{Op: OpDelete, Text: a[x]})
+			}
+		}
+	}
+	// 回溯得到的是倒序的
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
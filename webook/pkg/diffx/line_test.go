@@ -0,0 +1,117 @@
+package diffx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name string
+		from string
+		to   string
+
+		wantLines []Line
+	}{
+		{
+			name: "完全相同",
+			from: "a\nb",
+			to:   "a\nb",
+			wantLines: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpEqual, Text: "b"},
+			},
+		},
+		{
+			name: "新建",
+			from: "",
+			to:   "a\nb",
+			wantLines: []Line{
+				{Op: OpInsert, Text: "a"},
+				{Op: OpInsert, Text: "b"},
+			},
+		},
+		{
+			name: "清空",
+			from: "a\nb",
+			to:   "",
+			wantLines: []Line{
+				{Op: OpDelete, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+			},
+		},
+		{
+			name: "中间修改一行",
+			from: "a\nb\nc",
+			to:   "a\nx\nc",
+			wantLines: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpInsert, Text: "x"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name: "交错增删",
+			from: "a\nb\nc\na\nb\nb\na",
+			to:   "c\nb\na\nb\na\nc",
+			wantLines: []Line{
+				{Op: OpDelete, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpEqual, Text: "c"},
+				{Op: OpInsert, Text: "b"},
+				{Op: OpEqual, Text: "a"},
+				{Op: OpEqual, Text: "b"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpEqual, Text: "a"},
+				{Op: OpInsert, Text: "c"},
+			},
+		},
+		{
+			name: "兼容 windows 换行",
+			from: "a\r\nb",
+			to:   "a\nc",
+			wantLines: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpInsert, Text: "c"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := Lines(tc.from, tc.to)
+			assert.Equal(t, tc.wantLines, lines)
+		})
+	}
+}
+
+// TestLines_Apply 编辑脚本里面的 equal 和 delete 拼起来是旧版本，equal 和 insert 拼起来是新版本
+func TestLines_Apply(t *testing.T) {
+	testCases := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{name: "完全不同", from: "a\nb\nc", to: "x\ny"},
+		{name: "重复的行", from: "a\na\nb\na\na", to: "b\na\na\nb\nb"},
+		{name: "只有一行", from: "a", to: "b"},
+		{name: "很多处修改", from: strings.Repeat("a\nb\nc\n", 50), to: strings.Repeat("c\nb\nx\n", 40)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var from, to []string
+			for _, l := range Lines(tc.from, tc.to) {
+				if l.Op != OpInsert {
+					from = append(from, l.Text)
+				}
+				if l.Op != OpDelete {
+					to = append(to, l.Text)
+				}
+			}
+			assert.Equal(t, splitLines(tc.from), from)
+			assert.Equal(t, splitLines(tc.to), to)
+		})
+	}
+}
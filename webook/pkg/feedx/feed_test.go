@@ -0,0 +1,89 @@
+package feedx
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFeed() Feed {
+	utime := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	return Feed{
+		Title:       "大明的文章",
+		Description: "大明最新发表的文章",
+		Link:        "https://webook.com/authors/123",
+		Self:        "https://webook.com/feeds/authors/123.atom",
+		Updated:     utime,
+		Items: []Item{
+			{
+				Title:     "Go & 并发",
+				Link:      "https://webook.com/articles/1",
+				Author:    "大明",
+				Summary:   "摘要",
+				Content:   "<p>正文</p>",
+				Published: utime.Add(-time.Hour),
+				Updated:   utime,
+			},
+			{
+				Title:   "没有正文",
+				Link:    "https://webook.com/articles/2",
+				Author:  "大明",
+				Summary: "只有摘要",
+				Updated: utime.Add(-time.Hour),
+			},
+		},
+	}
+}
+
+func TestFeed_RSS(t *testing.T) {
+	data, err := testFeed().RSS()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), xml.Header))
+
+	var res rss
+	require.NoError(t, xml.Unmarshal(data, &res))
+	assert.Equal(t, "2.0", res.Version)
+	assert.Equal(t, "大明的文章", res.Channel.Title)
+	assert.Equal(t, "Sat, 02 Mar 2024 10:00:00 +0000", res.Channel.LastBuildDate)
+	require.Len(t, res.Channel.Items, 2)
+	assert.Equal(t, rssItem{
+		Title:       "Go & 并发",
+		Link:        "https://webook.com/articles/1",
+		Guid:        rssGuid{IsPermaLink: true, Value: "https://webook.com/articles/1"},
+		PubDate:     "Sat, 02 Mar 2024 09:00:00 +0000",
+		Description: "摘要",
+	}, res.Channel.Items[0])
+	// 没有发表时间就不输出
+	assert.Empty(t, res.Channel.Items[1].PubDate)
+}
+
+func TestFeed_Atom(t *testing.T) {
+	data, err := testFeed().Atom()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	// 正文里面的 HTML 要转义
+	assert.Contains(t, string(data), `<content type="html">&lt;p&gt;正文&lt;/p&gt;</content>`)
+
+	var res atomFeed
+	require.NoError(t, xml.Unmarshal(data, &res))
+	assert.Equal(t, "https://webook.com/feeds/authors/123.atom", res.Id)
+	assert.Equal(t, "2024-03-02T10:00:00Z", res.Updated)
+	assert.Equal(t, []atomLink{
+		{Href: "https://webook.com/feeds/authors/123.atom", Rel: "self"},
+		{Href: "https://webook.com/authors/123", Rel: "alternate"},
+	}, res.Links)
+	require.Len(t, res.Entries, 2)
+	entry := res.Entries[0]
+	assert.Equal(t, "https://webook.com/articles/1", entry.Id)
+	assert.Equal(t, "2024-03-02T09:00:00Z", entry.Published)
+	assert.Equal(t, "大明", entry.Author.Name)
+	assert.Equal(t, "摘要", entry.Summary)
+	require.NotNil(t, entry.Content)
+	assert.Equal(t, "<p>正文</p>", entry.Content.Value)
+	// 没有正文的时候只有摘要
+	assert.Nil(t, res.Entries[1].Content)
+	assert.Empty(t, res.Entries[1].Published)
+}
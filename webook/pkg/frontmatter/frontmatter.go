@@ -0,0 +1,65 @@
+// Package frontmatter 读写带 YAML front matter 的 Markdown 文件，形如
+//
+//	---
+//	title: 标题
+//	---
+//
+//	正文
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+const delimiter = "---"
+
+var ErrUnclosed = errors.New("front matter 没有结束的 ---")
+
+// Marshal meta 编码成 YAML 放在最前面，正文前面空一行
+func Marshal(meta any, body string) ([]byte, error) {
+	data, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(data) + len(body) + 16)
+	buf.WriteString(delimiter + "\n")
+	buf.Write(data)
+	buf.WriteString(delimiter + "\n\n")
+	buf.WriteString(body)
+	return buf.Bytes(), nil
+}
+
+// Unmarshal 返回正文。没有 front matter 的时候 meta 不变，整个文件都是正文
+func Unmarshal(data []byte, meta any) (string, error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if !strings.HasPrefix(text, delimiter+"\n") {
+		return text, nil
+	}
+	rest := text[len(delimiter)+1:]
+	var head string
+	// 结束的 --- 可能紧跟在开始的后面，也可能在文件的最后面
+	if strings.HasPrefix(rest, delimiter+"\n") || rest == delimiter {
+		rest = strings.TrimPrefix(rest, delimiter)
+	} else {
+		end := strings.Index(rest, "\n"+delimiter+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+delimiter) {
+				return "", ErrUnclosed
+			}
+			end = len(rest) - len(delimiter) - 1
+		}
+		head = rest[:end+1]
+		rest = rest[end+1+len(delimiter):]
+	}
+	if err := yaml.Unmarshal([]byte(head), meta); err != nil {
+		return "", err
+	}
+	// 去掉结束的 --- 后面的换行和 Marshal 加的空行
+	rest = strings.TrimPrefix(rest, "\n")
+	return strings.TrimPrefix(rest, "\n"), nil
+}
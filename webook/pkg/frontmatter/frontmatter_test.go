@@ -0,0 +1,83 @@
+package frontmatter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type meta struct {
+	Title string    `yaml:"title"`
+	Tags  []string  `yaml:"tags,omitempty"`
+	Ctime time.Time `yaml:"ctime"`
+}
+
+func TestMarshal(t *testing.T) {
+	ctime := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	data, err := Marshal(meta{Title: "标题: 带冒号", Tags: []string{"go"}, Ctime: ctime}, "# 正文\n\n---\n分隔线后面")
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: '标题: 带冒号'\ntags:\n    - go\nctime: 2024-03-02T10:00:00Z\n---\n\n# 正文\n\n---\n分隔线后面", string(data))
+
+	var m meta
+	body, err := Unmarshal(data, &m)
+	require.NoError(t, err)
+	assert.Equal(t, meta{Title: "标题: 带冒号", Tags: []string{"go"}, Ctime: ctime}, m)
+	assert.Equal(t, "# 正文\n\n---\n分隔线后面", body)
+}
+
+func TestUnmarshal(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+
+		wantMeta meta
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "没有 front matter",
+			data:     "# 标题\n正文",
+			wantBody: "# 标题\n正文",
+		},
+		{
+			name:     "Windows 的换行和 BOM",
+			data:     "\ufeff---\r\ntitle: 标题\r\n---\r\n正文\r\n",
+			wantMeta: meta{Title: "标题"},
+			wantBody: "正文\n",
+		},
+		{
+			name:     "空的 front matter",
+			data:     "---\n---\n正文",
+			wantBody: "正文",
+		},
+		{
+			name:     "只有 front matter",
+			data:     "---\ntitle: 标题\n---",
+			wantMeta: meta{Title: "标题"},
+		},
+		{
+			name:    "没有结束",
+			data:    "---\ntitle: 标题\n正文",
+			wantErr: true,
+		},
+		{
+			name:    "YAML 格式不对",
+			data:    "---\ntitle: [标题\n---\n正文",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var m meta
+			body, err := Unmarshal([]byte(tc.data), &m)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMeta, m)
+			assert.Equal(t, tc.wantBody, body)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ToHTML 把 markdown 渲染成 HTML，支持常用的语法：标题、段落、强调、行内代码、代码块、引用、列表、链接、图片和分割线
+// 原始 HTML 不会透传，所有文本都会转义，链接只允许 http、https、mailto 和相对地址，所以结果可以直接展示给读者
+func ToHTML(src string) string {
+	var sb strings.Builder
+	renderBlocks(&sb, splitLines(src))
+	return sb.String()
+}
+
+// PlainToHTML 纯文本按照空行分段，段落里面的换行保留成 <br>
+func PlainToHTML(src string) string {
+	var sb strings.Builder
+	for _, para := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n\n") {
+		para = strings.Trim(para, "\n")
+		if strings.TrimSpace(para) == "" {
+			continue
+		}
+		sb.WriteString("<p>")
+		sb.WriteString(strings.ReplaceAll(html.EscapeString(para), "\n", "<br>\n"))
+		sb.WriteString("</p>\n")
+	}
+	return sb.String()
+}
+
+var (
+	headingRe  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	hrRe       = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fenceRe    = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \\t]*([^`\\s]*)")
+	bulletRe   = regexp.MustCompile(`^ {0,3}([-*+])[ \t]+(.*)$`)
+	orderedRe  = regexp.MustCompile(`^ {0,3}(\d{1,9})[.)][ \t]+(.*)$`)
+	quoteRe    = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	langRe     = regexp.MustCompile(`^[a-zA-Z0-9_+#-]+$`)
+	indentCode = "    "
+)
+
+func splitLines(src string) []string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\t", "    ")
+	return strings.Split(src, "\n")
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// startsBlock 判断这一行会不会打断正在进行的段落
+func startsBlock(line string) bool {
+	return headingRe.MatchString(line) || hrRe.MatchString(line) || fenceRe.MatchString(line) ||
+		bulletRe.MatchString(line) || orderedRe.MatchString(line) || quoteRe.MatchString(line)
+}
+
+func renderBlocks(sb *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case fenceRe.MatchString(line):
+			i = renderFence(sb, lines, i)
+		case hrRe.MatchString(line):
+			sb.WriteString("<hr>\n")
+			i++
+		case headingRe.MatchString(line):
+			m := headingRe.FindStringSubmatch(line)
+			level := strconv.Itoa(len(m[1]))
+			sb.WriteString("<h" + level + ">")
+			renderInline(sb, strings.TrimSpace(m[2]))
+			sb.WriteString("</h" + level + ">\n")
+			i++
+		case quoteRe.MatchString(line):
+			i = renderQuote(sb, lines, i)
+		case bulletRe.MatchString(line):
+			i = renderList(sb, lines, i, bulletRe, "ul")
+		case orderedRe.MatchString(line):
+			i = renderList(sb, lines, i, orderedRe, "ol")
+		case strings.HasPrefix(line, indentCode):
+			i = renderIndentedCode(sb, lines, i)
+		default:
+			i = renderParagraph(sb, lines, i)
+		}
+	}
+}
+
+func renderFence(sb *strings.Builder, lines []string, i int) int {
+	m := fenceRe.FindStringSubmatch(lines[i])
+	fence, lang := m[1], m[2]
+	if lang != "" && langRe.MatchString(lang) {
+		sb.WriteString(`<pre><code class="language-` + html.EscapeString(lang) + `">`)
+	} else {
+		sb.WriteString("<pre><code>")
+	}
+	i++
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence[:3]) && strings.Trim(trimmed, fence[:1]) == "" &&
+			len(trimmed) >= len(fence) {
+			// 跳过结束的 fence
+			i++
+			break
+		}
+		sb.WriteString(html.EscapeString(lines[i]))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("</code></pre>\n")
+	return i
+}
+
+func renderIndentedCode(sb *strings.Builder, lines []string, i int) int {
+	var code []string
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], indentCode) {
+			code = append(code, lines[i][len(indentCode):])
+			continue
+		}
+		if isBlank(lines[i]) {
+			code = append(code, "")
+			continue
+		}
+		break
+	}
+	// 末尾的空行不属于代码块
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+	sb.WriteString("<pre><code>")
+	for _, line := range code {
+		sb.WriteString(html.EscapeString(line))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("</code></pre>\n")
+	return i
+}
+
+func renderQuote(sb *strings.Builder, lines []string, i int) int {
+	var inner []string
+	for ; i < len(lines); i++ {
+		m := quoteRe.FindStringSubmatch(lines[i])
+		if m == nil {
+			// 懒惰的续行，段落没有结束的时候可以省略 >
+			if isBlank(lines[i]) || startsBlock(lines[i]) || len(inner) == 0 || isBlank(inner[len(inner)-1]) {
+				break
+			}
+			inner = append(inner, lines[i])
+			continue
+		}
+		inner = append(inner, m[1])
+	}
+	sb.WriteString("<blockquote>\n")
+	renderBlocks(sb, inner)
+	sb.WriteString("</blockquote>\n")
+	return i
+}
+
+func renderList(sb *strings.Builder, lines []string, i int, itemRe *regexp.Regexp, tag string) int {
+	first := itemRe.FindStringSubmatch(lines[i])
+	if tag == "ol" && first[1] != "1" {
+		start, _ := strconv.Atoi(first[1])
+		sb.WriteString(`<ol start="` + strconv.Itoa(start) + `">` + "\n")
+	} else {
+		sb.WriteString("<" + tag + ">\n")
+	}
+	for i < len(lines) {
+		m := itemRe.FindStringSubmatch(lines[i])
+		if m == nil {
+			break
+		}
+		item := []string{m[2]}
+		i++
+		// 缩进的行属于当前的列表项，包括嵌套的列表
+		for i < len(lines) {
+			line := lines[i]
+			if strings.HasPrefix(line, "  ") && !isBlank(line) {
+				item = append(item, strings.TrimPrefix(strings.TrimPrefix(line, "  "), "  "))
+				i++
+				continue
+			}
+			if !isBlank(line) && !startsBlock(line) {
+				// 懒惰的续行
+				item = append(item, line)
+				i++
+				continue
+			}
+			break
+		}
+		sb.WriteString("<li>")
+		renderItem(sb, item)
+		sb.WriteString("</li>\n")
+		// 列表项之间允许有一个空行
+		if i+1 < len(lines) && isBlank(lines[i]) && itemRe.MatchString(lines[i+1]) {
+			i++
+		}
+	}
+	sb.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// renderItem 列表项只有一段文字的时候不包 <p>
+func renderItem(sb *strings.Builder, item []string) {
+	var inner strings.Builder
+	renderBlocks(&inner, item)
+	res := inner.String()
+	if strings.HasPrefix(res, "<p>") && strings.Count(res, "<p>") == 1 {
+		rest := strings.TrimPrefix(res, "<p>")
+		end := strings.Index(rest, "</p>\n")
+		sb.WriteString(rest[:end])
+		if tail := rest[end+len("</p>\n"):]; tail != "" {
+			sb.WriteString("\n")
+			sb.WriteString(tail)
+		}
+		return
+	}
+	sb.WriteString(res)
+}
+
+func renderParagraph(sb *strings.Builder, lines []string, i int) int {
+	var para []string
+	for ; i < len(lines); i++ {
+		if isBlank(lines[i]) || (len(para) > 0 && startsBlock(lines[i])) {
+			break
+		}
+		para = append(para, lines[i])
+	}
+	sb.WriteString("<p>")
+	renderInline(sb, strings.TrimSpace(strings.Join(para, "\n")))
+	sb.WriteString("</p>\n")
+	return i
+}
@@ -0,0 +1,274 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+)
+
+// escapable 反斜杠可以转义的字符
+const escapable = "\\`*_{}[]()#+-.!~>|<"
+
+func renderInline(sb *strings.Builder, s string) {
+	var text strings.Builder
+	flush := func() {
+		sb.WriteString(html.EscapeString(text.String()))
+		text.Reset()
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte(escapable, s[i+1]) >= 0:
+			text.WriteByte(s[i+1])
+			i += 2
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			flush()
+			sb.WriteString("<br>\n")
+			i += 2
+		case c == '\n':
+			flush()
+			// 行尾两个空格是强制换行
+			if strings.HasSuffix(sb.String(), "  ") {
+				sb.WriteString("<br>")
+			}
+			sb.WriteString("\n")
+			i++
+		case c == '`':
+			n, ok := renderCodeSpan(sb, s[i:], flush)
+			if !ok {
+				text.WriteString(s[i : i+n])
+			}
+			i += n
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if n, ok := renderLink(sb, s[i+1:], true, flush); ok {
+				i += n + 1
+				continue
+			}
+			text.WriteByte(c)
+			i++
+		case c == '[':
+			if n, ok := renderLink(sb, s[i:], false, flush); ok {
+				i += n
+				continue
+			}
+			text.WriteByte(c)
+			i++
+		case c == '<':
+			if n, ok := renderAutolink(sb, s[i:], flush); ok {
+				i += n
+				continue
+			}
+			text.WriteByte(c)
+			i++
+		case c == '_' && i > 0 && isAlnum(s[i-1]):
+			// snake_case 之类单词里面的下划线不是强调
+			text.WriteByte(c)
+			i++
+		case c == '*' || c == '_' || c == '~':
+			if n, ok := renderEmphasis(sb, s[i:], flush); ok {
+				i += n
+				continue
+			}
+			text.WriteByte(c)
+			i++
+		default:
+			text.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+}
+
+// renderCodeSpan 返回消耗的字节数，没有配对的反引号的时候原样输出
+func renderCodeSpan(sb *strings.Builder, s string, flush func()) (int, bool) {
+	n := 0
+	for n < len(s) && s[n] == '`' {
+		n++
+	}
+	ticks := s[:n]
+	for j := n; j < len(s); {
+		k := strings.Index(s[j:], ticks)
+		if k < 0 {
+			break
+		}
+		end := j + k
+		// 反引号的数量要完全一样
+		if end+n < len(s) && s[end+n] == '`' {
+			j = end + n
+			for j < len(s) && s[j] == '`' {
+				j++
+			}
+			continue
+		}
+		code := strings.ReplaceAll(s[n:end], "\n", " ")
+		if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+			code = code[1 : len(code)-1]
+		}
+		flush()
+		sb.WriteString("<code>")
+		sb.WriteString(html.EscapeString(code))
+		sb.WriteString("</code>")
+		return end + n, true
+	}
+	return n, false
+}
+
+// renderLink 处理 [text](url "title")，image 为 true 的时候处理图片，s 从 [ 开始
+func renderLink(sb *strings.Builder, s string, image bool, flush func()) (int, bool) {
+	closeText := matchBracket(s, '[', ']')
+	if closeText < 0 || closeText+1 >= len(s) || s[closeText+1] != '(' {
+		return 0, false
+	}
+	closeDest := matchBracket(s[closeText+1:], '(', ')')
+	if closeDest < 0 {
+		return 0, false
+	}
+	label := s[1:closeText]
+	dest := strings.TrimSpace(s[closeText+2 : closeText+1+closeDest])
+	var title string
+	if k := strings.IndexAny(dest, " \n"); k >= 0 {
+		title = strings.Trim(strings.TrimSpace(dest[k:]), `"'`)
+		dest = dest[:k]
+	}
+	dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+	flush()
+	url, safe := safeURL(dest)
+	if image {
+		if !safe {
+			// 不安全的图片直接展示替代文字
+			sb.WriteString(html.EscapeString(label))
+		} else {
+			sb.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(label) + `"`)
+			if title != "" {
+				sb.WriteString(` title="` + html.EscapeString(title) + `"`)
+			}
+			sb.WriteString(">")
+		}
+		return closeText + 2 + closeDest, true
+	}
+	if !safe {
+		renderInline(sb, label)
+		return closeText + 2 + closeDest, true
+	}
+	sb.WriteString(`<a href="` + html.EscapeString(url) + `"`)
+	if title != "" {
+		sb.WriteString(` title="` + html.EscapeString(title) + `"`)
+	}
+	sb.WriteString(` rel="nofollow noopener">`)
+	renderInline(sb, label)
+	sb.WriteString("</a>")
+	return closeText + 2 + closeDest, true
+}
+
+// renderAutolink 处理 <https://example.com>，其它的尖括号当成普通文本
+func renderAutolink(sb *strings.Builder, s string, flush func()) (int, bool) {
+	end := strings.IndexByte(s, '>')
+	if end < 0 {
+		return 0, false
+	}
+	dest := s[1:end]
+	if strings.ContainsAny(dest, " \n<") {
+		return 0, false
+	}
+	lower := strings.ToLower(dest)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") &&
+		!strings.HasPrefix(lower, "mailto:") {
+		return 0, false
+	}
+	flush()
+	sb.WriteString(`<a href="` + html.EscapeString(dest) + `" rel="nofollow noopener">` + html.EscapeString(dest) + "</a>")
+	return end + 1, true
+}
+
+// renderEmphasis 处理 *em*、**strong**、_em_、__strong__ 和 ~~del~~
+func renderEmphasis(sb *strings.Builder, s string, flush func()) (int, bool) {
+	c := s[0]
+	n := 0
+	for n < len(s) && s[n] == c && n < 2 {
+		n++
+	}
+	if c == '~' && n != 2 {
+		return 0, false
+	}
+	delim := s[:n]
+	// 开始的分隔符后面不能是空白
+	if n >= len(s) || s[n] == ' ' || s[n] == '\n' {
+		return 0, false
+	}
+	for j := n; j < len(s); {
+		k := strings.Index(s[j:], delim)
+		if k < 0 {
+			return 0, false
+		}
+		end := j + k
+		// 结束的分隔符前面不能是空白
+		if end > n && s[end-1] != ' ' && s[end-1] != '\n' && s[end-1] != '\\' &&
+			!(c == '_' && end+n < len(s) && isAlnum(s[end+n])) {
+			tag := "em"
+			switch {
+			case c == '~':
+				tag = "del"
+			case n == 2:
+				tag = "strong"
+			}
+			flush()
+			sb.WriteString("<" + tag + ">")
+			renderInline(sb, s[n:end])
+			sb.WriteString("</" + tag + ">")
+			return end + n, true
+		}
+		j = end + n
+	}
+	return 0, false
+}
+
+// matchBracket 找到和 s[0] 配对的右括号的位置
+func matchBracket(s string, open, close byte) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// safeURL 只允许 http、https、mailto 和相对地址，避免 javascript: 之类的 XSS
+// 地址里面的实体先解码再检查，不然 &#106;avascript: 这种写法换个渲染器就能绕过去
+func safeURL(url string) (string, bool) {
+	url = strings.TrimSpace(html.UnescapeString(url))
+	if url == "" {
+		return "", false
+	}
+	// 去掉控制字符，浏览器解析协议的时候会忽略它们
+	url = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, url)
+	colon := strings.IndexByte(url, ':')
+	if colon < 0 {
+		return url, true
+	}
+	// 冒号出现在路径、查询或者锚点里面，说明是相对地址
+	if sep := strings.IndexAny(url, "/?#"); sep >= 0 && sep < colon {
+		return url, true
+	}
+	switch strings.ToLower(url[:colon]) {
+	case "http", "https", "mailto":
+		return url, true
+	}
+	return "", false
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
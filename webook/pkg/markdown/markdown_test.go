@@ -0,0 +1,200 @@
+package markdown
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+
+		wantHTML string
+	}{
+		{
+			name:     "标题和段落",
+			src:      "# 标题 #\n\n第一行\n第二行",
+			wantHTML: "<h1>标题</h1>\n<p>第一行\n第二行</p>\n",
+		},
+		{
+			name:     "强调和行内代码",
+			src:      "**粗体** *斜体* ~~删除~~ `a<b` snake_case_name",
+			wantHTML: "<p><strong>粗体</strong> <em>斜体</em> <del>删除</del> <code>a&lt;b</code> snake_case_name</p>\n",
+		},
+		{
+			name:     "代码块",
+			src:      "```go\nfmt.Println(\"<hi>\")\n```",
+			wantHTML: "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)\n</code></pre>\n",
+		},
+		{
+			name:     "列表和嵌套列表",
+			src:      "- a\n- b\n  1. c\n  2. d",
+			wantHTML: "<ul>\n<li>a</li>\n<li>b\n<ol>\n<li>c</li>\n<li>d</li>\n</ol>\n</li>\n</ul>\n",
+		},
+		{
+			name:     "引用和分割线",
+			src:      "> 引用\n\n---",
+			wantHTML: "<blockquote>\n<p>引用</p>\n</blockquote>\n<hr>\n",
+		},
+		{
+			name:     "链接和图片",
+			src:      "[官网](https://example.com \"t\") ![图](/img/a.png)",
+			wantHTML: "<p><a href=\"https://example.com\" title=\"t\" rel=\"nofollow noopener\">官网</a> <img src=\"/img/a.png\" alt=\"图\"></p>\n",
+		},
+		{
+			name:     "原始 HTML 会被转义",
+			src:      "<script>alert(1)</script>\n<img src=x onerror=alert(1)>",
+			wantHTML: "<p>&lt;script&gt;alert(1)&lt;/script&gt;\n&lt;img src=x onerror=alert(1)&gt;</p>\n",
+		},
+		{
+			name:     "危险的链接只保留文字",
+			src:      "[点我](javascript:alert(1)) [再点](JaVaScRiPt:alert(1)) ![x](data:image/png;base64,AAA)",
+			wantHTML: "<p>点我 再点 x</p>\n",
+		},
+		{
+			name:     "属性里面的引号会被转义",
+			src:      "[a](https://example.com/\"onclick=\"alert(1))",
+			wantHTML: "<p><a href=\"https://example.com/&#34;onclick=&#34;alert(1)\" rel=\"nofollow noopener\">a</a></p>\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantHTML, ToHTML(tc.src))
+		})
+	}
+}
+
+func TestToHTML_XSS(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+
+		wantHTML string
+	}{
+		{
+			name:     "javascript 协议",
+			src:      "[x](javascript:alert(1)) [y](vbscript:msgbox(1)) [z](data:text/html;base64,PHNjcmlwdD4=)",
+			wantHTML: "<p>x y z</p>\n",
+		},
+		{
+			name:     "协议前后有空白和控制字符",
+			src:      "[x]( JAVASCRIPT:alert(1)) [y](\x01javascript:alert(1)) [z](java\x00script:alert(1))",
+			wantHTML: "<p>x y z</p>\n",
+		},
+		{
+			name:     "尖括号包起来的地址",
+			src:      "[x](<javascript:alert(1)>) <javascript:alert(1)>",
+			wantHTML: "<p>x &lt;javascript:alert(1)&gt;</p>\n",
+		},
+		{
+			name:     "实体编码的协议",
+			src:      "[x](&#106;avascript:alert(1)) [y](javascript&#58;alert(1)) [z](javascript&colon;alert(1)) ![i](&#x6A;avascript:alert(1))",
+			wantHTML: "<p>x y z i</p>\n",
+		},
+		{
+			name:     "百分号编码的冒号是相对地址",
+			src:      "[x](javascript%3Aalert(1))",
+			wantHTML: "<p><a href=\"javascript%3Aalert(1)\" rel=\"nofollow noopener\">x</a></p>\n",
+		},
+		{
+			name:     "实体解码之后是正常的地址",
+			src:      "[x](https://example.com/?a=1&amp;b=2)",
+			wantHTML: "<p><a href=\"https://example.com/?a=1&amp;b=2\" rel=\"nofollow noopener\">x</a></p>\n",
+		},
+		{
+			name:     "嵌套的链接",
+			src:      "[[a](javascript:alert(1))](https://example.com)",
+			wantHTML: "<p><a href=\"https://example.com\" rel=\"nofollow noopener\">a</a></p>\n",
+		},
+		{
+			name:     "嵌套和没有闭合的标签",
+			src:      "<scr<script>ipt>alert(1)</script>\n<div><p>没有闭合\n**<script>alert(1)",
+			wantHTML: "<p>&lt;scr&lt;script&gt;ipt&gt;alert(1)&lt;/script&gt;\n&lt;div&gt;&lt;p&gt;没有闭合\n**&lt;script&gt;alert(1)</p>\n",
+		},
+		{
+			name:     "交错的强调标签保持闭合",
+			src:      "*a **b* c**",
+			wantHTML: "<p><em>a *</em>b* c**</p>\n",
+		},
+		{
+			name:     "标题里面注入属性",
+			src:      "[x](https://example.com \"t\" onclick=\"alert(1)\")",
+			wantHTML: "<p><a href=\"https://example.com\" title=\"t&#34; onclick=&#34;alert(1)\" rel=\"nofollow noopener\">x</a></p>\n",
+		},
+		{
+			name:     "替代文字里面注入属性",
+			src:      "![a\" onerror=\"alert(1)](/a.png)",
+			wantHTML: "<p><img src=\"/a.png\" alt=\"a&#34; onerror=&#34;alert(1)\"></p>\n",
+		},
+		{
+			name:     "自动链接里面注入属性",
+			src:      "<https://example.com/\"onmouseover=\"alert(1)>",
+			wantHTML: "<p><a href=\"https://example.com/&#34;onmouseover=&#34;alert(1)\" rel=\"nofollow noopener\">https://example.com/&#34;onmouseover=&#34;alert(1)</a></p>\n",
+		},
+		{
+			name:     "代码块的语言里面注入属性",
+			src:      "```js\" onload=\"alert(1)\n<b>\n```",
+			wantHTML: "<pre><code>&lt;b&gt;\n</code></pre>\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantHTML, ToHTML(tc.src))
+		})
+	}
+}
+
+func TestPlainToHTML(t *testing.T) {
+	assert.Equal(t, "<p>第一段&lt;b&gt;<br>\n还是第一段</p>\n<p>第二段</p>\n",
+		PlainToHTML("第一段<b>\n还是第一段\n\n\n第二段"))
+}
+
+func TestText(t *testing.T) {
+	assert.Equal(t, "标题 正文 a<b 代码",
+		Text(ToHTML("# 标题\n\n**正文** `a<b`\n\n```\n代码\n```")))
+}
+
+func TestAnalyze(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+
+		wantStats Stats
+	}{
+		{
+			name:      "中文一个字算一个",
+			src:       "你好，世界！",
+			wantStats: Stats{Words: 4, CJKChars: 4},
+		},
+		{
+			name:      "英文按照单词算",
+			src:       "Don't repeat yourself, use e-mail 2 times.",
+			wantStats: Stats{Words: 7},
+		},
+		{
+			name:      "中英文混排",
+			src:       "使用 Go 语言写Web服务",
+			wantStats: Stats{Words: 9, CJKChars: 7},
+		},
+		{
+			name: "图片和代码块",
+			src:  "![图](/a.png) 和 ![](/b.png)\n\n```go\nfmt.Println(1)\n```\n\n    indented",
+			wantStats: Stats{
+				Words:      5,
+				CJKChars:   1,
+				Images:     2,
+				CodeBlocks: 2,
+			},
+		},
+		{
+			name:      "空的",
+			wantStats: Stats{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantStats, Analyze(ToHTML(tc.src)))
+		})
+	}
+}
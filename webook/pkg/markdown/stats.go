@@ -0,0 +1,54 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Stats 渲染好的 HTML 的长度统计
+type Stats struct {
+	// Words 字数，中日韩文字一个字算一个，其它语言连续的字母和数字算一个词
+	Words int
+	// CJKChars Words 里面有多少个中日韩文字，估算阅读时间的时候和单词分开算
+	CJKChars   int
+	Images     int
+	CodeBlocks int
+}
+
+// Analyze 统计 ToHTML 或者 PlainToHTML 渲染出来的 HTML，文本都是转义过的，所以标签不会数错
+func Analyze(htmlStr string) Stats {
+	words, cjk := countWords(Text(htmlStr))
+	return Stats{
+		Words:      words,
+		CJKChars:   cjk,
+		Images:     strings.Count(htmlStr, "<img "),
+		CodeBlocks: strings.Count(htmlStr, "<pre>"),
+	}
+}
+
+func countWords(text string) (words int, cjk int) {
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			// 中文之间没有空格，一个字就是一个词
+			cjk++
+			words++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			if !inWord {
+				words++
+				inWord = true
+			}
+		case inWord && (r == '\'' || r == '’' || r == '-' || r == '_'):
+			// don't、e-mail 这种算一个词
+		default:
+			inWord = false
+		}
+	}
+	return words, cjk
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,27 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Text 从渲染好的 HTML 里面提取纯文本，用来生成摘要和统计字数，连续的空白会合并成一个空格
+func Text(htmlStr string) string {
+	var sb strings.Builder
+	inTag := false
+	for i := 0; i < len(htmlStr); i++ {
+		c := htmlStr[i]
+		switch {
+		case c == '<':
+			inTag = true
+			// 标签也是分隔符，避免两个段落的文字粘在一起
+			sb.WriteByte(' ')
+		case c == '>' && inTag:
+			inTag = false
+		case !inTag:
+			sb.WriteByte(c)
+		}
+	}
+	return strings.Join(strings.FieldsFunc(html.UnescapeString(sb.String()), unicode.IsSpace), " ")
+}
@@ -0,0 +1,85 @@
+// Package rlock 基于 Redis 的分布式锁，用来保证多个实例里面只有一个在执行某个任务
+package rlock
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+var (
+	//go:embed unlock.lua
+	luaUnlock string
+	//go:embed refresh.lua
+	luaRefresh string
+)
+
+var (
+	// ErrLockHeld 锁在别人手上
+	ErrLockHeld = errors.New("rlock: 锁被别人持有")
+	// ErrLockNotHold 锁已经过期，或者被别人拿走了
+	ErrLockNotHold = errors.New("rlock: 没有持有锁")
+)
+
+type Client struct {
+	cmd redis.Cmdable
+}
+
+func NewClient(cmd redis.Cmdable) *Client {
+	return &Client{
+		cmd: cmd,
+	}
+}
+
+// TryLock 只尝试一次，拿不到返回 ErrLockHeld。expiration 要比持有锁的时间长，否则要 Refresh
+func (c *Client) TryLock(ctx context.Context, key string, expiration time.Duration) (*Lock, error) {
+	// value 用来区分是谁的锁，释放和续约的时候要校验
+	val := uuid.New().String()
+	ok, err := c.cmd.SetNX(ctx, key, val, expiration).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLockHeld
+	}
+	return &Lock{
+		cmd:        c.cmd,
+		key:        key,
+		value:      val,
+		expiration: expiration,
+	}, nil
+}
+
+type Lock struct {
+	cmd        redis.Cmdable
+	key        string
+	value      string
+	expiration time.Duration
+}
+
+// Refresh 续约，重新设置成 TryLock 时候的过期时间
+func (l *Lock) Refresh(ctx context.Context) error {
+	res, err := l.cmd.Eval(ctx, luaRefresh, []string{l.key}, l.value, l.expiration.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if res != 1 {
+		return ErrLockNotHold
+	}
+	return nil
+}
+
+// Unlock 锁已经过期或者被别人拿走的时候返回 ErrLockNotHold
+func (l *Lock) Unlock(ctx context.Context) error {
+	res, err := l.cmd.Eval(ctx, luaUnlock, []string{l.key}, l.value).Int64()
+	if err != nil {
+		return err
+	}
+	if res != 1 {
+		return ErrLockNotHold
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package rlock
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/internal/repository/cache/redismocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestClient_TryLock(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) redis.Cmdable
+
+		wantErr error
+	}{
+		{
+			name: "拿到锁",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewBoolCmd(context.Background())
+				res.SetVal(true)
+				cmd.EXPECT().SetNX(gomock.Any(), "job:ranking", gomock.Any(), time.Minute).Return(res)
+				return cmd
+			},
+		},
+		{
+			name: "锁被别人持有",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewBoolCmd(context.Background())
+				res.SetVal(false)
+				cmd.EXPECT().SetNX(gomock.Any(), "job:ranking", gomock.Any(), time.Minute).Return(res)
+				return cmd
+			},
+			wantErr: ErrLockHeld,
+		},
+		{
+			name: "Redis 错误",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewBoolCmd(context.Background())
+				res.SetErr(errors.New("网络错误"))
+				cmd.EXPECT().SetNX(gomock.Any(), "job:ranking", gomock.Any(), time.Minute).Return(res)
+				return cmd
+			},
+			wantErr: errors.New("网络错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			l, err := NewClient(tc.mock(ctrl)).TryLock(context.Background(), "job:ranking", time.Minute)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, "job:ranking", l.key)
+			assert.NotEmpty(t, l.value)
+		})
+	}
+}
+
+func TestLock_Unlock(t *testing.T) {
+	testCases := []struct {
+		name string
+		res  int64
+
+		wantErr error
+	}{
+		{
+			name: "释放成功",
+			res:  1,
+		},
+		{
+			name:    "锁已经过期或者被别人拿走了",
+			res:     0,
+			wantErr: ErrLockNotHold,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cmd := redismocks.NewMockCmdable(ctrl)
+			res := redis.NewCmd(context.Background())
+			res.SetVal(tc.res)
+			cmd.EXPECT().Eval(gomock.Any(), luaUnlock, []string{"job:ranking"}, "abc").Return(res)
+			l := &Lock{cmd: cmd, key: "job:ranking", value: "abc", expiration: time.Minute}
+			assert.Equal(t, tc.wantErr, l.Unlock(context.Background()))
+		})
+	}
+}
+
+func TestLock_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	res := redis.NewCmd(context.Background())
+	res.SetVal(int64(1))
+	cmd.EXPECT().Eval(gomock.Any(), luaRefresh, []string{"job:ranking"}, "abc", int64(60000)).Return(res)
+	l := &Lock{cmd: cmd, key: "job:ranking", value: "abc", expiration: time.Minute}
+	require.NoError(t, l.Refresh(context.Background()))
+}
@@ -0,0 +1,122 @@
+package saramax
+
+import (
+	"context"
+	"encoding/json"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/IBM/sarama"
+	"time"
+)
+
+// BatchHandler 攒够 batchSize 条消息或者等了 interval 就处理一批。
+// fn 成功之后才提交这一批的偏移量，失败了会重试 retries 次，还是失败就记日志跳过，和 Handler 一样。
+// 反序列化失败的消息在 msgs 里面，不在 events 里面，所以 fn 重试的时候要保证幂等或者整批原子
+type BatchHandler[T any] struct {
+	fn        func(msgs []*sarama.ConsumerMessage, events []T) error
+	batchSize int
+	interval  time.Duration
+	retries   int
+	l         logger.LoggerV1
+}
+
+func NewBatchHandler[T any](fn func(msgs []*sarama.ConsumerMessage, events []T) error,
+	batchSize int, interval time.Duration, l logger.LoggerV1) *BatchHandler[T] {
+	return &BatchHandler[T]{
+		fn:        fn,
+		batchSize: batchSize,
+		interval:  interval,
+		retries:   3,
+		l:         l,
+	}
+}
+
+func (h *BatchHandler[T]) Setup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *BatchHandler[T]) Cleanup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *BatchHandler[T]) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	msgs := claim.Messages()
+	for {
+		batch, events, ok := h.collect(session.Context(), msgs)
+		if !ok {
+			// 再均衡或者退出，没攒完的这一批没有提交，会被重新消费
+			return nil
+		}
+		h.handle(batch, events)
+		// 整批处理完才提交
+		for _, msg := range batch {
+			session.MarkMessage(msg, "")
+		}
+	}
+}
+
+// collect 攒一批消息，ok 是 false 代表 msgs 已经关闭或者 session 结束了，这个时候 batch 不用处理
+func (h *BatchHandler[T]) collect(ctx context.Context,
+	msgs <-chan *sarama.ConsumerMessage) (batch []*sarama.ConsumerMessage, events []T, ok bool) {
+	batch = make([]*sarama.ConsumerMessage, 0, h.batchSize)
+	events = make([]T, 0, h.batchSize)
+	// 计时从第一条消息开始，没有消息的时候不需要空转
+	var (
+		timer   *time.Timer
+		timeout <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for len(batch) < h.batchSize {
+		select {
+		case <-ctx.Done():
+			return batch, events, false
+		case <-timeout:
+			return batch, events, true
+		case msg, open := <-msgs:
+			if !open {
+				return batch, events, false
+			}
+			if timer == nil {
+				timer = time.NewTimer(h.interval)
+				timeout = timer.C
+			}
+			batch = append(batch, msg)
+			var t T
+			err := json.Unmarshal(msg.Value, &t)
+			if err != nil {
+				// 坏消息不交给 fn，但是偏移量照样提交
+				h.l.Error("反序列化对象失败",
+					logger.String("topic", msg.Topic),
+					logger.Int32("partition", msg.Partition),
+					logger.Int64("offset", msg.Offset),
+					logger.Error(err))
+				continue
+			}
+			events = append(events, t)
+		}
+	}
+	return batch, events, true
+}
+
+func (h *BatchHandler[T]) handle(batch []*sarama.ConsumerMessage, events []T) {
+	if len(events) == 0 {
+		return
+	}
+	var err error
+	for i := 0; i <= h.retries; i++ {
+		err = h.fn(batch, events)
+		if err == nil {
+			return
+		}
+	}
+	first, last := batch[0], batch[len(batch)-1]
+	h.l.Error("批量处理消息失败",
+		logger.String("topic", first.Topic),
+		logger.Int32("partition", first.Partition),
+		logger.Int64("first_offset", first.Offset),
+		logger.Int64("last_offset", last.Offset),
+		logger.Error(err))
+}
@@ -0,0 +1,136 @@
+package saramax
+
+import (
+	"context"
+	"errors"
+	"geek-basic-go/webook/pkg/logger"
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testEvent struct {
+	Id int64
+}
+
+func TestBatchHandler_ConsumeClaim(t *testing.T) {
+	testCases := []struct {
+		name string
+		// msgs 依次发送，nil 代表等一个 interval
+		msgs []*sarama.ConsumerMessage
+		fn   func(msgs []*sarama.ConsumerMessage, events []testEvent) error
+
+		wantBatches [][]testEvent
+		wantMarked  []int64
+	}{
+		{
+			name: "攒够一批",
+			msgs: []*sarama.ConsumerMessage{
+				msg(0, `{"Id":1}`), msg(1, `{"Id":2}`), msg(2, `{"Id":3}`),
+			},
+			wantBatches: [][]testEvent{{{Id: 1}, {Id: 2}}},
+			// 第三条没攒够一批，退出的时候不提交
+			wantMarked: []int64{0, 1},
+		},
+		{
+			name: "超时处理一批",
+			msgs: []*sarama.ConsumerMessage{
+				msg(0, `{"Id":1}`), nil, msg(1, `{"Id":2}`), msg(2, `{"Id":3}`),
+			},
+			wantBatches: [][]testEvent{{{Id: 1}}, {{Id: 2}, {Id: 3}}},
+			wantMarked:  []int64{0, 1, 2},
+		},
+		{
+			name: "坏消息只提交",
+			msgs: []*sarama.ConsumerMessage{
+				msg(0, `{"Id":1}`), msg(1, `abc`),
+			},
+			wantBatches: [][]testEvent{{{Id: 1}}},
+			wantMarked:  []int64{0, 1},
+		},
+		{
+			name: "一直失败记日志跳过",
+			msgs: []*sarama.ConsumerMessage{
+				msg(0, `{"Id":1}`), msg(1, `{"Id":2}`),
+			},
+			fn: func(msgs []*sarama.ConsumerMessage, events []testEvent) error {
+				return errors.New("mock db 错误")
+			},
+			// 第一次加上重试 3 次
+			wantBatches: [][]testEvent{
+				{{Id: 1}, {Id: 2}}, {{Id: 1}, {Id: 2}}, {{Id: 1}, {Id: 2}}, {{Id: 1}, {Id: 2}},
+			},
+			wantMarked: []int64{0, 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var batches [][]testEvent
+			session := &fakeSession{ctx: context.Background()}
+			h := NewBatchHandler[testEvent](func(msgs []*sarama.ConsumerMessage, events []testEvent) error {
+				batches = append(batches, events)
+				// 处理的时候还没有提交
+				for _, m := range msgs {
+					assert.NotContains(t, session.markedOffsets(), m.Offset)
+				}
+				if tc.fn != nil {
+					return tc.fn(msgs, events)
+				}
+				return nil
+			}, 2, time.Millisecond*50, logger.NewNopLogger())
+			ch := make(chan *sarama.ConsumerMessage)
+			go func() {
+				for _, m := range tc.msgs {
+					if m == nil {
+						time.Sleep(time.Millisecond * 100)
+						continue
+					}
+					ch <- m
+				}
+				close(ch)
+			}()
+			err := h.ConsumeClaim(session, &fakeClaim{msgs: ch})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantBatches, batches)
+			assert.Equal(t, tc.wantMarked, session.markedOffsets())
+		})
+	}
+}
+
+func msg(offset int64, val string) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{Topic: "test", Offset: offset, Value: []byte(val)}
+}
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	mu     sync.Mutex
+	marked []int64
+}
+
+func (s *fakeSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked = append(s.marked, msg.Offset)
+}
+
+func (s *fakeSession) markedOffsets() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.marked...)
+}
+
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	msgs chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.msgs
+}
@@ -0,0 +1,146 @@
+package searchx
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// BM25 的参数，取的是常见的默认值
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Field 文档的一个字段，Weight 越大，这个字段里面的词越重要，比如标题的权重一般比内容大
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+type Hit struct {
+	Id    int64
+	Score float64
+}
+
+// Index 内存里面的倒排索引，并发安全，使用 BM25 计算相关度
+type Index struct {
+	mu sync.RWMutex
+	// postings 词 -> 文档 -> 加权之后的词频
+	postings map[string]map[int64]float64
+	// docs 文档 -> 文档里面出现过的词，删除的时候用
+	docs     map[int64][]string
+	lens     map[int64]float64
+	totalLen float64
+}
+
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[int64]float64),
+		docs:     make(map[int64][]string),
+		lens:     make(map[int64]float64),
+	}
+}
+
+// Upsert 建立或者覆盖 id 的索引
+func (i *Index) Upsert(id int64, fields ...Field) {
+	tf := make(map[string]float64)
+	var length float64
+	for _, f := range fields {
+		for _, term := range Tokenize(f.Text) {
+			tf[term] += f.Weight
+			length += f.Weight
+		}
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.delete(id)
+	terms := make([]string, 0, len(tf))
+	for term, freq := range tf {
+		docs, ok := i.postings[term]
+		if !ok {
+			docs = make(map[int64]float64)
+			i.postings[term] = docs
+		}
+		docs[id] = freq
+		terms = append(terms, term)
+	}
+	i.docs[id] = terms
+	i.lens[id] = length
+	i.totalLen += length
+}
+
+func (i *Index) Delete(id int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.delete(id)
+}
+
+func (i *Index) delete(id int64) {
+	terms, ok := i.docs[id]
+	if !ok {
+		return
+	}
+	for _, term := range terms {
+		docs := i.postings[term]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	i.totalLen -= i.lens[id]
+	delete(i.docs, id)
+	delete(i.lens, id)
+}
+
+// Search 返回按照相关度从高到低排序的第 offset 到 offset+limit 个结果，以及命中的总数
+func (i *Index) Search(query string, offset int, limit int) ([]Hit, int) {
+	terms := Tokenize(query)
+	i.mu.RLock()
+	n := float64(len(i.docs))
+	if n == 0 {
+		i.mu.RUnlock()
+		return nil, 0
+	}
+	avgLen := i.totalLen / n
+	scores := make(map[int64]float64)
+	seen := make(map[string]struct{}, len(terms))
+	for _, term := range terms {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+		seen[term] = struct{}{}
+		docs := i.postings[term]
+		if len(docs) == 0 {
+			continue
+		}
+		df := float64(len(docs))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, freq := range docs {
+			norm := 1 - bm25B + bm25B*i.lens[id]/avgLen
+			scores[id] += idf * freq * (bm25K1 + 1) / (freq + bm25K1*norm)
+		}
+	}
+	i.mu.RUnlock()
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{Id: id, Score: score})
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		// 分数一样的时候新的文章排在前面，保证分页稳定
+		return hits[a].Id > hits[b].Id
+	})
+	total := len(hits)
+	if offset >= total {
+		return nil, total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return hits[offset:end], total
+}
@@ -0,0 +1,85 @@
+package searchx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+
+		wantTerms []string
+	}{
+		{
+			name:      "英文转小写",
+			text:      "Hello, Go-1.21!",
+			wantTerms: []string{"hello", "go", "1", "21"},
+		},
+		{
+			name:      "中文单字和二元组",
+			text:      "数据库",
+			wantTerms: []string{"数", "数据", "据", "据库", "库"},
+		},
+		{
+			name:      "中英混排",
+			text:      "学习Go语言",
+			wantTerms: []string{"学", "学习", "习", "go", "语", "语言", "言"},
+		},
+		{
+			name: "空文本",
+			text: "  ，。 ",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantTerms, Tokenize(tc.text))
+		})
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert(1, Field{Text: "Go 语言入门", Weight: 3}, Field{Text: "介绍一下语法", Weight: 1})
+	idx.Upsert(2, Field{Text: "数据库", Weight: 3}, Field{Text: "Go 连接数据库的时候要注意连接池", Weight: 1})
+	idx.Upsert(3, Field{Text: "随笔", Weight: 3}, Field{Text: "今天天气不错", Weight: 1})
+
+	// 标题命中的排在前面
+	hits, total := idx.Search("go", 0, 10)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int64{1, 2}, hitIds(hits))
+
+	hits, total = idx.Search("数据库", 0, 10)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, []int64{2}, hitIds(hits))
+
+	// 分页
+	hits, total = idx.Search("go", 1, 10)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int64{2}, hitIds(hits))
+	hits, _ = idx.Search("go", 2, 10)
+	assert.Empty(t, hits)
+
+	// 覆盖之后旧的内容搜不到了
+	idx.Upsert(3, Field{Text: "Go 随笔", Weight: 3})
+	hits, _ = idx.Search("天气", 0, 10)
+	assert.Empty(t, hits)
+	hits, _ = idx.Search("go", 0, 10)
+	assert.ElementsMatch(t, []int64{1, 2, 3}, hitIds(hits))
+
+	// 删除
+	idx.Delete(1)
+	idx.Delete(3)
+	hits, total = idx.Search("go", 0, 10)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, []int64{2}, hitIds(hits))
+}
+
+func hitIds(hits []Hit) []int64 {
+	var res []int64
+	for _, h := range hits {
+		res = append(res, h.Id)
+	}
+	return res
+}
@@ -0,0 +1,57 @@
+package searchx
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize 切分文本，用于建立索引和解析查询
+// 英文和数字按照单词切分并且转小写，中日韩文字没有分隔符，
+// 所以同时输出单字和相邻两个字组成的二元组，
+// 单字保证单个字也能搜到，二元组让连续匹配的文档排在前面
+func Tokenize(text string) []string {
+	var (
+		res  []string
+		word strings.Builder
+		// cjk 当前连续的一段中日韩文字
+		cjk []rune
+	)
+	flushWord := func() {
+		if word.Len() > 0 {
+			res = append(res, word.String())
+			word.Reset()
+		}
+	}
+	flushCJK := func() {
+		for i, r := range cjk {
+			res = append(res, string(r))
+			if i+1 < len(cjk) {
+				res = append(res, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return res
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
@@ -0,0 +1,140 @@
+package sensitive
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// Filter 找出文本里面的敏感词
+type Filter interface {
+	// Find 返回命中的敏感词，按照第一次出现的顺序，不重复，没有命中返回 nil
+	Find(text string) []string
+}
+
+// ACFilter 基于 Aho-Corasick 自动机，一次扫描就能找出所有的敏感词。
+// 英文字母不区分大小写，构建之后是只读的，可以并发使用
+type ACFilter struct {
+	root  *node
+	words []string
+}
+
+type node struct {
+	children map[rune]*node
+	fail     *node
+	// outputs 以这个节点结尾的敏感词，包括 fail 链上面的
+	outputs []int
+}
+
+func newNode() *node {
+	return &node{children: make(map[rune]*node)}
+}
+
+// NewACFilter 空白的词会被忽略，没有词的时候什么都不会命中
+func NewACFilter(words []string) *ACFilter {
+	f := &ACFilter{root: newNode()}
+	seen := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w == "" {
+			continue
+		}
+		if _, ok := seen[w]; ok {
+			continue
+		}
+		seen[w] = struct{}{}
+		f.insert(w, len(f.words))
+		f.words = append(f.words, w)
+	}
+	f.build()
+	return f
+}
+
+// LoadACFilter 从文件加载词典，一行一个词，# 开头的是注释
+func LoadACFilter(path string) (*ACFilter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewACFilter(words), nil
+}
+
+func (f *ACFilter) insert(word string, idx int) {
+	cur := f.root
+	for _, r := range word {
+		next, ok := cur.children[r]
+		if !ok {
+			next = newNode()
+			cur.children[r] = next
+		}
+		cur = next
+	}
+	cur.outputs = append(cur.outputs, idx)
+}
+
+// build 按层遍历设置 fail 指针，上一层的 fail 一定已经设置好了
+func (f *ACFilter) build() {
+	f.root.fail = f.root
+	queue := make([]*node, 0, len(f.root.children))
+	for _, child := range f.root.children {
+		child.fail = f.root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range cur.children {
+			fail := cur.fail
+			for fail != f.root && fail.children[r] == nil {
+				fail = fail.fail
+			}
+			if next, ok := fail.children[r]; ok && next != child {
+				child.fail = next
+			} else {
+				child.fail = f.root
+			}
+			child.outputs = append(child.outputs, child.fail.outputs...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+func (f *ACFilter) Find(text string) []string {
+	if len(f.words) == 0 {
+		return nil
+	}
+	var (
+		res  []string
+		seen = make(map[int]struct{})
+		cur  = f.root
+	)
+	for _, r := range strings.ToLower(text) {
+		for cur != f.root && cur.children[r] == nil {
+			cur = cur.fail
+		}
+		if next, ok := cur.children[r]; ok {
+			cur = next
+		}
+		for _, idx := range cur.outputs {
+			if _, ok := seen[idx]; ok {
+				continue
+			}
+			seen[idx] = struct{}{}
+			res = append(res, f.words[idx])
+		}
+	}
+	return res
+}
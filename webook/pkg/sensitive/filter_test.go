@@ -0,0 +1,71 @@
+package sensitive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestACFilter_Find(t *testing.T) {
+	testCases := []struct {
+		name  string
+		words []string
+		text  string
+
+		wantWords []string
+	}{
+		{
+			name:  "没有词",
+			words: nil,
+			text:  "随便什么内容",
+		},
+		{
+			name:  "没有命中",
+			words: []string{"赌博", "代开发票"},
+			text:  "今天学习 Go 的并发",
+		},
+		{
+			name:      "按照出现的顺序去重",
+			words:     []string{"赌博", "代开发票"},
+			text:      "可以代开发票，也可以赌博，还可以代开发票",
+			wantWords: []string{"代开发票", "赌博"},
+		},
+		{
+			name:      "重叠的词都能找到",
+			words:     []string{"he", "she", "his", "hers"},
+			text:      "ushers",
+			wantWords: []string{"she", "he", "hers"},
+		},
+		{
+			name:      "失配之后沿着 fail 继续匹配",
+			words:     []string{"abcd", "bce"},
+			text:      "abce",
+			wantWords: []string{"bce"},
+		},
+		{
+			name:      "英文不区分大小写",
+			words:     []string{"Spam"},
+			text:      "no SPAM please",
+			wantWords: []string{"spam"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := NewACFilter(tc.words)
+			assert.Equal(t, tc.wantWords, f.Find(tc.text))
+		})
+	}
+}
+
+func TestLoadACFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# 注释\n赌博\n\n  代开发票  \n"), 0644))
+	f, err := LoadACFilter(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"赌博", "代开发票"}, f.Find("赌博和代开发票"))
+
+	_, err = LoadACFilter(filepath.Join(t.TempDir(), "not_exist.txt"))
+	assert.Error(t, err)
+}
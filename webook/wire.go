@@ -4,6 +4,7 @@ package main
 
 import (
 	"geek-basic-go/webook/internal/events/article"
+	"geek-basic-go/webook/internal/job"
 	"geek-basic-go/webook/internal/repository"
 	"geek-basic-go/webook/internal/repository/cache"
 	"geek-basic-go/webook/internal/repository/dao"
@@ -11,6 +12,7 @@ import (
 	"geek-basic-go/webook/internal/web"
 	ijwt "geek-basic-go/webook/internal/web/jwt"
 	"geek-basic-go/webook/ioc"
+	"geek-basic-go/webook/pkg/rlock"
 	"github.com/google/wire"
 )
 
@@ -18,6 +20,9 @@ var interactiveSvcSet = wire.NewSet(
 	dao.NewGormInteractiveDao,
 	cache.NewInteractiveRedisCache,
 	repository.NewCachedInteractiveRepository,
+	dao.NewGormCollectionDao,
+	repository.NewCollectionRepository,
+	ioc.InitBizRegistry,
 	service.NewInteractiveServiceImpl,
 )
 
@@ -29,24 +34,75 @@ func InitWebServer() *App {
 		ioc.InitSyncProducer,
 		// Dao
 		dao.NewUserDao,
-		dao.NewGormDBArticleDao,
+		ioc.InitBlobStore,
+		ioc.InitArticleDao,
+		dao.NewGormArticleRevisionDao,
+		dao.NewGormArticleScheduleDao,
+		dao.NewGormArticleAssetDao,
+		dao.NewGormArticleCollaboratorDao,
+		dao.NewGormCommentDao,
+		dao.NewGormArticleReviewDao,
+		dao.NewGormArticleSeriesDao,
+		dao.NewGormArticleExportDao,
+		ioc.InitArticleSearchDao,
+		wire.Bind(new(dao.ArticleSearchDao), new(*dao.MemoryArticleSearchDao)),
+		rlock.NewClient,
 
 		interactiveSvcSet,
 		article.NewSaramaSyncProducer, article.NewInteractiveReadEventConsumer, ioc.InitConsumers,
 		// Cache
 		cache.NewUserCache /*cache.NewRedisCodeCache,*/, cache.NewGoCacheCodeCache, cache.NewArticleRedisCache,
+		cache.NewRankingRedisCache, cache.NewRankingLocalCache,
 		// repository
 		repository.NewCachedUserRepository, repository.NewCachedCodeRepository, repository.NewArticleRepository,
+		repository.NewArticleRevisionRepository,
+		repository.NewArticleScheduleRepository,
+		repository.NewArticleAssetRepository,
+		repository.NewArticleCollaboratorRepository,
+		repository.NewCachedCommentRepository,
+		repository.NewArticleReviewRepository,
+		repository.NewArticleSeriesRepository,
+		repository.NewArticleExportRepository,
+		repository.NewCachedRankingRepository,
 		// service
 		ioc.InitSmsService, service.NewUserService, service.NewCodeService, service.NewArticleService,
+		service.NewArticleAssetService,
+		service.NewArticleCollaboratorService,
+		service.NewCommentService,
+		service.NewArticleReviewService,
+		service.NewFeedService,
+		service.NewArticleSeriesService,
+		service.NewArticleBackupService,
+		service.NewBatchRankingService,
+		service.NewCollectionService,
+		ioc.InitSensitiveFilter,
 		ioc.InitWechatService,
 		// handler
 		web.NewUserHandler,
 		ijwt.NewRedisJwtHandler,
 		web.NewOAuth2WechatHandler,
 		web.NewArticleHandler,
+		web.NewArticleAssetHandler,
+		web.NewArticleCollaboratorHandler,
+		web.NewCommentHandler,
+		web.NewArticleSeriesHandler,
+		web.NewArticleBackupHandler,
+		web.NewRankingHandler,
+		web.NewCollectionHandler,
+		web.NewInteractiveHandler,
+		ioc.InitArticleReviewHandler,
+		ioc.InitFeedHandler,
 		ioc.InitGinMiddlewares,
 		ioc.InitWebServer,
+		// job
+		job.NewArticleScheduleJob,
+		job.NewArticleAssetGCJob,
+		job.NewArticleTrashPurgeJob,
+		job.NewArticleExportJob,
+		job.NewArticleSearchRefreshJob,
+		job.NewArticleReviewReconcileJob,
+		job.NewRankingJob,
+		ioc.InitJobs,
 		wire.Struct(new(App), "*"),
 	)
 	return new(App)
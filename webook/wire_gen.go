@@ -8,6 +8,7 @@ package main
 
 import (
 	"geek-basic-go/webook/internal/events/article"
+	"geek-basic-go/webook/internal/job"
 	"geek-basic-go/webook/internal/repository"
 	"geek-basic-go/webook/internal/repository/cache"
 	"geek-basic-go/webook/internal/repository/dao"
@@ -15,6 +16,7 @@ import (
 	"geek-basic-go/webook/internal/web"
 	"geek-basic-go/webook/internal/web/jwt"
 	"geek-basic-go/webook/ioc"
+	"geek-basic-go/webook/pkg/rlock"
 	"github.com/google/wire"
 )
 
@@ -41,28 +43,82 @@ func InitWebServer() *App {
 	userHandler := web.NewUserHandler(userService, codeService, handler, loggerV1)
 	wechatService := ioc.InitWechatService(loggerV1)
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
-	articleDao := dao.NewGormDBArticleDao(db)
+	blobStore := ioc.InitBlobStore()
+	articleDao := ioc.InitArticleDao(db, blobStore)
 	articleCache := cache.NewArticleRedisCache(cmdable)
-	articleRepository := repository.NewArticleRepository(articleDao, userRepository, articleCache)
+	memoryArticleSearchDao := ioc.InitArticleSearchDao(articleDao, loggerV1)
+	articleRepository := repository.NewArticleRepository(articleDao, memoryArticleSearchDao, userRepository, articleCache)
 	client := ioc.InitSaramaClient()
 	syncProducer := ioc.InitSyncProducer(client)
 	producer := article.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer)
+	articleRevisionDao := dao.NewGormArticleRevisionDao(db)
+	articleRevisionRepository := repository.NewArticleRevisionRepository(articleRevisionDao)
+	articleScheduleDao := dao.NewGormArticleScheduleDao(db)
+	articleScheduleRepository := repository.NewArticleScheduleRepository(articleScheduleDao)
 	interactiveDao := dao.NewGormInteractiveDao(db)
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDao, loggerV1, interactiveCache)
-	interactiveService := service.NewInteractiveServiceImpl(interactiveRepository)
-	articleHandler := web.NewArticleHandler(articleService, interactiveService, loggerV1)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
+	articleCollaboratorDao := dao.NewGormArticleCollaboratorDao(db)
+	articleCollaboratorRepository := repository.NewArticleCollaboratorRepository(articleCollaboratorDao)
+	articleReviewDao := dao.NewGormArticleReviewDao(db)
+	articleReviewRepository := repository.NewArticleReviewRepository(articleReviewDao)
+	articleSeriesDao := dao.NewGormArticleSeriesDao(db)
+	articleSeriesRepository := repository.NewArticleSeriesRepository(articleSeriesDao)
+	filter := ioc.InitSensitiveFilter(loggerV1)
+	articleService := service.NewArticleService(articleRepository, articleRevisionRepository, articleScheduleRepository, interactiveRepository, articleCollaboratorRepository, articleReviewRepository, articleSeriesRepository, filter, producer, loggerV1)
+	collectionDao := dao.NewGormCollectionDao(db)
+	collectionRepository := repository.NewCollectionRepository(collectionDao, interactiveCache, loggerV1)
+	commentDao := dao.NewGormCommentDao(db)
+	commentRepository := repository.NewCachedCommentRepository(commentDao, interactiveCache, loggerV1)
+	bizRegistry := ioc.InitBizRegistry(articleRepository, commentRepository, articleSeriesRepository, userRepository)
+	interactiveService := service.NewInteractiveServiceImpl(interactiveRepository, collectionRepository, bizRegistry)
+	articleCollaboratorService := service.NewArticleCollaboratorService(articleCollaboratorRepository, articleRepository, userRepository, loggerV1)
+	articleSeriesService := service.NewArticleSeriesService(articleSeriesRepository, articleRepository, userRepository, loggerV1)
+	articleHandler := web.NewArticleHandler(articleService, interactiveService, articleCollaboratorService, userService, articleSeriesService, loggerV1)
+	articleAssetDao := dao.NewGormArticleAssetDao(db)
+	articleAssetRepository := repository.NewArticleAssetRepository(articleAssetDao, blobStore)
+	articleAssetService := service.NewArticleAssetService(articleAssetRepository, articleRepository, articleCollaboratorRepository, loggerV1)
+	articleAssetHandler := web.NewArticleAssetHandler(articleAssetService, loggerV1)
+	articleCollaboratorHandler := web.NewArticleCollaboratorHandler(articleCollaboratorService, loggerV1)
+	commentService := service.NewCommentService(commentRepository, articleRepository, userRepository, loggerV1)
+	commentHandler := web.NewCommentHandler(commentService, loggerV1)
+	articleReviewService := service.NewArticleReviewService(articleReviewRepository, articleRepository, loggerV1)
+	articleReviewHandler := ioc.InitArticleReviewHandler(articleReviewService, loggerV1)
+	feedService := service.NewFeedService(articleRepository, userRepository, loggerV1)
+	feedHandler := ioc.InitFeedHandler(feedService, loggerV1)
+	articleSeriesHandler := web.NewArticleSeriesHandler(articleSeriesService, loggerV1)
+	articleExportDao := dao.NewGormArticleExportDao(db)
+	articleExportRepository := repository.NewArticleExportRepository(articleExportDao, blobStore)
+	articleBackupService := service.NewArticleBackupService(articleExportRepository, articleRepository, articleRevisionRepository, articleService, loggerV1)
+	articleBackupHandler := web.NewArticleBackupHandler(articleBackupService, loggerV1)
+	rankingRedisCache := cache.NewRankingRedisCache(cmdable)
+	rankingLocalCache := cache.NewRankingLocalCache()
+	rankingRepository := repository.NewCachedRankingRepository(rankingRedisCache, rankingLocalCache, loggerV1)
+	rankingService := service.NewBatchRankingService(articleRepository, interactiveRepository, rankingRepository, loggerV1)
+	rankingHandler := web.NewRankingHandler(rankingService, loggerV1)
+	collectionService := service.NewCollectionService(collectionRepository, articleRepository, loggerV1)
+	collectionHandler := web.NewCollectionHandler(collectionService, loggerV1)
+	interactiveHandler := web.NewInteractiveHandler(interactiveService, loggerV1)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler, articleAssetHandler, articleCollaboratorHandler, commentHandler, articleReviewHandler, feedHandler, articleSeriesHandler, articleBackupHandler, rankingHandler, collectionHandler, interactiveHandler)
 	interactiveReadEventConsumer := article.NewInteractiveReadEventConsumer(interactiveRepository, client, loggerV1)
 	v2 := ioc.InitConsumers(interactiveReadEventConsumer)
+	articleScheduleJob := job.NewArticleScheduleJob(articleService, loggerV1)
+	client2 := rlock.NewClient(cmdable)
+	articleAssetGCJob := job.NewArticleAssetGCJob(articleAssetService, client2, loggerV1)
+	articleTrashPurgeJob := job.NewArticleTrashPurgeJob(articleService, client2, loggerV1)
+	articleExportJob := job.NewArticleExportJob(articleBackupService, loggerV1)
+	articleSearchRefreshJob := job.NewArticleSearchRefreshJob(memoryArticleSearchDao, articleDao)
+	articleReviewReconcileJob := job.NewArticleReviewReconcileJob(articleService, client2, loggerV1)
+	rankingJob := job.NewRankingJob(rankingService, client2, loggerV1)
+	v3 := ioc.InitJobs(loggerV1, articleScheduleJob, articleAssetGCJob, articleTrashPurgeJob, articleExportJob, articleSearchRefreshJob, articleReviewReconcileJob, rankingJob)
 	app := &App{
 		server:    engine,
 		consumers: v2,
+		jobs:      v3,
 	}
 	return app
 }
 
 // wire.go:
 
-var interactiveSvcSet = wire.NewSet(dao.NewGormInteractiveDao, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, service.NewInteractiveServiceImpl)
+var interactiveSvcSet = wire.NewSet(dao.NewGormInteractiveDao, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, dao.NewGormCollectionDao, repository.NewCollectionRepository, ioc.InitBizRegistry, service.NewInteractiveServiceImpl)